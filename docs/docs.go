@@ -15,9 +15,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/health": {
+        "/carrier/": {
             "get": {
-                "description": "Verifica se a API está funcionando corretamente",
+                "description": "Retorna todas as transportadoras do catálogo, inclusive as inativas.",
                 "consumes": [
                     "application/json"
                 ],
@@ -25,22 +25,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "carriers"
                 ],
-                "summary": "Health check da API",
+                "summary": "Listar transportadoras",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Transportadoras cadastradas",
                         "schema": {
-                            "$ref": "#/definitions/dto.HealthCheckResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.CarrierResponse"
+                            }
                         }
                     }
                 }
-            }
-        },
-        "/package/": {
+            },
             "post": {
-                "description": "Cria um novo pacote com produto, peso e estado de destino. O sistema automaticamente mapeia o estado para a região correspondente e calcula as transportadoras disponíveis.",
+                "description": "Cadastra uma transportadora com as regiões atendidas, prazos e preços por kg. Com cotacao_url, o preço e o prazo passam a vir da API da transportadora. A transportadora passa a ser cotada imediatamente.",
                 "consumes": [
                     "application/json"
                 ],
@@ -48,33 +49,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "packages"
+                    "carriers"
                 ],
-                "summary": "Criar um novo pacote",
+                "summary": "Cadastrar transportadora",
                 "parameters": [
                     {
-                        "description": "Dados do pacote",
-                        "name": "package",
+                        "description": "Dados da transportadora",
+                        "name": "carrier",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.PackageRequest"
+                            "$ref": "#/definitions/dto.CreateCarrierRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chave privilegiada de auth.privileged_api_keys",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Pacote criado com sucesso",
+                        "description": "Transportadora cadastrada",
                         "schema": {
-                            "$ref": "#/definitions/dto.CreatePackageResponse"
+                            "$ref": "#/definitions/dto.CarrierResponse"
                         }
                     }
                 }
             }
         },
-        "/package/hire-carrier": {
-            "post": {
-                "description": "Contrata uma transportadora para realizar a entrega do pacote. O status do pacote será automaticamente alterado para 'esperando_coleta'. Transportadoras disponíveis: nebulix, rotafacil, moventra.",
+        "/carrier/{id}": {
+            "get": {
+                "description": "Retorna os dados de uma transportadora e das regiões que ela atende.",
                 "consumes": [
                     "application/json"
                 ],
@@ -82,23 +90,104 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "packages"
+                    "carriers"
                 ],
-                "summary": "Contratar transportadora",
+                "summary": "Consultar uma transportadora",
                 "parameters": [
                     {
-                        "description": "Dados para contratação",
-                        "name": "request",
+                        "type": "string",
+                        "description": "ID da transportadora",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dados da transportadora",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CarrierResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Substitui o nome, as regiões e a API de cotação da transportadora. Pacotes já contratados mantêm o preço e o prazo cotados na contratação.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "carriers"
+                ],
+                "summary": "Alterar transportadora",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da transportadora",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Novos dados da transportadora",
+                        "name": "carrier",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.HireCarrierRequest"
+                            "$ref": "#/definitions/dto.UpdateCarrierRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chave privilegiada de auth.privileged_api_keys",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Transportadora contratada com sucesso",
+                        "description": "Transportadora alterada",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CarrierResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a transportadora do catálogo. Para suspender temporariamente, prefira desativá-la. Pacotes já contratados não são afetados.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "carriers"
+                ],
+                "summary": "Excluir transportadora",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da transportadora",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chave privilegiada de auth.privileged_api_keys",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transportadora excluída",
                         "schema": {
                             "$ref": "#/definitions/dto.SuccessResponse"
                         }
@@ -106,9 +195,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/package/status": {
-            "put": {
-                "description": "Atualiza o status de um pacote específico. Status válidos: criado, esperando_coleta, coletado, enviado, entregue, extraviado.",
+        "/carrier/{id}/activate": {
+            "post": {
+                "description": "Volta a cotar e permitir a contratação da transportadora.",
                 "consumes": [
                     "application/json"
                 ],
@@ -116,33 +205,77 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "packages"
+                    "carriers"
                 ],
-                "summary": "Atualizar status de um pacote",
+                "summary": "Ativar transportadora",
                 "parameters": [
                     {
-                        "description": "Dados para atualização de status",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                        "type": "string",
+                        "description": "ID da transportadora",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chave privilegiada de auth.privileged_api_keys",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transportadora ativada",
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateStatusRequest"
+                            "$ref": "#/definitions/dto.CarrierResponse"
                         }
                     }
+                }
+            }
+        },
+        "/carrier/{id}/deactivate": {
+            "post": {
+                "description": "Mantém a transportadora no catálogo, mas deixa de cotá-la e de permitir novas contratações. Pacotes já contratados não são afetados.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "carriers"
+                ],
+                "summary": "Desativar transportadora",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da transportadora",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chave privilegiada de auth.privileged_api_keys",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Status atualizado com sucesso",
+                        "description": "Transportadora desativada",
                         "schema": {
-                            "$ref": "#/definitions/dto.SuccessResponse"
+                            "$ref": "#/definitions/dto.CarrierResponse"
                         }
                     }
                 }
             }
         },
-        "/package/{id}": {
+        "/carrier/{id}/metrics": {
             "get": {
-                "description": "Retorna os dados completos de um pacote pelo ID, incluindo informações de entrega se uma transportadora foi contratada.",
+                "description": "Calcula as métricas de uma transportadora pelo histórico de status dos pacotes contratados com ela. Transportadoras excluídas do catálogo continuam consultáveis enquanto tiverem pacotes no período.",
                 "consumes": [
                     "application/json"
                 ],
@@ -150,31 +283,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "packages"
+                    "carriers"
                 ],
-                "summary": "Consultar um pacote específico",
+                "summary": "Métricas de desempenho de uma transportadora",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID único do pacote",
+                        "example": "nebulix",
+                        "description": "ID da transportadora",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "sul",
+                        "description": "Região de destino",
+                        "name": "regiao_destino",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pacotes criados a partir de (AAAA-MM-DD ou RFC3339)",
+                        "name": "criado_de",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pacotes criados até (AAAA-MM-DD inclui o dia inteiro)",
+                        "name": "criado_ate",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Dados do pacote",
+                        "description": "Métricas da transportadora",
                         "schema": {
-                            "$ref": "#/definitions/dto.PackageResponse"
+                            "$ref": "#/definitions/dto.CarrierMetricsResponse"
                         }
                     }
                 }
             }
         },
-        "/package/{id}/quote": {
-            "post": {
-                "description": "Retorna cotações de frete disponíveis para um pacote, ordenadas por prazo de entrega. Inclui preços e prazos estimados de todas as transportadoras que atendem a região do pacote.",
+        "/carrier/{id}/regions": {
+            "get": {
+                "description": "Retorna as regiões atendidas pela transportadora com prazo e preço por kg.",
                 "consumes": [
                     "application/json"
                 ],
@@ -182,13 +335,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "packages"
+                    "carriers"
                 ],
-                "summary": "Cotação de fretes",
+                "summary": "Listar regiões da transportadora",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID do pacote",
+                        "description": "ID da transportadora",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -196,83 +349,1689 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Cotações de frete disponíveis",
+                        "description": "Regiões atendidas",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/dto.ShippingQuoteResponse"
+                                "$ref": "#/definitions/dto.CarrierRegionResponse"
                             }
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "dto.CreatePackageResponse": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
-                },
-                "message": {
-                    "type": "string",
-                    "example": "Package created successfully"
-                }
-            }
-        },
-        "dto.HealthCheckResponse": {
-            "description": "Resposta simples de status da API",
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "OK!"
+            },
+            "post": {
+                "description": "Passa a atender uma nova região. Regiões já atendidas retornam 409.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "carriers"
+                ],
+                "summary": "Adicionar região à transportadora",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da transportadora",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Região, prazo e preço por kg",
+                        "name": "region",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CarrierRegionRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chave privilegiada de auth.privileged_api_keys",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transportadora alterada",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CarrierResponse"
+                        }
+                    }
                 }
             }
         },
-        "dto.HireCarrierRequest": {
-            "description": "Dados necessários para contratar uma transportadora",
-            "type": "object",
-            "required": [
-                "carrier_id",
-                "package_id"
-            ],
-            "properties": {
-                "carrier_id": {
-                    "type": "string",
-                    "example": "nebulix"
-                },
-                "package_id": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+        "/carrier/{id}/regions/{region}": {
+            "put": {
+                "description": "Altera o prazo e o preço por kg de uma região atendida. Pacotes já contratados mantêm o preço e o prazo cotados na contratação.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "carriers"
+                ],
+                "summary": "Alterar região da transportadora",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da transportadora",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "norte",
+                            "nordeste",
+                            "centro-oeste",
+                            "sudeste",
+                            "sul"
+                        ],
+                        "type": "string",
+                        "description": "Região",
+                        "name": "region",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Novo prazo e preço por kg",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateCarrierRegionRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chave privilegiada de auth.privileged_api_keys",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transportadora alterada",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CarrierResponse"
+                        }
+                    }
                 }
-            }
-        },
-        "dto.PackageRequest": {
-            "description": "Dados necessários para criar um novo pacote",
+            },
+            "delete": {
+                "description": "Deixa de atender uma região. A transportadora precisa manter ao menos uma região; para suspendê-la por completo, desative-a.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "carriers"
+                ],
+                "summary": "Remover região da transportadora",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da transportadora",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "norte",
+                            "nordeste",
+                            "centro-oeste",
+                            "sudeste",
+                            "sul"
+                        ],
+                        "type": "string",
+                        "description": "Região",
+                        "name": "region",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chave privilegiada de auth.privileged_api_keys",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transportadora alterada",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CarrierResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Verifica se a API está funcionando corretamente",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Health check da API",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HealthCheckResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/package/": {
+            "get": {
+                "description": "Lista pacotes com filtros opcionais, ordenação e paginação por cursor. Para a próxima página, repita a consulta com o proximo_cursor retornado.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "packages"
+                ],
+                "summary": "Listar pacotes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "enviado,entregue",
+                        "description": "Status separados por vírgula",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "sul",
+                        "description": "Região de destino",
+                        "name": "regiao_destino",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "PR",
+                        "description": "UF de destino",
+                        "name": "estado_destino",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "nebulix",
+                        "description": "ID da transportadora contratada",
+                        "name": "transportadora_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Criados a partir de (AAAA-MM-DD ou RFC3339)",
+                        "name": "criado_de",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Criados até (AAAA-MM-DD inclui o dia inteiro)",
+                        "name": "criado_ate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Atualizados a partir de (AAAA-MM-DD ou RFC3339)",
+                        "name": "atualizado_de",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Atualizados até (AAAA-MM-DD inclui o dia inteiro)",
+                        "name": "atualizado_ate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Peso mínimo em kg",
+                        "name": "peso_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Peso máximo em kg",
+                        "name": "peso_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-criado_em",
+                        "description": "criado_em, atualizado_em ou peso_kg; prefixo - para decrescente",
+                        "name": "ordenar",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Itens por página (máximo 100)",
+                        "name": "limite",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor retornado pela página anterior",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chave privilegiada; sem ela, os dados pessoais do destinatário e do remetente vêm mascarados",
+                        "name": "X-API-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Página de pacotes",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PackageListResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Cria um novo pacote com produto, peso, destinatário e, opcionalmente, remetente. O estado e o CEP de destino vêm do endereço do destinatário quando não informados. O sistema automaticamente mapeia o estado para a região correspondente e calcula as transportadoras disponíveis. As dimensões são opcionais; quando informadas, as transportadoras cobram pelo maior entre o peso real e o peso cúbico. Com politica_contratacao, o frete é cotado e contratado na mesma chamada e retornado em entrega; se nenhuma transportadora atender a política, o pacote não é criado (422).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "packages"
+                ],
+                "summary": "Criar um novo pacote",
+                "parameters": [
+                    {
+                        "description": "Dados do pacote",
+                        "name": "package",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PackageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Pacote criado com sucesso",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreatePackageResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/package/hire-carrier": {
+            "post": {
+                "description": "Contrata uma transportadora para realizar a entrega do pacote. O status do pacote será automaticamente alterado para 'esperando_coleta'. Com quote_id, o frete é contratado pelo preço e prazo da cotação, que é rejeitada com 410 depois de expirar. Com carrier_id, a transportadora é cotada novamente no momento da contratação. O pacote precisa ter destinatário; pacotes criados sem ele o recebem em destinatario.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "packages"
+                ],
+                "summary": "Contratar transportadora",
+                "parameters": [
+                    {
+                        "description": "Dados para contratação",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.HireCarrierRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag do pacote lido; rejeita com 412 se o pacote mudou",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transportadora contratada com sucesso",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SuccessResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Nova versão do pacote"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/package/status": {
+            "put": {
+                "description": "Atualiza o status de um pacote específico respeitando o fluxo criado → esperando_coleta → coletado → enviado → entregue/extraviado. Transições fora do fluxo retornam 409 com a lista de próximos status permitidos.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "packages"
+                ],
+                "summary": "Atualizar status de um pacote",
+                "parameters": [
+                    {
+                        "description": "Dados para atualização de status",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateStatusRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag do pacote lido; rejeita com 412 se o pacote mudou",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Status atualizado com sucesso",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SuccessResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Nova versão do pacote"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/package/{id}": {
+            "get": {
+                "description": "Retorna os dados completos de um pacote pelo ID, incluindo informações de entrega se uma transportadora foi contratada.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "packages"
+                ],
+                "summary": "Consultar um pacote específico",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID único do pacote",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chave privilegiada; sem ela, os dados pessoais do destinatário e do remetente vêm mascarados",
+                        "name": "X-API-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dados do pacote",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PackageResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versão atual do pacote, para uso no If-Match"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/package/{id}/history": {
+            "get": {
+                "description": "Retorna a linha do tempo de status do pacote, do mais antigo ao mais recente, com data, ator e observação de cada mudança.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "packages"
+                ],
+                "summary": "Consultar histórico de status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID único do pacote",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Histórico de status",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.StatusEventResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/package/{id}/quote": {
+            "post": {
+                "description": "Cota o pacote em paralelo com todas as transportadoras ativas que atendem a região. As cotações vêm ordenadas conforme sort: fastest (menor prazo, padrão), cheapest (menor preço), best_value (custo-benefício entre preço e prazo) ou reliable (maior confiabilidade pelo histórico de entregas, depois menor prazo); empates são desfeitos pelo menor preço e pelo ID da transportadora. Cada cotação é registrada com um ID e pode ser contratada pelo preço cotado até valida_ate. Transportadoras que falham ou não respondem a tempo aparecem em falhas com o motivo, sem impedir as cotações das demais.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "packages"
+                ],
+                "summary": "Cotação de fretes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do pacote",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "fastest",
+                            "cheapest",
+                            "best_value",
+                            "reliable"
+                        ],
+                        "type": "string",
+                        "default": "fastest",
+                        "description": "Ordenação das cotações",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cotações de frete e falhas por transportadora",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ShippingQuotesResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/package/{id}/transitions": {
+            "get": {
+                "description": "Retorna o status atual do pacote e os próximos status permitidos. Fluxo: criado → esperando_coleta → coletado → enviado → entregue/extraviado. Os status entregue e extraviado são terminais.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "packages"
+                ],
+                "summary": "Consultar transições de status permitidas",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID único do pacote",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transições permitidas",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PackageTransitionsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/carriers": {
+            "get": {
+                "description": "Calcula, pelo histórico de status dos pacotes contratados, a taxa de entregas no prazo, a média de dias prometidos e reais, a taxa de extravio e o volume por região de cada transportadora. A confiabilidade, de 0 a 1, é a nota usada na ordenação reliable das cotações.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Métricas de desempenho das transportadoras",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "sul",
+                        "description": "Região de destino",
+                        "name": "regiao_destino",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pacotes criados a partir de (AAAA-MM-DD ou RFC3339)",
+                        "name": "criado_de",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pacotes criados até (AAAA-MM-DD inclui o dia inteiro)",
+                        "name": "criado_ate",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Métricas das transportadoras",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CarrierReportResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/late": {
+            "get": {
+                "description": "Lista os pacotes marcados como atrasados, agrupados por transportadora e região, para a cobrança de multas. Um pacote é marcado pela verificação periódica quando passa da data de entrega prometida sem ter sido entregue, e continua no relatório depois da entrega ou do extravio. Os dias de atraso contam até a entrega, o extravio ou o momento da consulta.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Relatório de entregas atrasadas",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "nebulix",
+                        "description": "ID da transportadora",
+                        "name": "transportadora_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "sul",
+                        "description": "Região de destino",
+                        "name": "regiao_destino",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pacotes criados a partir de (AAAA-MM-DD ou RFC3339)",
+                        "name": "criado_de",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pacotes criados até (AAAA-MM-DD inclui o dia inteiro)",
+                        "name": "criado_ate",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Entregas atrasadas",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LateReportResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouse/": {
+            "get": {
+                "description": "Retorna os armazéns configurados em warehouses, que podem ser informados como origem na criação de pacotes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouses"
+                ],
+                "summary": "Listar armazéns",
+                "responses": {
+                    "200": {
+                        "description": "Armazéns de origem",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.WarehouseResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouse/{id}": {
+            "get": {
+                "description": "Retorna os dados de um armazém de origem.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouses"
+                ],
+                "summary": "Consultar um armazém",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "curitiba",
+                        "description": "ID do armazém",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dados do armazém",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WarehouseResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.AddressRequest": {
+            "description": "Endereço de entrega ou de coleta. Use S/N no número de endereços sem número.",
+            "type": "object",
+            "required": [
+                "bairro",
+                "cep",
+                "cidade",
+                "logradouro",
+                "numero"
+            ],
+            "properties": {
+                "bairro": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Centro"
+                },
+                "cep": {
+                    "type": "string",
+                    "example": "80010-000"
+                },
+                "cidade": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Curitiba"
+                },
+                "complemento": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Apto 12"
+                },
+                "logradouro": {
+                    "type": "string",
+                    "maxLength": 150,
+                    "example": "Rua XV de Novembro"
+                },
+                "numero": {
+                    "type": "string",
+                    "maxLength": 10,
+                    "example": "1000"
+                }
+            }
+        },
+        "dto.AddressResponse": {
+            "description": "Endereço de entrega ou de coleta, mascarado com *** nos campos ocultos",
+            "type": "object",
+            "properties": {
+                "bairro": {
+                    "type": "string",
+                    "example": "Centro"
+                },
+                "cep": {
+                    "type": "string",
+                    "example": "80010-***"
+                },
+                "cidade": {
+                    "type": "string",
+                    "example": "Curitiba"
+                },
+                "complemento": {
+                    "type": "string",
+                    "example": "***"
+                },
+                "logradouro": {
+                    "type": "string",
+                    "example": "***"
+                },
+                "numero": {
+                    "type": "string",
+                    "example": "***"
+                }
+            }
+        },
+        "dto.CEPRangeRequest": {
+            "description": "Faixa de CEPs da região, como capital, interior ou área remota. A primeira faixa que contém o CEP de destino vale: nao_atendida exclui a faixa da cobertura, e o prazo e os preços informados substituem os da região.",
+            "type": "object",
+            "required": [
+                "cep_fim",
+                "cep_inicio",
+                "nome"
+            ],
+            "properties": {
+                "cep_fim": {
+                    "type": "string",
+                    "example": "82999-999"
+                },
+                "cep_inicio": {
+                    "type": "string",
+                    "example": "80000-000"
+                },
+                "faixas_peso": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WeightBandRequest"
+                    }
+                },
+                "nao_atendida": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "nome": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "capital"
+                },
+                "prazo_estimado_dias": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 2
+                },
+                "preco_por_kg": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 4.9
+                },
+                "valor_minimo": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 9.9
+                }
+            }
+        },
+        "dto.CEPRangeResponse": {
+            "description": "Faixa de CEPs da região; prazo e preços omitidos seguem os da região",
+            "type": "object",
+            "properties": {
+                "cep_fim": {
+                    "type": "string",
+                    "example": "82999-999"
+                },
+                "cep_inicio": {
+                    "type": "string",
+                    "example": "80000-000"
+                },
+                "faixas_peso": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WeightBandResponse"
+                    }
+                },
+                "nao_atendida": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "nome": {
+                    "type": "string",
+                    "example": "capital"
+                },
+                "prazo_estimado_dias": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "preco_por_kg": {
+                    "type": "number",
+                    "example": 4.9
+                },
+                "valor_minimo": {
+                    "type": "number",
+                    "example": 9.9
+                }
+            }
+        },
+        "dto.CarrierMetricsResponse": {
+            "description": "Desempenho calculado pelo histórico de status dos pacotes contratados. As taxas vão de 0 a 1 e os dias são corridos, da contratação à data prometida ou à entrega.",
+            "type": "object",
+            "properties": {
+                "confiabilidade": {
+                    "type": "number",
+                    "example": 0.8235
+                },
+                "em_transito": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "entregues": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "entregues_no_prazo": {
+                    "type": "integer",
+                    "example": 27
+                },
+                "extraviados": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "media_dias_prometidos": {
+                    "type": "number",
+                    "example": 5.2
+                },
+                "media_dias_reais": {
+                    "type": "number",
+                    "example": 4.8
+                },
+                "pacotes_contratados": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "taxa_extravio": {
+                    "type": "number",
+                    "example": 0.0625
+                },
+                "taxa_no_prazo": {
+                    "type": "number",
+                    "example": 0.9
+                },
+                "transportadora": {
+                    "type": "string",
+                    "example": "Nebulix Logística"
+                },
+                "transportadora_id": {
+                    "type": "string",
+                    "example": "nebulix"
+                },
+                "volume_por_regiao": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RegionVolumeResponse"
+                    }
+                }
+            }
+        },
+        "dto.CarrierRegionRequest": {
+            "description": "Prazo e preço da transportadora em uma região: preço por kg ou faixas de peso, valor mínimo e fator de cubagem (kg/m³, 0 cobra só o peso real). Pacotes com valor declarado pagam o seguro ad valorem, o percentual do valor declarado com no mínimo o seguro mínimo; as taxas adicionais são cobradas em todo pacote.",
+            "type": "object",
+            "required": [
+                "regiao"
+            ],
+            "properties": {
+                "ad_valorem_percentual": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 0.5
+                },
+                "faixas_cep": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CEPRangeRequest"
+                    }
+                },
+                "faixas_peso": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WeightBandRequest"
+                    }
+                },
+                "fator_cubagem": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 300
+                },
+                "origens": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "$ref": "#/definitions/dto.OriginRateRequest"
+                    }
+                },
+                "prazo_estimado_dias": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "preco_por_kg": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 5.9
+                },
+                "regiao": {
+                    "type": "string",
+                    "enum": [
+                        "norte",
+                        "nordeste",
+                        "centro-oeste",
+                        "sudeste",
+                        "sul"
+                    ],
+                    "example": "sul"
+                },
+                "seguro_minimo": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 2
+                },
+                "taxas_adicionais": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "$ref": "#/definitions/dto.SurchargeRequest"
+                    }
+                },
+                "valor_minimo": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 12.9
+                }
+            }
+        },
+        "dto.CarrierRegionResponse": {
+            "description": "Prazo, tabela de preço, fator de cubagem, seguro ad valorem e taxas adicionais da transportadora em uma região",
+            "type": "object",
+            "properties": {
+                "ad_valorem_percentual": {
+                    "type": "number",
+                    "example": 0.5
+                },
+                "faixas_cep": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CEPRangeResponse"
+                    }
+                },
+                "faixas_peso": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WeightBandResponse"
+                    }
+                },
+                "fator_cubagem": {
+                    "type": "number",
+                    "example": 300
+                },
+                "origens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OriginRateResponse"
+                    }
+                },
+                "prazo_estimado_dias": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "preco_por_kg": {
+                    "type": "number",
+                    "example": 5.9
+                },
+                "regiao": {
+                    "type": "string",
+                    "example": "sul"
+                },
+                "seguro_minimo": {
+                    "type": "number",
+                    "example": 2
+                },
+                "taxas_adicionais": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SurchargeResponse"
+                    }
+                },
+                "valor_minimo": {
+                    "type": "number",
+                    "example": 12.9
+                }
+            }
+        },
+        "dto.CarrierReportResponse": {
+            "description": "Métricas de desempenho das transportadoras, ordenadas pelo ID",
+            "type": "object",
+            "properties": {
+                "transportadoras": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CarrierMetricsResponse"
+                    }
+                }
+            }
+        },
+        "dto.CarrierResponse": {
+            "description": "Dados de uma transportadora do catálogo",
+            "type": "object",
+            "properties": {
+                "ativa": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "cotacao_url": {
+                    "type": "string",
+                    "example": "https://api.nebulix.com.br/v1/cotacao"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "nebulix"
+                },
+                "nome": {
+                    "type": "string",
+                    "example": "Nebulix Logística"
+                },
+                "peso_maximo_kg": {
+                    "type": "number",
+                    "example": 100
+                },
+                "regioes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CarrierRegionResponse"
+                    }
+                }
+            }
+        },
+        "dto.ContactRequest": {
+            "description": "Quem recebe ou envia o pacote. O documento é um CPF ou um CNPJ, com ou sem pontuação, e tem os dígitos verificadores conferidos; o telefone é opcional e leva o DDD.",
+            "type": "object",
+            "required": [
+                "documento",
+                "nome"
+            ],
+            "properties": {
+                "documento": {
+                    "type": "string",
+                    "example": "529.982.247-25"
+                },
+                "endereco": {
+                    "$ref": "#/definitions/dto.AddressRequest"
+                },
+                "nome": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "Maria da Silva"
+                },
+                "telefone": {
+                    "type": "string",
+                    "example": "(41) 99876-5432"
+                }
+            }
+        },
+        "dto.ContactResponse": {
+            "description": "Quem recebe ou envia o pacote. Sem uma chave privilegiada no header X-API-Key, os dados de pessoas físicas vêm mascarados: o primeiro nome com as iniciais dos sobrenomes, o CPF sem os três primeiros dígitos e os verificadores, o telefone só com o DDD e os quatro últimos dígitos e o endereço só com o bairro, a cidade e o início do CEP.",
+            "type": "object",
+            "properties": {
+                "documento": {
+                    "type": "string",
+                    "example": "***.982.247-**"
+                },
+                "endereco": {
+                    "$ref": "#/definitions/dto.AddressResponse"
+                },
+                "mascarado": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "nome": {
+                    "type": "string",
+                    "example": "Maria S."
+                },
+                "telefone": {
+                    "type": "string",
+                    "example": "(41) *****-5432"
+                },
+                "tipo_documento": {
+                    "type": "string",
+                    "example": "cpf"
+                }
+            }
+        },
+        "dto.CreateCarrierRequest": {
+            "description": "Dados necessários para cadastrar uma transportadora",
+            "type": "object",
+            "required": [
+                "id",
+                "nome",
+                "regioes"
+            ],
+            "properties": {
+                "cotacao_url": {
+                    "type": "string",
+                    "example": "https://api.nebulix.com.br/v1/cotacao"
+                },
+                "id": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 2,
+                    "example": "nebulix"
+                },
+                "nome": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "Nebulix Logística"
+                },
+                "peso_maximo_kg": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 100
+                },
+                "regioes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "$ref": "#/definitions/dto.CarrierRegionRequest"
+                    }
+                }
+            }
+        },
+        "dto.CreatePackageResponse": {
+            "type": "object",
+            "properties": {
+                "entrega": {
+                    "description": "Entrega é o frete contratado pela política de contratação, se informada",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.ShippingQuoteResponse"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Package created successfully"
+                }
+            }
+        },
+        "dto.DimensionsRequest": {
+            "description": "Medidas da embalagem em centímetros, usadas no cálculo do peso cúbico",
+            "type": "object",
+            "required": [
+                "altura_cm",
+                "comprimento_cm",
+                "largura_cm"
+            ],
+            "properties": {
+                "altura_cm": {
+                    "type": "number",
+                    "maximum": 500,
+                    "example": 20
+                },
+                "comprimento_cm": {
+                    "type": "number",
+                    "maximum": 500,
+                    "example": 40
+                },
+                "largura_cm": {
+                    "type": "number",
+                    "maximum": 500,
+                    "example": 30
+                }
+            }
+        },
+        "dto.DimensionsResponse": {
+            "description": "Medidas da embalagem em centímetros",
+            "type": "object",
+            "properties": {
+                "altura_cm": {
+                    "type": "number",
+                    "example": 20
+                },
+                "comprimento_cm": {
+                    "type": "number",
+                    "example": 40
+                },
+                "largura_cm": {
+                    "type": "number",
+                    "example": 30
+                }
+            }
+        },
+        "dto.HealthCheckResponse": {
+            "description": "Resposta simples de status da API",
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "OK!"
+                }
+            }
+        },
+        "dto.HireCarrierRequest": {
+            "description": "Dados necessários para contratar uma transportadora: quote_id contrata pelo preço cotado e carrier_id cota novamente. O destinatário só é aceito, e é obrigatório, para pacotes criados sem ele.",
+            "type": "object",
+            "required": [
+                "package_id"
+            ],
+            "properties": {
+                "ator": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "operador.joao"
+                },
+                "carrier_id": {
+                    "type": "string",
+                    "example": "nebulix"
+                },
+                "destinatario": {
+                    "$ref": "#/definitions/dto.ContactRequest"
+                },
+                "package_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "quote_id": {
+                    "type": "string",
+                    "example": "9b2f4c1e-7d3a-4e8b-a1c5-2f6d8e0b3a7c"
+                }
+            }
+        },
+        "dto.HirePolicyRequest": {
+            "description": "Política de contratação automática: cheapest (mais barato), fastest (mais rápido), max_days (mais barato com prazo até prazo_maximo_dias) ou max_price (mais rápido com preço até preco_maximo)",
+            "type": "object",
+            "required": [
+                "tipo"
+            ],
+            "properties": {
+                "prazo_maximo_dias": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 5
+                },
+                "preco_maximo": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 50
+                },
+                "tipo": {
+                    "type": "string",
+                    "enum": [
+                        "cheapest",
+                        "fastest",
+                        "max_days",
+                        "max_price"
+                    ],
+                    "example": "max_days"
+                }
+            }
+        },
+        "dto.ItemRequest": {
+            "description": "Mercadoria do pacote, com os dados da declaração de conteúdo e da nota fiscal. O SKU e o NCM são opcionais; o NCM aceita o código com ou sem pontos.",
+            "type": "object",
+            "required": [
+                "descricao",
+                "peso_unitario_kg",
+                "quantidade",
+                "valor_unitario"
+            ],
+            "properties": {
+                "descricao": {
+                    "type": "string",
+                    "maxLength": 120,
+                    "minLength": 2,
+                    "example": "Camisa azul tamanho G"
+                },
+                "ncm": {
+                    "type": "string",
+                    "example": "6205.20.00"
+                },
+                "peso_unitario_kg": {
+                    "type": "number",
+                    "maximum": 1000,
+                    "example": 0.3
+                },
+                "quantidade": {
+                    "type": "integer",
+                    "maximum": 10000,
+                    "example": 2
+                },
+                "sku": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "CAM-G-AZUL"
+                },
+                "valor_unitario": {
+                    "type": "number",
+                    "example": 89.9
+                }
+            }
+        },
+        "dto.ItemResponse": {
+            "description": "Mercadoria do pacote com o peso e o valor de todas as unidades",
+            "type": "object",
+            "properties": {
+                "descricao": {
+                    "type": "string",
+                    "example": "Camisa azul tamanho G"
+                },
+                "ncm": {
+                    "type": "string",
+                    "example": "6205.20.00"
+                },
+                "peso_total_kg": {
+                    "type": "number",
+                    "example": 0.6
+                },
+                "peso_unitario_kg": {
+                    "type": "number",
+                    "example": 0.3
+                },
+                "quantidade": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "CAM-G-AZUL"
+                },
+                "valor_total": {
+                    "type": "number",
+                    "example": 179.8
+                },
+                "valor_unitario": {
+                    "type": "number",
+                    "example": 89.9
+                }
+            }
+        },
+        "dto.LateDeliveryGroupResponse": {
+            "description": "Pacotes atrasados de uma transportadora em uma região, os mais atrasados primeiro",
+            "type": "object",
+            "properties": {
+                "pacotes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LatePackageResponse"
+                    }
+                },
+                "quantidade_pacotes": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "regiao_destino": {
+                    "type": "string",
+                    "example": "sul"
+                },
+                "total_dias_atraso": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "transportadora": {
+                    "type": "string",
+                    "example": "Nebulix Logística"
+                },
+                "transportadora_id": {
+                    "type": "string",
+                    "example": "nebulix"
+                }
+            }
+        },
+        "dto.LatePackageResponse": {
+            "description": "Pacote atrasado com a data prometida, quando foi marcado e os dias de atraso até a entrega ou até agora",
+            "type": "object",
+            "properties": {
+                "atrasado_em": {
+                    "type": "string",
+                    "example": "2025-01-22T00:05:00Z"
+                },
+                "data_entrega_estimada": {
+                    "type": "string",
+                    "example": "2025-01-21"
+                },
+                "dias_atraso": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "estado_destino": {
+                    "type": "string",
+                    "example": "PR"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "prazo_estimado_dias": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "status": {
+                    "type": "string",
+                    "example": "entregue"
+                }
+            }
+        },
+        "dto.LateReportResponse": {
+            "description": "Pacotes que passaram da data de entrega prometida, agrupados por transportadora e região",
+            "type": "object",
+            "properties": {
+                "grupos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LateDeliveryGroupResponse"
+                    }
+                },
+                "total_pacotes": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "dto.OriginRateRequest": {
+            "description": "Prazo e preço para pacotes despachados de armazéns da região de origem: nao_atendida exclui a origem da cobertura, e o prazo e os preços informados substituem os da região. As faixas de CEP valem depois da origem.",
+            "type": "object",
+            "required": [
+                "origem"
+            ],
+            "properties": {
+                "faixas_peso": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WeightBandRequest"
+                    }
+                },
+                "nao_atendida": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "origem": {
+                    "type": "string",
+                    "enum": [
+                        "norte",
+                        "nordeste",
+                        "centro-oeste",
+                        "sudeste",
+                        "sul"
+                    ],
+                    "example": "nordeste"
+                },
+                "prazo_estimado_dias": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 8
+                },
+                "preco_por_kg": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 8.9
+                },
+                "valor_minimo": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 19.9
+                }
+            }
+        },
+        "dto.OriginRateResponse": {
+            "description": "Prazo e preço para pacotes de uma região de origem; prazo e preços omitidos seguem os da região",
+            "type": "object",
+            "properties": {
+                "faixas_peso": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WeightBandResponse"
+                    }
+                },
+                "nao_atendida": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "origem": {
+                    "type": "string",
+                    "example": "nordeste"
+                },
+                "prazo_estimado_dias": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "preco_por_kg": {
+                    "type": "number",
+                    "example": 8.9
+                },
+                "valor_minimo": {
+                    "type": "number",
+                    "example": 19.9
+                }
+            }
+        },
+        "dto.PackageListResponse": {
+            "description": "Pacotes encontrados e cursor da próxima página",
+            "type": "object",
+            "properties": {
+                "pacotes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PackageResponse"
+                    }
+                },
+                "proximo_cursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiY3JpYWRvX2VtIn0"
+                }
+            }
+        },
+        "dto.PackageRequest": {
+            "description": "Dados necessários para criar um novo pacote. O destinatário é opcional na criação, mas é exigido para contratar o frete, inclusive pela política de contratação; sem CEP de destino, o CEP do endereço dele é o CEP de destino; com estado ou CEP de destino, o endereço precisa ficar neles. A origem é o ID de um armazém de GET /warehouse; sem ela, valem os preços das transportadoras para qualquer origem. Com itens, o produto e o peso podem ser omitidos: o produto passa a ser a descrição do primeiro item e o peso, a soma dos itens; o peso informado não pode ser menor que essa soma. O valor declarado é a base do seguro ad valorem cobrado pelas transportadoras; com itens, ele é a soma dos itens quando omitido e não pode ser menor que ela.",
             "type": "object",
-            "required": [
-                "estado_destino",
-                "peso_kg",
-                "produto"
-            ],
             "properties": {
+                "cep_destino": {
+                    "type": "string",
+                    "example": "80010-000"
+                },
+                "destinatario": {
+                    "$ref": "#/definitions/dto.ContactRequest"
+                },
+                "dimensoes": {
+                    "$ref": "#/definitions/dto.DimensionsRequest"
+                },
                 "estado_destino": {
                     "type": "string",
                     "example": "PR"
                 },
+                "itens": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.ItemRequest"
+                    }
+                },
+                "origem": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "curitiba"
+                },
                 "peso_kg": {
                     "type": "number",
                     "maximum": 1000,
                     "example": 0.6
                 },
+                "politica_contratacao": {
+                    "description": "PoliticaContratacao, quando informada, cota e contrata o frete na criação",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.HirePolicyRequest"
+                        }
+                    ]
+                },
                 "produto": {
                     "type": "string",
                     "maxLength": 100,
                     "minLength": 2,
                     "example": "Camisa tamanho G"
+                },
+                "remetente": {
+                    "$ref": "#/definitions/dto.ContactRequest"
+                },
+                "valor_declarado": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 179.8
                 }
             }
         },
@@ -280,6 +2039,32 @@ const docTemplate = `{
             "description": "Resposta com os dados de um pacote",
             "type": "object",
             "properties": {
+                "atrasado": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "atrasado_em": {
+                    "type": "string",
+                    "example": "2025-01-22T00:05:00Z"
+                },
+                "atualizado_em": {
+                    "type": "string",
+                    "example": "2025-01-16T09:10:00Z"
+                },
+                "cep_destino": {
+                    "type": "string",
+                    "example": "80010-000"
+                },
+                "criado_em": {
+                    "type": "string",
+                    "example": "2025-01-15T14:30:00Z"
+                },
+                "destinatario": {
+                    "$ref": "#/definitions/dto.ContactResponse"
+                },
+                "dimensoes": {
+                    "$ref": "#/definitions/dto.DimensionsResponse"
+                },
                 "entrega": {
                     "$ref": "#/definitions/dto.ShippingQuoteResponse"
                 },
@@ -287,10 +2072,25 @@ const docTemplate = `{
                     "type": "string",
                     "example": "PR"
                 },
+                "historico": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.StatusEventResponse"
+                    }
+                },
                 "id": {
                     "type": "string",
                     "example": "123e4567-e89b-12d3-a456-426614174000"
                 },
+                "itens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ItemResponse"
+                    }
+                },
+                "origem": {
+                    "$ref": "#/definitions/dto.WarehouseResponse"
+                },
                 "peso_kg": {
                     "type": "number",
                     "example": 0.6
@@ -303,9 +2103,137 @@ const docTemplate = `{
                     "type": "string",
                     "example": "sul"
                 },
+                "remetente": {
+                    "$ref": "#/definitions/dto.ContactResponse"
+                },
                 "status": {
                     "type": "string",
                     "example": "criado"
+                },
+                "valor_declarado": {
+                    "description": "ValorDeclarado é omitido em pacotes sem valor declarado",
+                    "type": "number",
+                    "example": 179.8
+                },
+                "versao": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "dto.PackageTransitionsResponse": {
+            "description": "Status atual e transições de status permitidas para um pacote",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "proximos_status": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "enviado"
+                    ]
+                },
+                "status_atual": {
+                    "type": "string",
+                    "example": "coletado"
+                },
+                "terminal": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "dto.PriceBreakdownResponse": {
+            "description": "Composição do preço estimado: o frete pelo peso cobrado, o seguro ad valorem sobre o valor declarado e as taxas adicionais da transportadora. Transportadoras cotadas pela própria API informam só o preço total, mostrado como frete.",
+            "type": "object",
+            "properties": {
+                "adicionais": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SurchargeResponse"
+                    }
+                },
+                "frete": {
+                    "type": "number",
+                    "example": 33.5
+                },
+                "seguro": {
+                    "type": "number",
+                    "example": 9
+                }
+            }
+        },
+        "dto.QuoteFailureResponse": {
+            "description": "Transportadora que falhou na cotação e o motivo",
+            "type": "object",
+            "properties": {
+                "motivo": {
+                    "type": "string",
+                    "example": "Carrier did not answer in time"
+                },
+                "transportadora": {
+                    "type": "string",
+                    "example": "Moventra Express"
+                },
+                "transportadora_id": {
+                    "type": "string",
+                    "example": "moventra"
+                }
+            }
+        },
+        "dto.QuoteResponse": {
+            "description": "Cotação de frete com preço garantido até valida_ate",
+            "type": "object",
+            "properties": {
+                "composicao": {
+                    "$ref": "#/definitions/dto.PriceBreakdownResponse"
+                },
+                "cotacao_id": {
+                    "type": "string",
+                    "example": "9b2f4c1e-7d3a-4e8b-a1c5-2f6d8e0b3a7c"
+                },
+                "moeda": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "prazo_estimado_dias": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "preco_estimado": {
+                    "type": "number",
+                    "example": 42.5
+                },
+                "transportadora": {
+                    "type": "string",
+                    "example": "Nebulix Logística"
+                },
+                "transportadora_id": {
+                    "type": "string",
+                    "example": "nebulix"
+                },
+                "valida_ate": {
+                    "type": "string",
+                    "example": "2025-01-15T15:00:00Z"
+                }
+            }
+        },
+        "dto.RegionVolumeResponse": {
+            "description": "Pacotes contratados para uma região de destino",
+            "type": "object",
+            "properties": {
+                "pacotes": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "regiao": {
+                    "type": "string",
+                    "example": "sul"
                 }
             }
         },
@@ -313,6 +2241,18 @@ const docTemplate = `{
             "description": "Dados de uma cotação de frete",
             "type": "object",
             "properties": {
+                "composicao": {
+                    "$ref": "#/definitions/dto.PriceBreakdownResponse"
+                },
+                "data_entrega_estimada": {
+                    "description": "DataEntregaEstimada conta o prazo em dias úteis a partir da contratação",
+                    "type": "string",
+                    "example": "2025-01-21"
+                },
+                "moeda": {
+                    "type": "string",
+                    "example": "BRL"
+                },
                 "prazo_estimado_dias": {
                     "type": "integer",
                     "example": 4
@@ -331,6 +2271,46 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.ShippingQuotesResponse": {
+            "description": "Cotações obtidas, na ordem pedida em sort (fastest, cheapest, best_value ou reliable; por padrão, pelo menor prazo), e transportadoras que não conseguiram cotar",
+            "type": "object",
+            "properties": {
+                "cotacoes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.QuoteResponse"
+                    }
+                },
+                "falhas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.QuoteFailureResponse"
+                    }
+                }
+            }
+        },
+        "dto.StatusEventResponse": {
+            "description": "Mudança de status registrada no histórico do pacote",
+            "type": "object",
+            "properties": {
+                "ator": {
+                    "type": "string",
+                    "example": "scanner.cd-curitiba"
+                },
+                "data_hora": {
+                    "type": "string",
+                    "example": "2025-01-15T14:30:00Z"
+                },
+                "observacao": {
+                    "type": "string",
+                    "example": "Coletado no CD Curitiba"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "coletado"
+                }
+            }
+        },
         "dto.SuccessResponse": {
             "type": "object",
             "properties": {
@@ -340,6 +2320,134 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.SurchargeRequest": {
+            "description": "Taxa fixa cobrada em todo pacote além do frete e do seguro, como a taxa de coleta ou o pedágio",
+            "type": "object",
+            "required": [
+                "nome",
+                "valor"
+            ],
+            "properties": {
+                "nome": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "coleta"
+                },
+                "valor": {
+                    "type": "number",
+                    "example": 4.9
+                }
+            }
+        },
+        "dto.SurchargeResponse": {
+            "description": "Taxa adicional cobrada pela transportadora além do frete e do seguro",
+            "type": "object",
+            "properties": {
+                "nome": {
+                    "type": "string",
+                    "example": "coleta"
+                },
+                "valor": {
+                    "type": "number",
+                    "example": 4.9
+                }
+            }
+        },
+        "dto.UpdateCarrierRegionRequest": {
+            "description": "Novo prazo e tabela de preço da região informada no caminho; os dados enviados substituem os atuais",
+            "type": "object",
+            "properties": {
+                "ad_valorem_percentual": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 0.5
+                },
+                "faixas_cep": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CEPRangeRequest"
+                    }
+                },
+                "faixas_peso": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WeightBandRequest"
+                    }
+                },
+                "fator_cubagem": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 300
+                },
+                "origens": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "$ref": "#/definitions/dto.OriginRateRequest"
+                    }
+                },
+                "prazo_estimado_dias": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "preco_por_kg": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 6.4
+                },
+                "seguro_minimo": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 2
+                },
+                "taxas_adicionais": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "$ref": "#/definitions/dto.SurchargeRequest"
+                    }
+                },
+                "valor_minimo": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 12.9
+                }
+            }
+        },
+        "dto.UpdateCarrierRequest": {
+            "description": "Nome, regiões atendidas, peso máximo e API de cotação; os dados enviados substituem os atuais",
+            "type": "object",
+            "required": [
+                "nome",
+                "regioes"
+            ],
+            "properties": {
+                "cotacao_url": {
+                    "type": "string",
+                    "example": "https://api.nebulix.com.br/v1/cotacao"
+                },
+                "nome": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "Nebulix Logística"
+                },
+                "peso_maximo_kg": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 100
+                },
+                "regioes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "$ref": "#/definitions/dto.CarrierRegionRequest"
+                    }
+                }
+            }
+        },
         "dto.UpdateStatusRequest": {
             "description": "Dados necessários para atualizar o status de um pacote",
             "type": "object",
@@ -348,6 +2456,16 @@ const docTemplate = `{
                 "status"
             ],
             "properties": {
+                "ator": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "scanner.cd-curitiba"
+                },
+                "observacao": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Saiu do centro de distribuição"
+                },
                 "package_id": {
                     "type": "string",
                     "example": "123e4567-e89b-12d3-a456-426614174000"
@@ -357,6 +2475,71 @@ const docTemplate = `{
                     "example": "enviado"
                 }
             }
+        },
+        "dto.WarehouseResponse": {
+            "description": "Armazém de onde os pacotes são despachados; a região de origem seleciona os preços das transportadoras",
+            "type": "object",
+            "properties": {
+                "cep": {
+                    "type": "string",
+                    "example": "81460-000"
+                },
+                "estado": {
+                    "type": "string",
+                    "example": "PR"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "curitiba"
+                },
+                "nome": {
+                    "type": "string",
+                    "example": "CD Curitiba"
+                },
+                "regiao": {
+                    "type": "string",
+                    "example": "sul"
+                }
+            }
+        },
+        "dto.WeightBandRequest": {
+            "description": "Faixa de peso: preço fixo mais preço por kg acima do início da faixa. A última faixa não tem ate_kg.",
+            "type": "object",
+            "properties": {
+                "ate_kg": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 5
+                },
+                "preco_fixo": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 12.9
+                },
+                "preco_por_kg": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 4.35
+                }
+            }
+        },
+        "dto.WeightBandResponse": {
+            "description": "Faixa de peso: preço fixo mais preço por kg acima do início da faixa",
+            "type": "object",
+            "properties": {
+                "ate_kg": {
+                    "type": "number",
+                    "example": 5
+                },
+                "preco_fixo": {
+                    "type": "number",
+                    "example": 12.9
+                },
+                "preco_por_kg": {
+                    "type": "number",
+                    "example": 4.35
+                }
+            }
         }
     }
 }`
@@ -3,17 +3,29 @@ package http
 import "github.com/foliveiracamara/delivery-manager-api/internal/api/http/controller"
 
 type ControllerManager struct {
-	PackageController *controller.PackageController
+	PackageController   *controller.PackageController
+	CarrierController   *controller.CarrierController
+	ReportController    *controller.ReportController
+	WarehouseController *controller.WarehouseController
 }
 
 var ControllersList = []any{
 	controller.NewPackageController,
+	controller.NewCarrierController,
+	controller.NewReportController,
+	controller.NewWarehouseController,
 }
 
 func NewControllerManager(
 	packageController *controller.PackageController,
+	carrierController *controller.CarrierController,
+	reportController *controller.ReportController,
+	warehouseController *controller.WarehouseController,
 ) *ControllerManager {
 	return &ControllerManager{
-		PackageController: packageController,
+		PackageController:   packageController,
+		CarrierController:   carrierController,
+		ReportController:    reportController,
+		WarehouseController: warehouseController,
 	}
 }
@@ -0,0 +1,343 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/api/http/dto"
+	"github.com/foliveiracamara/delivery-manager-api/internal/application/usecase"
+	"github.com/foliveiracamara/delivery-manager-api/internal/infrastructure/integration"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+type CarrierController struct {
+	us        *usecase.CarrierUseCase
+	validator *validator.Validate
+}
+
+func NewCarrierController(usecase *usecase.CarrierUseCase) *CarrierController {
+	return &CarrierController{
+		us:        usecase,
+		validator: newValidator(),
+	}
+}
+
+// List godoc
+// @Summary Listar transportadoras
+// @Description Retorna todas as transportadoras do catálogo, inclusive as inativas.
+// @Tags carriers
+// @Accept json
+// @Produce json
+// @Success 200 {array} dto.CarrierResponse "Transportadoras cadastradas"
+// @Router /carrier/ [get]
+func (c *CarrierController) List(ctx echo.Context) error {
+	carriers := c.us.List()
+
+	response := make([]dto.CarrierResponse, len(carriers))
+	for i, carrier := range carriers {
+		response[i] = toCarrierResponse(carrier)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// Get godoc
+// @Summary Consultar uma transportadora
+// @Description Retorna os dados de uma transportadora e das regiões que ela atende.
+// @Tags carriers
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da transportadora"
+// @Success 200 {object} dto.CarrierResponse "Dados da transportadora"
+// @Router /carrier/{id} [get]
+func (c *CarrierController) Get(ctx echo.Context) error {
+	carrier, err := c.us.Get(ctx.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, toCarrierResponse(carrier))
+}
+
+// Create godoc
+// @Summary Cadastrar transportadora
+// @Description Cadastra uma transportadora com as regiões atendidas, prazos e preços por kg. Com cotacao_url, o preço e o prazo passam a vir da API da transportadora. A transportadora passa a ser cotada imediatamente.
+// @Tags carriers
+// @Accept json
+// @Produce json
+// @Param carrier body dto.CreateCarrierRequest true "Dados da transportadora"
+// @Param X-API-Key header string true "Chave privilegiada de auth.privileged_api_keys"
+// @Success 201 {object} dto.CarrierResponse "Transportadora cadastrada"
+// @Router /carrier/ [post]
+func (c *CarrierController) Create(ctx echo.Context) error {
+	req := &dto.CreateCarrierRequest{}
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(http.StatusBadRequest,
+			map[string]string{"error": "Invalid request body"})
+	}
+	if err := c.validator.Struct(req); err != nil {
+		return ctx.JSON(http.StatusBadRequest,
+			map[string]string{"error": err.Error()})
+	}
+
+	carrier, err := c.us.Create(*req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusCreated, toCarrierResponse(carrier))
+}
+
+// Update godoc
+// @Summary Alterar transportadora
+// @Description Substitui o nome, as regiões e a API de cotação da transportadora. Pacotes já contratados mantêm o preço e o prazo cotados na contratação.
+// @Tags carriers
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da transportadora"
+// @Param carrier body dto.UpdateCarrierRequest true "Novos dados da transportadora"
+// @Param X-API-Key header string true "Chave privilegiada de auth.privileged_api_keys"
+// @Success 200 {object} dto.CarrierResponse "Transportadora alterada"
+// @Router /carrier/{id} [put]
+func (c *CarrierController) Update(ctx echo.Context) error {
+	req := &dto.UpdateCarrierRequest{}
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(http.StatusBadRequest,
+			map[string]string{"error": "Invalid request body"})
+	}
+	if err := c.validator.Struct(req); err != nil {
+		return ctx.JSON(http.StatusBadRequest,
+			map[string]string{"error": err.Error()})
+	}
+
+	carrier, err := c.us.Update(ctx.Param("id"), *req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, toCarrierResponse(carrier))
+}
+
+// Delete godoc
+// @Summary Excluir transportadora
+// @Description Remove a transportadora do catálogo. Para suspender temporariamente, prefira desativá-la. Pacotes já contratados não são afetados.
+// @Tags carriers
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da transportadora"
+// @Param X-API-Key header string true "Chave privilegiada de auth.privileged_api_keys"
+// @Success 200 {object} dto.SuccessResponse "Transportadora excluída"
+// @Router /carrier/{id} [delete]
+func (c *CarrierController) Delete(ctx echo.Context) error {
+	if err := c.us.Delete(ctx.Param("id")); err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]string{
+		"message": "Carrier deleted successfully",
+	})
+}
+
+// Activate godoc
+// @Summary Ativar transportadora
+// @Description Volta a cotar e permitir a contratação da transportadora.
+// @Tags carriers
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da transportadora"
+// @Param X-API-Key header string true "Chave privilegiada de auth.privileged_api_keys"
+// @Success 200 {object} dto.CarrierResponse "Transportadora ativada"
+// @Router /carrier/{id}/activate [post]
+func (c *CarrierController) Activate(ctx echo.Context) error {
+	return c.setActive(ctx, true)
+}
+
+// Deactivate godoc
+// @Summary Desativar transportadora
+// @Description Mantém a transportadora no catálogo, mas deixa de cotá-la e de permitir novas contratações. Pacotes já contratados não são afetados.
+// @Tags carriers
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da transportadora"
+// @Param X-API-Key header string true "Chave privilegiada de auth.privileged_api_keys"
+// @Success 200 {object} dto.CarrierResponse "Transportadora desativada"
+// @Router /carrier/{id}/deactivate [post]
+func (c *CarrierController) Deactivate(ctx echo.Context) error {
+	return c.setActive(ctx, false)
+}
+
+func (c *CarrierController) setActive(ctx echo.Context, active bool) error {
+	carrier, err := c.us.SetActive(ctx.Param("id"), active)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, toCarrierResponse(carrier))
+}
+
+// ListRegions godoc
+// @Summary Listar regiões da transportadora
+// @Description Retorna as regiões atendidas pela transportadora com prazo e preço por kg.
+// @Tags carriers
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da transportadora"
+// @Success 200 {array} dto.CarrierRegionResponse "Regiões atendidas"
+// @Router /carrier/{id}/regions [get]
+func (c *CarrierController) ListRegions(ctx echo.Context) error {
+	carrier, err := c.us.Get(ctx.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, toCarrierRegionResponses(carrier.Regions))
+}
+
+// AddRegion godoc
+// @Summary Adicionar região à transportadora
+// @Description Passa a atender uma nova região. Regiões já atendidas retornam 409.
+// @Tags carriers
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da transportadora"
+// @Param region body dto.CarrierRegionRequest true "Região, prazo e preço por kg"
+// @Param X-API-Key header string true "Chave privilegiada de auth.privileged_api_keys"
+// @Success 200 {object} dto.CarrierResponse "Transportadora alterada"
+// @Router /carrier/{id}/regions [post]
+func (c *CarrierController) AddRegion(ctx echo.Context) error {
+	req := &dto.CarrierRegionRequest{}
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(http.StatusBadRequest,
+			map[string]string{"error": "Invalid request body"})
+	}
+	if err := c.validator.Struct(req); err != nil {
+		return ctx.JSON(http.StatusBadRequest,
+			map[string]string{"error": err.Error()})
+	}
+
+	carrier, err := c.us.AddRegion(ctx.Param("id"), *req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, toCarrierResponse(carrier))
+}
+
+// UpdateRegion godoc
+// @Summary Alterar região da transportadora
+// @Description Altera o prazo e o preço por kg de uma região atendida. Pacotes já contratados mantêm o preço e o prazo cotados na contratação.
+// @Tags carriers
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da transportadora"
+// @Param region path string true "Região" Enums(norte, nordeste, centro-oeste, sudeste, sul)
+// @Param request body dto.UpdateCarrierRegionRequest true "Novo prazo e preço por kg"
+// @Param X-API-Key header string true "Chave privilegiada de auth.privileged_api_keys"
+// @Success 200 {object} dto.CarrierResponse "Transportadora alterada"
+// @Router /carrier/{id}/regions/{region} [put]
+func (c *CarrierController) UpdateRegion(ctx echo.Context) error {
+	req := &dto.UpdateCarrierRegionRequest{}
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(http.StatusBadRequest,
+			map[string]string{"error": "Invalid request body"})
+	}
+	if err := c.validator.Struct(req); err != nil {
+		return ctx.JSON(http.StatusBadRequest,
+			map[string]string{"error": err.Error()})
+	}
+
+	carrier, err := c.us.UpdateRegion(ctx.Param("id"), ctx.Param("region"), *req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, toCarrierResponse(carrier))
+}
+
+// RemoveRegion godoc
+// @Summary Remover região da transportadora
+// @Description Deixa de atender uma região. A transportadora precisa manter ao menos uma região; para suspendê-la por completo, desative-a.
+// @Tags carriers
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da transportadora"
+// @Param region path string true "Região" Enums(norte, nordeste, centro-oeste, sudeste, sul)
+// @Param X-API-Key header string true "Chave privilegiada de auth.privileged_api_keys"
+// @Success 200 {object} dto.CarrierResponse "Transportadora alterada"
+// @Router /carrier/{id}/regions/{region} [delete]
+func (c *CarrierController) RemoveRegion(ctx echo.Context) error {
+	carrier, err := c.us.RemoveRegion(ctx.Param("id"), ctx.Param("region"))
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, toCarrierResponse(carrier))
+}
+
+func toCarrierResponse(carrier *integration.Carrier) dto.CarrierResponse {
+	return dto.CarrierResponse{
+		ID:           carrier.ID,
+		Nome:         carrier.Name,
+		Ativa:        carrier.IsActive(),
+		Regioes:      toCarrierRegionResponses(carrier.Regions),
+		PesoMaximoKg: carrier.MaxWeightKg,
+		CotacaoURL:   carrier.QuoteURL,
+	}
+}
+
+func toCarrierRegionResponses(regions []integration.CarrierRegion) []dto.CarrierRegionResponse {
+	response := make([]dto.CarrierRegionResponse, len(regions))
+	for i, region := range regions {
+		response[i] = dto.CarrierRegionResponse{
+			Regiao:              region.Region,
+			PrazoEstimadoDias:   region.EstimatedDays,
+			PrecoPorKg:          optionalMoney(region.PricePerKg),
+			ValorMinimo:         optionalMoney(region.MinimumCharge),
+			FatorCubagem:        region.CubingFactor,
+			AdValoremPercentual: region.AdValoremPercent,
+			SeguroMinimo:        optionalMoney(region.MinimumInsurance),
+		}
+		response[i].FaixasPeso = toWeightBandResponses(region.WeightBands)
+		for _, surcharge := range region.Surcharges {
+			response[i].TaxasAdicionais = append(response[i].TaxasAdicionais, dto.SurchargeResponse{
+				Nome:  surcharge.Name,
+				Valor: surcharge.Amount,
+			})
+		}
+		for _, rate := range region.Origins {
+			response[i].Origens = append(response[i].Origens, dto.OriginRateResponse{
+				Origem:            rate.Origin,
+				NaoAtendida:       rate.Unserved,
+				PrazoEstimadoDias: rate.EstimatedDays,
+				PrecoPorKg:        optionalMoney(rate.PricePerKg),
+				FaixasPeso:        toWeightBandResponses(rate.WeightBands),
+				ValorMinimo:       optionalMoney(rate.MinimumCharge),
+			})
+		}
+		for _, cepRange := range region.CEPRanges {
+			response[i].FaixasCEP = append(response[i].FaixasCEP, dto.CEPRangeResponse{
+				Nome:              cepRange.Name,
+				CEPInicio:         cepRange.From,
+				CEPFim:            cepRange.To,
+				NaoAtendida:       cepRange.Unserved,
+				PrazoEstimadoDias: cepRange.EstimatedDays,
+				PrecoPorKg:        optionalMoney(cepRange.PricePerKg),
+				FaixasPeso:        toWeightBandResponses(cepRange.WeightBands),
+				ValorMinimo:       optionalMoney(cepRange.MinimumCharge),
+			})
+		}
+	}
+	return response
+}
+
+func toWeightBandResponses(bands []integration.WeightBand) []dto.WeightBandResponse {
+	var response []dto.WeightBandResponse
+	for _, band := range bands {
+		response = append(response, dto.WeightBandResponse{
+			AteKg:      band.UpToKg,
+			PrecoFixo:  band.FixedPrice,
+			PrecoPorKg: band.PricePerKg,
+		})
+	}
+	return response
+}
@@ -0,0 +1,36 @@
+package controller
+
+import (
+	"strconv"
+	"strings"
+
+	apperr "github.com/foliveiracamara/delivery-manager-api/internal/shared/apperror"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderETag    = "ETag"
+	HeaderIfMatch = "If-Match"
+)
+
+// setETag expõe a versão do pacote como ETag forte, ex.: "3"
+func setETag(ctx echo.Context, version int) {
+	ctx.Response().Header().Set(HeaderETag, strconv.Quote(strconv.Itoa(version)))
+}
+
+// parseIfMatch lê a versão esperada do header If-Match. Retorna 0 quando o header
+// está ausente ou é "*", indicando que a operação não exige versão específica.
+func parseIfMatch(ctx echo.Context) (int, error) {
+	header := strings.TrimSpace(ctx.Request().Header.Get(HeaderIfMatch))
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	tag := strings.Trim(strings.TrimPrefix(header, "W/"), `"`)
+	version, err := strconv.Atoi(tag)
+	if err != nil || version < 1 {
+		return 0, apperr.NewBadRequestError("Invalid If-Match header: " + header)
+	}
+
+	return version, nil
+}
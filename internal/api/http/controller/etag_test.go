@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIfMatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		header      string
+		expected    int
+		shouldError bool
+	}{
+		{name: "absent header", header: "", expected: 0},
+		{name: "wildcard", header: "*", expected: 0},
+		{name: "strong etag", header: `"3"`, expected: 3},
+		{name: "weak etag", header: `W/"7"`, expected: 7},
+		{name: "unquoted version", header: "2", expected: 2},
+		{name: "not a version", header: `"abc"`, shouldError: true},
+		{name: "zero version", header: `"0"`, shouldError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/package/status", nil)
+			if tt.header != "" {
+				req.Header.Set(HeaderIfMatch, tt.header)
+			}
+			ctx := echo.New().NewContext(req, httptest.NewRecorder())
+
+			version, err := parseIfMatch(ctx)
+
+			if tt.shouldError {
+				assert.ErrorContains(t, err, "Invalid If-Match header")
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, version)
+			}
+		})
+	}
+}
+
+func TestSetETag(t *testing.T) {
+	rec := httptest.NewRecorder()
+	ctx := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/package/1", nil), rec)
+
+	setETag(ctx, 4)
+
+	assert.Equal(t, `"4"`, rec.Header().Get(HeaderETag))
+}
@@ -2,13 +2,20 @@ package controller
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/foliveiracamara/delivery-manager-api/internal/api/http/dto"
+	"github.com/foliveiracamara/delivery-manager-api/internal/api/middlewares"
 	"github.com/foliveiracamara/delivery-manager-api/internal/application/usecase"
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain"
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 )
 
+// maskedField substitui os campos ocultos dos dados pessoais
+const maskedField = "***"
+
 type PackageController struct {
 	us        *usecase.PackageUseCase
 	validator *validator.Validate
@@ -17,13 +24,13 @@ type PackageController struct {
 func NewPackageController(usecase *usecase.PackageUseCase) *PackageController {
 	return &PackageController{
 		us:        usecase,
-		validator: validator.New(),
+		validator: newValidator(),
 	}
 }
 
 // Create godoc
 // @Summary Criar um novo pacote
-// @Description Cria um novo pacote com produto, peso e estado de destino. O sistema automaticamente mapeia o estado para a região correspondente e calcula as transportadoras disponíveis.
+// @Description Cria um novo pacote com produto, peso, destinatário e, opcionalmente, remetente. O estado e o CEP de destino vêm do endereço do destinatário quando não informados. O sistema automaticamente mapeia o estado para a região correspondente e calcula as transportadoras disponíveis. As dimensões são opcionais; quando informadas, as transportadoras cobram pelo maior entre o peso real e o peso cúbico. Com politica_contratacao, o frete é cotado e contratado na mesma chamada e retornado em entrega; se nenhuma transportadora atender a política, o pacote não é criado (422).
 // @Tags packages
 // @Accept json
 // @Produce json
@@ -41,15 +48,20 @@ func (c *PackageController) Create(ctx echo.Context) error {
 			map[string]string{"error": err.Error()})
 	}
 
-	id, err := c.us.Create(*req)
+	pkg, err := c.us.Create(ctx.Request().Context(), *req)
 	if err != nil {
 		return err
 	}
 
-	return ctx.JSON(http.StatusCreated, map[string]string{
-		"message": "Package created successfully",
-		"id":      id,
-	})
+	res := dto.CreatePackageResponse{
+		Message: "Package created successfully",
+		ID:      pkg.ID,
+	}
+	if pkg.Shipping != nil {
+		res.Entrega = toShippingResponse(pkg.Shipping)
+	}
+
+	return ctx.JSON(http.StatusCreated, res)
 }
 
 // Get godoc
@@ -59,7 +71,9 @@ func (c *PackageController) Create(ctx echo.Context) error {
 // @Accept json
 // @Produce json
 // @Param id path string true "ID único do pacote"
+// @Param X-API-Key header string false "Chave privilegiada; sem ela, os dados pessoais do destinatário e do remetente vêm mascarados"
 // @Success 200 {object} dto.PackageResponse "Dados do pacote"
+// @Header 200 {string} ETag "Versão atual do pacote, para uso no If-Match"
 // @Router /package/{id} [get]
 func (c *PackageController) Get(ctx echo.Context) error {
 	id := ctx.Param("id")
@@ -68,35 +82,22 @@ func (c *PackageController) Get(ctx echo.Context) error {
 		return err
 	}
 
-	res := dto.PackageResponse{
-		ID:            pkg.ID,
-		Product:       pkg.Product,
-		WeightKg:      pkg.WeightKg,
-		EstadoDestino: pkg.DestinationState,
-		RegiaoDestino: string(pkg.DestinationRegion),
-		Status:        string(pkg.Status),
-	}
-
-	if pkg.Shipping != nil {
-		res.Shipping = &dto.ShippingQuoteResponse{
-			Transportadora:    pkg.Shipping.CarrierName,
-			PrecoEstimado:     pkg.Shipping.EstimatedPrice,
-			PrazoEstimadoDias: pkg.Shipping.EstimatedDays,
-			TransportadoraID:  pkg.Shipping.CarrierID,
-		}
-	}
+	res := toPackageResponse(pkg, middlewares.IsPrivileged(ctx))
 
+	setETag(ctx, pkg.Version)
 	return ctx.JSON(http.StatusOK, res)
 }
 
 // UpdateStatus godoc
 // @Summary Atualizar status de um pacote
-// @Description Atualiza o status de um pacote específico. Status válidos: criado, esperando_coleta, coletado, enviado, entregue, extraviado.
+// @Description Atualiza o status de um pacote específico respeitando o fluxo criado → esperando_coleta → coletado → enviado → entregue/extraviado. Transições fora do fluxo retornam 409 com a lista de próximos status permitidos.
 // @Tags packages
 // @Accept json
 // @Produce json
 // @Param request body dto.UpdateStatusRequest true "Dados para atualização de status"
+// @Param If-Match header string false "ETag do pacote lido; rejeita com 412 se o pacote mudou"
 // @Success 200 {object} dto.SuccessResponse "Status atualizado com sucesso"
+// @Header 200 {string} ETag "Nova versão do pacote"
 // @Router /package/status [put]
 func (c *PackageController) UpdateStatus(ctx echo.Context) error {
 	req := &dto.UpdateStatusRequest{}
@@ -109,41 +110,163 @@ func (c *PackageController) UpdateStatus(ctx echo.Context) error {
 			map[string]string{"error": err.Error()})
 	}
 
-	err := c.us.UpdateStatus(req.PackageID, req.Status)
+	expectedVersion, err := parseIfMatch(ctx)
+	if err != nil {
+		return err
+	}
+
+	pkg, err := c.us.UpdateStatus(req.PackageID, req.Status, req.Ator, req.Observacao, expectedVersion)
 	if err != nil {
 		return err
 	}
 
+	setETag(ctx, pkg.Version)
+
 	return ctx.JSON(http.StatusOK, map[string]string{
 		"message": "Status updated successfully",
 	})
 }
 
+// List godoc
+// @Summary Listar pacotes
+// @Description Lista pacotes com filtros opcionais, ordenação e paginação por cursor. Para a próxima página, repita a consulta com o proximo_cursor retornado.
+// @Tags packages
+// @Accept json
+// @Produce json
+// @Param status query string false "Status separados por vírgula" example(enviado,entregue)
+// @Param regiao_destino query string false "Região de destino" example(sul)
+// @Param estado_destino query string false "UF de destino" example(PR)
+// @Param transportadora_id query string false "ID da transportadora contratada" example(nebulix)
+// @Param criado_de query string false "Criados a partir de (AAAA-MM-DD ou RFC3339)"
+// @Param criado_ate query string false "Criados até (AAAA-MM-DD inclui o dia inteiro)"
+// @Param atualizado_de query string false "Atualizados a partir de (AAAA-MM-DD ou RFC3339)"
+// @Param atualizado_ate query string false "Atualizados até (AAAA-MM-DD inclui o dia inteiro)"
+// @Param peso_min query number false "Peso mínimo em kg"
+// @Param peso_max query number false "Peso máximo em kg"
+// @Param ordenar query string false "criado_em, atualizado_em ou peso_kg; prefixo - para decrescente" default(-criado_em)
+// @Param limite query int false "Itens por página (máximo 100)" default(20)
+// @Param cursor query string false "Cursor retornado pela página anterior"
+// @Param X-API-Key header string false "Chave privilegiada; sem ela, os dados pessoais do destinatário e do remetente vêm mascarados"
+// @Success 200 {object} dto.PackageListResponse "Página de pacotes"
+// @Router /package/ [get]
+func (c *PackageController) List(ctx echo.Context) error {
+	req := &dto.ListPackagesRequest{}
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(http.StatusBadRequest,
+			map[string]string{"error": "Invalid query parameters"})
+	}
+	if err := c.validator.Struct(req); err != nil {
+		return ctx.JSON(http.StatusBadRequest,
+			map[string]string{"error": err.Error()})
+	}
+
+	page, err := c.us.List(*req)
+	if err != nil {
+		return err
+	}
+
+	res := dto.PackageListResponse{
+		Pacotes:       make([]dto.PackageResponse, len(page.Packages)),
+		ProximoCursor: page.NextCursor,
+	}
+	privileged := middlewares.IsPrivileged(ctx)
+	for i, pkg := range page.Packages {
+		res.Pacotes[i] = toPackageResponse(pkg, privileged)
+	}
+
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// GetHistory godoc
+// @Summary Consultar histórico de status
+// @Description Retorna a linha do tempo de status do pacote, do mais antigo ao mais recente, com data, ator e observação de cada mudança.
+// @Tags packages
+// @Accept json
+// @Produce json
+// @Param id path string true "ID único do pacote"
+// @Success 200 {array} dto.StatusEventResponse "Histórico de status"
+// @Router /package/{id}/history [get]
+func (c *PackageController) GetHistory(ctx echo.Context) error {
+	id := ctx.Param("id")
+	history, err := c.us.GetHistory(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, toStatusEventResponses(history))
+}
+
+// GetTransitions godoc
+// @Summary Consultar transições de status permitidas
+// @Description Retorna o status atual do pacote e os próximos status permitidos. Fluxo: criado → esperando_coleta → coletado → enviado → entregue/extraviado. Os status entregue e extraviado são terminais.
+// @Tags packages
+// @Accept json
+// @Produce json
+// @Param id path string true "ID único do pacote"
+// @Success 200 {object} dto.PackageTransitionsResponse "Transições permitidas"
+// @Router /package/{id}/transitions [get]
+func (c *PackageController) GetTransitions(ctx echo.Context) error {
+	id := ctx.Param("id")
+	pkg, next, err := c.us.GetTransitions(id)
+	if err != nil {
+		return err
+	}
+
+	res := dto.PackageTransitionsResponse{
+		ID:             pkg.ID,
+		StatusAtual:    string(pkg.Status),
+		ProximosStatus: make([]string, len(next)),
+		Terminal:       len(next) == 0,
+	}
+	for i, status := range next {
+		res.ProximosStatus[i] = string(status)
+	}
+
+	return ctx.JSON(http.StatusOK, res)
+}
+
 // QuoteShippings godoc
 // @Summary Cotação de fretes
-// @Description Retorna cotações de frete disponíveis para um pacote, ordenadas por prazo de entrega. Inclui preços e prazos estimados de todas as transportadoras que atendem a região do pacote.
+// @Description Cota o pacote em paralelo com todas as transportadoras ativas que atendem a região. As cotações vêm ordenadas conforme sort: fastest (menor prazo, padrão), cheapest (menor preço), best_value (custo-benefício entre preço e prazo) ou reliable (maior confiabilidade pelo histórico de entregas, depois menor prazo); empates são desfeitos pelo menor preço e pelo ID da transportadora. Cada cotação é registrada com um ID e pode ser contratada pelo preço cotado até valida_ate. Transportadoras que falham ou não respondem a tempo aparecem em falhas com o motivo, sem impedir as cotações das demais.
 // @Tags packages
 // @Accept json
 // @Produce json
 // @Param id path string true "ID do pacote"
-// @Success 200 {array} dto.ShippingQuoteResponse "Cotações de frete disponíveis"
+// @Param sort query string false "Ordenação das cotações" Enums(fastest, cheapest, best_value, reliable) default(fastest)
+// @Success 200 {object} dto.ShippingQuotesResponse "Cotações de frete e falhas por transportadora"
 // @Router /package/{id}/quote [post]
 func (c *PackageController) QuoteShippings(ctx echo.Context) error {
 	req := &dto.ShippingsQuoteRequest{}
 	req.PackageID = ctx.Param("id")
 
-	shippings, err := c.us.QuoteShipping(req.PackageID)
+	req.Sort = ctx.QueryParam("sort")
+
+	quotes, failures, err := c.us.QuoteShipping(ctx.Request().Context(), req.PackageID, req.Sort)
 	if err != nil {
 		return err
 	}
 
-	response := make([]dto.ShippingQuoteResponse, len(shippings))
-	for i, shipping := range shippings {
-		response[i] = dto.ShippingQuoteResponse{
-			Transportadora:    shipping.CarrierName,
-			PrecoEstimado:     shipping.EstimatedPrice,
-			PrazoEstimadoDias: shipping.EstimatedDays,
-			TransportadoraID:  shipping.CarrierID,
+	response := dto.ShippingQuotesResponse{
+		Cotacoes: make([]dto.QuoteResponse, len(quotes)),
+		Falhas:   make([]dto.QuoteFailureResponse, len(failures)),
+	}
+	for i, quote := range quotes {
+		response.Cotacoes[i] = dto.QuoteResponse{
+			CotacaoID:         quote.ID,
+			Transportadora:    quote.Shipping.CarrierName,
+			PrecoEstimado:     quote.Shipping.EstimatedPrice,
+			Moeda:             string(quote.Shipping.EstimatedPrice.Currency()),
+			Composicao:        toPriceBreakdownResponse(quote.Shipping),
+			PrazoEstimadoDias: quote.Shipping.EstimatedDays,
+			TransportadoraID:  quote.Shipping.CarrierID,
+			ValidaAte:         quote.ExpiresAt,
+		}
+	}
+	for i, failure := range failures {
+		response.Falhas[i] = dto.QuoteFailureResponse{
+			TransportadoraID: failure.CarrierID,
+			Transportadora:   failure.CarrierName,
+			Motivo:           failure.Reason,
 		}
 	}
 
@@ -152,12 +275,14 @@ func (c *PackageController) QuoteShippings(ctx echo.Context) error {
 
 // HireCarrier godoc
 // @Summary Contratar transportadora
-// @Description Contrata uma transportadora para realizar a entrega do pacote. O status do pacote será automaticamente alterado para 'esperando_coleta'. Transportadoras disponíveis: nebulix, rotafacil, moventra.
+// @Description Contrata uma transportadora para realizar a entrega do pacote. O status do pacote será automaticamente alterado para 'esperando_coleta'. Com quote_id, o frete é contratado pelo preço e prazo da cotação, que é rejeitada com 410 depois de expirar. Com carrier_id, a transportadora é cotada novamente no momento da contratação. O pacote precisa ter destinatário; pacotes criados sem ele o recebem em destinatario.
 // @Tags packages
 // @Accept json
 // @Produce json
 // @Param request body dto.HireCarrierRequest true "Dados para contratação"
+// @Param If-Match header string false "ETag do pacote lido; rejeita com 412 se o pacote mudou"
 // @Success 200 {object} dto.SuccessResponse "Transportadora contratada com sucesso"
+// @Header 200 {string} ETag "Nova versão do pacote"
 // @Router /package/hire-carrier [post]
 func (c *PackageController) HireCarrier(ctx echo.Context) error {
 	req := &dto.HireCarrierRequest{}
@@ -171,12 +296,172 @@ func (c *PackageController) HireCarrier(ctx echo.Context) error {
 			map[string]string{"error": err.Error()})
 	}
 
-	err := c.us.HireCarrier(req.PackageID, req.CarrierID)
+	expectedVersion, err := parseIfMatch(ctx)
+	if err != nil {
+		return err
+	}
+
+	var pkg *domain.Package
+	if req.QuoteID != "" {
+		pkg, err = c.us.HireQuote(req.PackageID, req.QuoteID, req.Ator, req.Destinatario, expectedVersion)
+	} else {
+		pkg, err = c.us.HireCarrier(ctx.Request().Context(), req.PackageID, req.CarrierID, req.Ator, req.Destinatario, expectedVersion)
+	}
 	if err != nil {
 		return err
 	}
 
+	setETag(ctx, pkg.Version)
+
 	return ctx.JSON(http.StatusOK, map[string]string{
 		"message": "Carrier hired successfully",
 	})
 }
+
+// toPackageResponse converte o pacote, mascarando os dados pessoais do destinatário e do
+// remetente para quem não tem privilégio
+func toPackageResponse(pkg *domain.Package, privileged bool) dto.PackageResponse {
+	res := dto.PackageResponse{
+		ID:            pkg.ID,
+		Product:       pkg.Product,
+		WeightKg:      pkg.WeightKg,
+		EstadoDestino: pkg.DestinationState,
+		CEPDestino:    pkg.DestinationCEP,
+		RegiaoDestino: string(pkg.DestinationRegion),
+		Status:        string(pkg.Status),
+		Historico:     toStatusEventResponses(pkg.History),
+		Versao:        pkg.Version,
+		CriadoEm:      pkg.CreatedAt,
+		AtualizadoEm:  pkg.UpdatedAt,
+	}
+
+	if !pkg.Dimensions.IsZero() {
+		res.Dimensoes = &dto.DimensionsResponse{
+			ComprimentoCm: pkg.Dimensions.LengthCm,
+			LarguraCm:     pkg.Dimensions.WidthCm,
+			AlturaCm:      pkg.Dimensions.HeightCm,
+		}
+	}
+
+	if len(pkg.Items) > 0 {
+		res.Itens = make([]dto.ItemResponse, len(pkg.Items))
+		for i, item := range pkg.Items {
+			res.Itens[i] = dto.ItemResponse{
+				SKU:            item.SKU,
+				Descricao:      item.Description,
+				Quantidade:     item.Quantity,
+				PesoUnitarioKg: item.UnitWeightKg,
+				ValorUnitario:  item.UnitValue,
+				NCM:            item.NCM,
+				PesoTotalKg:    item.WeightKg(),
+				ValorTotal:     item.Value(),
+			}
+		}
+	}
+	res.ValorDeclarado = optionalMoney(pkg.DeclaredValue)
+
+	if pkg.Origin != nil {
+		origin := toWarehouseResponse(pkg.Origin)
+		res.Origem = &origin
+	}
+
+	if pkg.Recipient != nil {
+		res.Destinatario = toContactResponse(*pkg.Recipient, privileged)
+	}
+	if pkg.Sender != nil {
+		res.Remetente = toContactResponse(*pkg.Sender, privileged)
+	}
+
+	if pkg.Shipping != nil {
+		res.Shipping = toShippingResponse(pkg.Shipping)
+		res.Atrasado = pkg.IsOverdue(time.Now())
+		res.AtrasadoEm = pkg.OverdueAt
+	}
+
+	return res
+}
+
+// toContactResponse converte o contato. Sem privilégio, os dados de pessoas físicas são
+// mascarados e o endereço mantém só o bairro, a cidade e o início do CEP.
+func toContactResponse(contact vo.Contact, privileged bool) *dto.ContactResponse {
+	address := contact.Address
+	res := &dto.ContactResponse{
+		Nome:          contact.Name,
+		TipoDocumento: string(contact.Document.Kind()),
+		Documento:     contact.Document.String(),
+		Endereco: dto.AddressResponse{
+			Logradouro:  address.Street,
+			Numero:      address.Number,
+			Complemento: address.Complement,
+			Bairro:      address.Neighborhood,
+			Cidade:      address.City,
+			CEP:         address.CEP.String(),
+		},
+	}
+	if !contact.Phone.IsZero() {
+		res.Telefone = contact.Phone.String()
+	}
+
+	if privileged || !contact.IsPerson() {
+		return res
+	}
+
+	res.Mascarado = true
+	res.Nome = contact.MaskedName()
+	res.Documento = contact.Document.Masked()
+	if !contact.Phone.IsZero() {
+		res.Telefone = contact.Phone.Masked()
+	}
+	res.Endereco.Logradouro = maskedField
+	res.Endereco.Numero = maskedField
+	if address.Complement != "" {
+		res.Endereco.Complemento = maskedField
+	}
+	res.Endereco.CEP = address.CEP.Masked()
+
+	return res
+}
+
+func toShippingResponse(shipping *vo.Shipping) *dto.ShippingQuoteResponse {
+	res := &dto.ShippingQuoteResponse{
+		Transportadora:    shipping.CarrierName,
+		PrecoEstimado:     shipping.EstimatedPrice,
+		Moeda:             string(shipping.EstimatedPrice.Currency()),
+		Composicao:        toPriceBreakdownResponse(*shipping),
+		PrazoEstimadoDias: shipping.EstimatedDays,
+		TransportadoraID:  shipping.CarrierID,
+	}
+	if !shipping.EstimatedDeliveryDate.IsZero() {
+		res.DataEntregaEstimada = shipping.EstimatedDeliveryDate.Format(time.DateOnly)
+	}
+	return res
+}
+
+func toPriceBreakdownResponse(shipping vo.Shipping) dto.PriceBreakdownResponse {
+	breakdown := shipping.PriceBreakdown()
+	res := dto.PriceBreakdownResponse{
+		Frete:      breakdown.Freight,
+		Seguro:     breakdown.Insurance,
+		Adicionais: make([]dto.SurchargeResponse, len(breakdown.Surcharges)),
+	}
+	for i, surcharge := range breakdown.Surcharges {
+		res.Adicionais[i] = dto.SurchargeResponse{
+			Nome:  surcharge.Name,
+			Valor: surcharge.Amount,
+		}
+	}
+	return res
+}
+
+func toStatusEventResponses(history []domain.StatusEvent) []dto.StatusEventResponse {
+	response := make([]dto.StatusEventResponse, len(history))
+	for i, event := range history {
+		response[i] = dto.StatusEventResponse{
+			Status:     string(event.Status),
+			DataHora:   event.Timestamp,
+			Ator:       event.Actor,
+			Observacao: event.Note,
+		}
+	}
+	return response
+}
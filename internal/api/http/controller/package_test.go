@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/api/http/dto"
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToContactResponse(t *testing.T) {
+	person := vo.Contact{
+		Name:     "Maria da Silva Souza",
+		Document: "52998224725",
+		Phone:    "41998765432",
+		Address:  vo.Address{Street: "Rua XV de Novembro", Number: "1000", Complement: "Apto 12", Neighborhood: "Centro", City: "Curitiba", CEP: "80010000"},
+	}
+	company := vo.Contact{
+		Name:     "Loja Exemplo Ltda",
+		Document: "11222333000181",
+		Address:  vo.Address{Street: "Avenida Paulista", Number: "1578", Neighborhood: "Bela Vista", City: "São Paulo", CEP: "01310200"},
+	}
+
+	t.Run("should mask the personal data of persons", func(t *testing.T) {
+		assert.Equal(t, &dto.ContactResponse{
+			Nome:          "Maria S. S.",
+			TipoDocumento: "cpf",
+			Documento:     "***.982.247-**",
+			Telefone:      "(41) *****-5432",
+			Endereco: dto.AddressResponse{
+				Logradouro:  "***",
+				Numero:      "***",
+				Complemento: "***",
+				Bairro:      "Centro",
+				Cidade:      "Curitiba",
+				CEP:         "80010-***",
+			},
+			Mascarado: true,
+		}, toContactResponse(person, false))
+	})
+
+	t.Run("should show everything to privileged callers", func(t *testing.T) {
+		assert.Equal(t, &dto.ContactResponse{
+			Nome:          "Maria da Silva Souza",
+			TipoDocumento: "cpf",
+			Documento:     "529.982.247-25",
+			Telefone:      "(41) 99876-5432",
+			Endereco: dto.AddressResponse{
+				Logradouro:  "Rua XV de Novembro",
+				Numero:      "1000",
+				Complemento: "Apto 12",
+				Bairro:      "Centro",
+				Cidade:      "Curitiba",
+				CEP:         "80010-000",
+			},
+		}, toContactResponse(person, true))
+	})
+
+	t.Run("should not mask companies", func(t *testing.T) {
+		res := toContactResponse(company, false)
+
+		assert.False(t, res.Mascarado)
+		assert.Equal(t, "Loja Exemplo Ltda", res.Nome)
+		assert.Equal(t, "cnpj", res.TipoDocumento)
+		assert.Equal(t, "11.222.333/0001-81", res.Documento)
+		assert.Equal(t, "Avenida Paulista", res.Endereco.Logradouro)
+		assert.Empty(t, res.Telefone)
+	})
+}
+
+func TestToPriceBreakdownResponse(t *testing.T) {
+	t.Run("should itemize the freight, the insurance and the surcharges", func(t *testing.T) {
+		breakdown := vo.PriceBreakdown{
+			Freight:    vo.NewMoney(1180),
+			Insurance:  vo.NewMoney(900),
+			Surcharges: []vo.Surcharge{{Name: "coleta", Amount: vo.NewMoney(490)}},
+		}
+
+		res := toPriceBreakdownResponse(vo.NewItemizedShippingQuote("Nebulix Logística", "nebulix", breakdown, 4))
+
+		assert.Equal(t, dto.PriceBreakdownResponse{
+			Frete:      vo.NewMoney(1180),
+			Seguro:     vo.NewMoney(900),
+			Adicionais: []dto.SurchargeResponse{{Nome: "coleta", Valor: vo.NewMoney(490)}},
+		}, res)
+	})
+
+	t.Run("should show the whole price of quotes without breakdown as freight", func(t *testing.T) {
+		res := toPriceBreakdownResponse(vo.Shipping{CarrierID: "nebulix", EstimatedPrice: vo.NewMoney(2550), EstimatedDays: 4})
+
+		assert.Equal(t, dto.PriceBreakdownResponse{
+			Frete:      vo.NewMoney(2550),
+			Adicionais: []dto.SurchargeResponse{},
+		}, res)
+	})
+}
@@ -0,0 +1,195 @@
+package controller
+
+import (
+	"cmp"
+	"math"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/api/http/dto"
+	"github.com/foliveiracamara/delivery-manager-api/internal/application/usecase"
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+type ReportController struct {
+	us        *usecase.ReportUseCase
+	validator *validator.Validate
+}
+
+func NewReportController(usecase *usecase.ReportUseCase) *ReportController {
+	return &ReportController{
+		us:        usecase,
+		validator: newValidator(),
+	}
+}
+
+// Late godoc
+// @Summary Relatório de entregas atrasadas
+// @Description Lista os pacotes marcados como atrasados, agrupados por transportadora e região, para a cobrança de multas. Um pacote é marcado pela verificação periódica quando passa da data de entrega prometida sem ter sido entregue, e continua no relatório depois da entrega ou do extravio. Os dias de atraso contam até a entrega, o extravio ou o momento da consulta.
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param transportadora_id query string false "ID da transportadora" example(nebulix)
+// @Param regiao_destino query string false "Região de destino" example(sul)
+// @Param criado_de query string false "Pacotes criados a partir de (AAAA-MM-DD ou RFC3339)"
+// @Param criado_ate query string false "Pacotes criados até (AAAA-MM-DD inclui o dia inteiro)"
+// @Success 200 {object} dto.LateReportResponse "Entregas atrasadas"
+// @Router /reports/late [get]
+func (c *ReportController) Late(ctx echo.Context) error {
+	req := &dto.LateReportRequest{}
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(http.StatusBadRequest,
+			map[string]string{"error": "Invalid query parameters"})
+	}
+	if err := c.validator.Struct(req); err != nil {
+		return ctx.JSON(http.StatusBadRequest,
+			map[string]string{"error": err.Error()})
+	}
+
+	now := time.Now()
+	groups, err := c.us.Late(*req, now)
+	if err != nil {
+		return err
+	}
+
+	res := dto.LateReportResponse{
+		Grupos: make([]dto.LateDeliveryGroupResponse, len(groups)),
+	}
+	for i, group := range groups {
+		res.Grupos[i] = toLateDeliveryGroupResponse(group)
+		res.TotalPacotes += len(group.Deliveries)
+	}
+
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// Carriers godoc
+// @Summary Métricas de desempenho das transportadoras
+// @Description Calcula, pelo histórico de status dos pacotes contratados, a taxa de entregas no prazo, a média de dias prometidos e reais, a taxa de extravio e o volume por região de cada transportadora. A confiabilidade, de 0 a 1, é a nota usada na ordenação reliable das cotações.
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param regiao_destino query string false "Região de destino" example(sul)
+// @Param criado_de query string false "Pacotes criados a partir de (AAAA-MM-DD ou RFC3339)"
+// @Param criado_ate query string false "Pacotes criados até (AAAA-MM-DD inclui o dia inteiro)"
+// @Success 200 {object} dto.CarrierReportResponse "Métricas das transportadoras"
+// @Router /reports/carriers [get]
+func (c *ReportController) Carriers(ctx echo.Context) error {
+	req := &dto.CarrierReportRequest{}
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(http.StatusBadRequest,
+			map[string]string{"error": "Invalid query parameters"})
+	}
+	if err := c.validator.Struct(req); err != nil {
+		return ctx.JSON(http.StatusBadRequest,
+			map[string]string{"error": err.Error()})
+	}
+
+	metrics, err := c.us.Carriers(*req)
+	if err != nil {
+		return err
+	}
+
+	res := dto.CarrierReportResponse{
+		Transportadoras: make([]dto.CarrierMetricsResponse, len(metrics)),
+	}
+	for i, m := range metrics {
+		res.Transportadoras[i] = toCarrierMetricsResponse(m)
+	}
+
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// CarrierMetrics godoc
+// @Summary Métricas de desempenho de uma transportadora
+// @Description Calcula as métricas de uma transportadora pelo histórico de status dos pacotes contratados com ela. Transportadoras excluídas do catálogo continuam consultáveis enquanto tiverem pacotes no período.
+// @Tags carriers
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da transportadora" example(nebulix)
+// @Param regiao_destino query string false "Região de destino" example(sul)
+// @Param criado_de query string false "Pacotes criados a partir de (AAAA-MM-DD ou RFC3339)"
+// @Param criado_ate query string false "Pacotes criados até (AAAA-MM-DD inclui o dia inteiro)"
+// @Success 200 {object} dto.CarrierMetricsResponse "Métricas da transportadora"
+// @Router /carrier/{id}/metrics [get]
+func (c *ReportController) CarrierMetrics(ctx echo.Context) error {
+	req := &dto.CarrierReportRequest{}
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(http.StatusBadRequest,
+			map[string]string{"error": "Invalid query parameters"})
+	}
+	if err := c.validator.Struct(req); err != nil {
+		return ctx.JSON(http.StatusBadRequest,
+			map[string]string{"error": err.Error()})
+	}
+
+	metrics, err := c.us.Carrier(ctx.Param("id"), *req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, toCarrierMetricsResponse(*metrics))
+}
+
+func toCarrierMetricsResponse(m domain.CarrierMetrics) dto.CarrierMetricsResponse {
+	res := dto.CarrierMetricsResponse{
+		TransportadoraID:    m.CarrierID,
+		Transportadora:      m.CarrierName,
+		PacotesContratados:  m.Hired,
+		Entregues:           m.Delivered,
+		EntreguesNoPrazo:    m.OnTime,
+		Extraviados:         m.Lost,
+		EmTransito:          m.InTransit(),
+		TaxaNoPrazo:         round(m.OnTimeRate(), 4),
+		TaxaExtravio:        round(m.LostRate(), 4),
+		MediaDiasPrometidos: round(m.AveragePromisedDays(), 2),
+		MediaDiasReais:      round(m.AverageActualDays(), 2),
+		Confiabilidade:      round(m.ReliabilityScore(), 4),
+		VolumePorRegiao:     []dto.RegionVolumeResponse{},
+	}
+
+	for region, count := range m.Regions {
+		res.VolumePorRegiao = append(res.VolumePorRegiao, dto.RegionVolumeResponse{Regiao: string(region), Pacotes: count})
+	}
+	slices.SortFunc(res.VolumePorRegiao, func(a, b dto.RegionVolumeResponse) int {
+		return cmp.Compare(a.Regiao, b.Regiao)
+	})
+
+	return res
+}
+
+// round arredonda para as casas decimais informadas
+func round(value float64, places int) float64 {
+	scale := math.Pow10(places)
+	return math.Round(value*scale) / scale
+}
+
+func toLateDeliveryGroupResponse(group domain.LateDeliveryGroup) dto.LateDeliveryGroupResponse {
+	res := dto.LateDeliveryGroupResponse{
+		TransportadoraID:  group.CarrierID,
+		Transportadora:    group.CarrierName,
+		RegiaoDestino:     string(group.Region),
+		QuantidadePacotes: len(group.Deliveries),
+		TotalDiasAtraso:   group.TotalLateDays,
+		Pacotes:           make([]dto.LatePackageResponse, len(group.Deliveries)),
+	}
+
+	for i, delivery := range group.Deliveries {
+		res.Pacotes[i] = dto.LatePackageResponse{
+			ID:                delivery.PackageID,
+			EstadoDestino:     delivery.DestinationState,
+			Status:            string(delivery.Status),
+			PrazoEstimadoDias: delivery.EstimatedDays,
+			AtrasadoEm:        delivery.OverdueAt,
+			DiasAtraso:        delivery.LateDays,
+		}
+		if !delivery.EstimatedDeliveryDate.IsZero() {
+			res.Pacotes[i].DataEntregaEstimada = delivery.EstimatedDeliveryDate.Format(time.DateOnly)
+		}
+	}
+
+	return res
+}
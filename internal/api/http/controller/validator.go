@@ -0,0 +1,22 @@
+package controller
+
+import (
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
+	"github.com/go-playground/validator/v10"
+)
+
+// newValidator cria o validador das requisições, com os valores monetários validados
+// pelos centavos
+func newValidator() *validator.Validate {
+	validate := validator.New()
+	validate.RegisterCustomTypeFunc(vo.MoneyCents, vo.Money{})
+	return validate
+}
+
+// optionalMoney omite da resposta os valores monetários não informados
+func optionalMoney(m vo.Money) *vo.Money {
+	if m.IsZero() {
+		return nil
+	}
+	return &m
+}
@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/api/http/dto"
+	"github.com/foliveiracamara/delivery-manager-api/internal/application/usecase"
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain"
+	"github.com/labstack/echo/v4"
+)
+
+type WarehouseController struct {
+	us *usecase.WarehouseUseCase
+}
+
+func NewWarehouseController(usecase *usecase.WarehouseUseCase) *WarehouseController {
+	return &WarehouseController{
+		us: usecase,
+	}
+}
+
+// List godoc
+// @Summary Listar armazéns
+// @Description Retorna os armazéns configurados em warehouses, que podem ser informados como origem na criação de pacotes.
+// @Tags warehouses
+// @Accept json
+// @Produce json
+// @Success 200 {array} dto.WarehouseResponse "Armazéns de origem"
+// @Router /warehouse/ [get]
+func (c *WarehouseController) List(ctx echo.Context) error {
+	warehouses := c.us.List()
+
+	response := make([]dto.WarehouseResponse, len(warehouses))
+	for i, warehouse := range warehouses {
+		response[i] = toWarehouseResponse(warehouse)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// Get godoc
+// @Summary Consultar um armazém
+// @Description Retorna os dados de um armazém de origem.
+// @Tags warehouses
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do armazém" example(curitiba)
+// @Success 200 {object} dto.WarehouseResponse "Dados do armazém"
+// @Router /warehouse/{id} [get]
+func (c *WarehouseController) Get(ctx echo.Context) error {
+	warehouse, err := c.us.Get(ctx.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, toWarehouseResponse(warehouse))
+}
+
+func toWarehouseResponse(warehouse *domain.Warehouse) dto.WarehouseResponse {
+	return dto.WarehouseResponse{
+		ID:     warehouse.ID,
+		Nome:   warehouse.Name,
+		Estado: warehouse.State,
+		CEP:    warehouse.CEP,
+		Regiao: string(warehouse.Region()),
+	}
+}
@@ -0,0 +1,155 @@
+package dto
+
+import "github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
+
+// CarrierRegionRequest representa a cobertura de uma transportadora em uma região
+// @Description Prazo e preço da transportadora em uma região: preço por kg ou faixas de peso, valor mínimo e fator de cubagem (kg/m³, 0 cobra só o peso real). Pacotes com valor declarado pagam o seguro ad valorem, o percentual do valor declarado com no mínimo o seguro mínimo; as taxas adicionais são cobradas em todo pacote.
+type CarrierRegionRequest struct {
+	Regiao              string              `json:"regiao" validate:"required,oneof=norte nordeste centro-oeste sudeste sul" example:"sul"`
+	PrazoEstimadoDias   int                 `json:"prazo_estimado_dias" validate:"gt=0" example:"4"`
+	PrecoPorKg          vo.Money            `json:"preco_por_kg" validate:"required_without=FaixasPeso,gte=0" swaggertype:"number" example:"5.90"`
+	FaixasPeso          []WeightBandRequest `json:"faixas_peso,omitempty" validate:"omitempty,dive"`
+	ValorMinimo         vo.Money            `json:"valor_minimo" validate:"gte=0" swaggertype:"number" example:"12.90"`
+	FatorCubagem        float64             `json:"fator_cubagem" validate:"gte=0" example:"300"`
+	Origens             []OriginRateRequest `json:"origens,omitempty" validate:"omitempty,unique=Origem,dive"`
+	FaixasCEP           []CEPRangeRequest   `json:"faixas_cep,omitempty" validate:"omitempty,dive"`
+	AdValoremPercentual float64             `json:"ad_valorem_percentual" validate:"gte=0,lte=100" example:"0.5"`
+	SeguroMinimo        vo.Money            `json:"seguro_minimo" validate:"gte=0" swaggertype:"number" example:"2.00"`
+	TaxasAdicionais     []SurchargeRequest  `json:"taxas_adicionais,omitempty" validate:"omitempty,unique=Nome,dive"`
+}
+
+// WeightBandRequest representa uma faixa de peso da tabela de preços
+// @Description Faixa de peso: preço fixo mais preço por kg acima do início da faixa. A última faixa não tem ate_kg.
+type WeightBandRequest struct {
+	AteKg      float64  `json:"ate_kg" validate:"gte=0" example:"5"`
+	PrecoFixo  vo.Money `json:"preco_fixo" validate:"gte=0" swaggertype:"number" example:"12.90"`
+	PrecoPorKg vo.Money `json:"preco_por_kg" validate:"gte=0" swaggertype:"number" example:"4.35"`
+}
+
+// SurchargeRequest representa uma taxa adicional da transportadora
+// @Description Taxa fixa cobrada em todo pacote além do frete e do seguro, como a taxa de coleta ou o pedágio
+type SurchargeRequest struct {
+	Nome  string   `json:"nome" validate:"required,max=50" example:"coleta"`
+	Valor vo.Money `json:"valor" validate:"required,gt=0" swaggertype:"number" example:"4.90"`
+}
+
+// OriginRateRequest representa o prazo e o preço da região para pacotes de uma região de origem
+// @Description Prazo e preço para pacotes despachados de armazéns da região de origem: nao_atendida exclui a origem da cobertura, e o prazo e os preços informados substituem os da região. As faixas de CEP valem depois da origem.
+type OriginRateRequest struct {
+	Origem            string              `json:"origem" validate:"required,oneof=norte nordeste centro-oeste sudeste sul" example:"nordeste"`
+	NaoAtendida       bool                `json:"nao_atendida" example:"false"`
+	PrazoEstimadoDias int                 `json:"prazo_estimado_dias" validate:"gte=0" example:"8"`
+	PrecoPorKg        vo.Money            `json:"preco_por_kg" validate:"gte=0" swaggertype:"number" example:"8.90"`
+	FaixasPeso        []WeightBandRequest `json:"faixas_peso,omitempty" validate:"omitempty,dive"`
+	ValorMinimo       vo.Money            `json:"valor_minimo" validate:"gte=0" swaggertype:"number" example:"19.90"`
+}
+
+// CEPRangeRequest representa uma faixa de CEPs da região com cobertura ou preço próprios
+// @Description Faixa de CEPs da região, como capital, interior ou área remota. A primeira faixa que contém o CEP de destino vale: nao_atendida exclui a faixa da cobertura, e o prazo e os preços informados substituem os da região.
+type CEPRangeRequest struct {
+	Nome              string              `json:"nome" validate:"required,max=50" example:"capital"`
+	CEPInicio         vo.CEP              `json:"cep_inicio" validate:"required" swaggertype:"string" example:"80000-000"`
+	CEPFim            vo.CEP              `json:"cep_fim" validate:"required" swaggertype:"string" example:"82999-999"`
+	NaoAtendida       bool                `json:"nao_atendida" example:"false"`
+	PrazoEstimadoDias int                 `json:"prazo_estimado_dias" validate:"gte=0" example:"2"`
+	PrecoPorKg        vo.Money            `json:"preco_por_kg" validate:"gte=0" swaggertype:"number" example:"4.90"`
+	FaixasPeso        []WeightBandRequest `json:"faixas_peso,omitempty" validate:"omitempty,dive"`
+	ValorMinimo       vo.Money            `json:"valor_minimo" validate:"gte=0" swaggertype:"number" example:"9.90"`
+}
+
+// CreateCarrierRequest representa a requisição para cadastrar uma transportadora
+// @Description Dados necessários para cadastrar uma transportadora
+type CreateCarrierRequest struct {
+	ID           string                 `json:"id" validate:"required,min=2,max=50,lowercase,alphanum" example:"nebulix"`
+	Nome         string                 `json:"nome" validate:"required,min=2,max=100" example:"Nebulix Logística"`
+	Regioes      []CarrierRegionRequest `json:"regioes" validate:"required,min=1,unique=Regiao,dive"`
+	PesoMaximoKg float64                `json:"peso_maximo_kg" validate:"gte=0" example:"100"`
+	CotacaoURL   string                 `json:"cotacao_url" validate:"omitempty,http_url" example:"https://api.nebulix.com.br/v1/cotacao"`
+}
+
+// UpdateCarrierRequest representa a requisição para alterar uma transportadora
+// @Description Nome, regiões atendidas, peso máximo e API de cotação; os dados enviados substituem os atuais
+type UpdateCarrierRequest struct {
+	Nome         string                 `json:"nome" validate:"required,min=2,max=100" example:"Nebulix Logística"`
+	Regioes      []CarrierRegionRequest `json:"regioes" validate:"required,min=1,unique=Regiao,dive"`
+	PesoMaximoKg float64                `json:"peso_maximo_kg" validate:"gte=0" example:"100"`
+	CotacaoURL   string                 `json:"cotacao_url" validate:"omitempty,http_url" example:"https://api.nebulix.com.br/v1/cotacao"`
+}
+
+// UpdateCarrierRegionRequest representa a requisição para alterar prazo e preço de uma região
+// @Description Novo prazo e tabela de preço da região informada no caminho; os dados enviados substituem os atuais
+type UpdateCarrierRegionRequest struct {
+	PrazoEstimadoDias   int                 `json:"prazo_estimado_dias" validate:"gt=0" example:"5"`
+	PrecoPorKg          vo.Money            `json:"preco_por_kg" validate:"required_without=FaixasPeso,gte=0" swaggertype:"number" example:"6.40"`
+	FaixasPeso          []WeightBandRequest `json:"faixas_peso,omitempty" validate:"omitempty,dive"`
+	ValorMinimo         vo.Money            `json:"valor_minimo" validate:"gte=0" swaggertype:"number" example:"12.90"`
+	FatorCubagem        float64             `json:"fator_cubagem" validate:"gte=0" example:"300"`
+	Origens             []OriginRateRequest `json:"origens,omitempty" validate:"omitempty,unique=Origem,dive"`
+	FaixasCEP           []CEPRangeRequest   `json:"faixas_cep,omitempty" validate:"omitempty,dive"`
+	AdValoremPercentual float64             `json:"ad_valorem_percentual" validate:"gte=0,lte=100" example:"0.5"`
+	SeguroMinimo        vo.Money            `json:"seguro_minimo" validate:"gte=0" swaggertype:"number" example:"2.00"`
+	TaxasAdicionais     []SurchargeRequest  `json:"taxas_adicionais,omitempty" validate:"omitempty,unique=Nome,dive"`
+}
+
+// End Requests
+
+// CarrierResponse representa a resposta de uma transportadora
+// @Description Dados de uma transportadora do catálogo
+type CarrierResponse struct {
+	ID           string                  `json:"id" example:"nebulix"`
+	Nome         string                  `json:"nome" example:"Nebulix Logística"`
+	Ativa        bool                    `json:"ativa" example:"true"`
+	Regioes      []CarrierRegionResponse `json:"regioes"`
+	PesoMaximoKg float64                 `json:"peso_maximo_kg,omitempty" example:"100"`
+	CotacaoURL   string                  `json:"cotacao_url,omitempty" example:"https://api.nebulix.com.br/v1/cotacao"`
+}
+
+// CarrierRegionResponse representa a cobertura de uma transportadora em uma região
+// @Description Prazo, tabela de preço, fator de cubagem, seguro ad valorem e taxas adicionais da transportadora em uma região
+type CarrierRegionResponse struct {
+	Regiao              string               `json:"regiao" example:"sul"`
+	PrazoEstimadoDias   int                  `json:"prazo_estimado_dias" example:"4"`
+	PrecoPorKg          *vo.Money            `json:"preco_por_kg,omitempty" swaggertype:"number" example:"5.90"`
+	FaixasPeso          []WeightBandResponse `json:"faixas_peso,omitempty"`
+	ValorMinimo         *vo.Money            `json:"valor_minimo,omitempty" swaggertype:"number" example:"12.90"`
+	FatorCubagem        float64              `json:"fator_cubagem" example:"300"`
+	Origens             []OriginRateResponse `json:"origens,omitempty"`
+	FaixasCEP           []CEPRangeResponse   `json:"faixas_cep,omitempty"`
+	AdValoremPercentual float64              `json:"ad_valorem_percentual,omitempty" example:"0.5"`
+	SeguroMinimo        *vo.Money            `json:"seguro_minimo,omitempty" swaggertype:"number" example:"2.00"`
+	TaxasAdicionais     []SurchargeResponse  `json:"taxas_adicionais,omitempty"`
+}
+
+// OriginRateResponse representa o prazo e o preço da região para pacotes de uma região de origem
+// @Description Prazo e preço para pacotes de uma região de origem; prazo e preços omitidos seguem os da região
+type OriginRateResponse struct {
+	Origem            string               `json:"origem" example:"nordeste"`
+	NaoAtendida       bool                 `json:"nao_atendida,omitempty" example:"false"`
+	PrazoEstimadoDias int                  `json:"prazo_estimado_dias,omitempty" example:"8"`
+	PrecoPorKg        *vo.Money            `json:"preco_por_kg,omitempty" swaggertype:"number" example:"8.90"`
+	FaixasPeso        []WeightBandResponse `json:"faixas_peso,omitempty"`
+	ValorMinimo       *vo.Money            `json:"valor_minimo,omitempty" swaggertype:"number" example:"19.90"`
+}
+
+// CEPRangeResponse representa uma faixa de CEPs da região com cobertura ou preço próprios
+// @Description Faixa de CEPs da região; prazo e preços omitidos seguem os da região
+type CEPRangeResponse struct {
+	Nome              string               `json:"nome" example:"capital"`
+	CEPInicio         vo.CEP               `json:"cep_inicio" swaggertype:"string" example:"80000-000"`
+	CEPFim            vo.CEP               `json:"cep_fim" swaggertype:"string" example:"82999-999"`
+	NaoAtendida       bool                 `json:"nao_atendida,omitempty" example:"false"`
+	PrazoEstimadoDias int                  `json:"prazo_estimado_dias,omitempty" example:"2"`
+	PrecoPorKg        *vo.Money            `json:"preco_por_kg,omitempty" swaggertype:"number" example:"4.90"`
+	FaixasPeso        []WeightBandResponse `json:"faixas_peso,omitempty"`
+	ValorMinimo       *vo.Money            `json:"valor_minimo,omitempty" swaggertype:"number" example:"9.90"`
+}
+
+// WeightBandResponse representa uma faixa de peso da tabela de preços
+// @Description Faixa de peso: preço fixo mais preço por kg acima do início da faixa
+type WeightBandResponse struct {
+	AteKg      float64  `json:"ate_kg,omitempty" example:"5"`
+	PrecoFixo  vo.Money `json:"preco_fixo" swaggertype:"number" example:"12.90"`
+	PrecoPorKg vo.Money `json:"preco_por_kg" swaggertype:"number" example:"4.35"`
+}
+
+// End Responses
@@ -1,31 +1,117 @@
 package dto
 
+import (
+	"time"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
+)
+
 // PackageRequest representa a requisição para criar um novo pacote
-// @Description Dados necessários para criar um novo pacote
+// @Description Dados necessários para criar um novo pacote. O destinatário é opcional na criação, mas é exigido para contratar o frete, inclusive pela política de contratação; sem CEP de destino, o CEP do endereço dele é o CEP de destino; com estado ou CEP de destino, o endereço precisa ficar neles. A origem é o ID de um armazém de GET /warehouse; sem ela, valem os preços das transportadoras para qualquer origem. Com itens, o produto e o peso podem ser omitidos: o produto passa a ser a descrição do primeiro item e o peso, a soma dos itens; o peso informado não pode ser menor que essa soma. O valor declarado é a base do seguro ad valorem cobrado pelas transportadoras; com itens, ele é a soma dos itens quando omitido e não pode ser menor que ela.
 type PackageRequest struct {
-	Product       string  `json:"produto" validate:"required,min=2,max=100" example:"Camisa tamanho G"`
-	WeightKg      float64 `json:"peso_kg" validate:"required,gt=0,lte=1000" example:"0.6"`
-	EstadoDestino string  `json:"estado_destino" validate:"required,len=2,alpha" example:"PR"`
+	Product        string             `json:"produto,omitempty" validate:"required_without=Itens,omitempty,min=2,max=100" example:"Camisa tamanho G"`
+	WeightKg       float64            `json:"peso_kg,omitempty" validate:"required_without=Itens,omitempty,gt=0,lte=1000" example:"0.6"`
+	Itens          []ItemRequest      `json:"itens,omitempty" validate:"omitempty,min=1,max=100,dive"`
+	ValorDeclarado vo.Money           `json:"valor_declarado,omitempty" validate:"gte=0" swaggertype:"number" example:"179.80"`
+	EstadoDestino  string             `json:"estado_destino,omitempty" validate:"omitempty,len=2,alpha" example:"PR"`
+	CEPDestino     vo.CEP             `json:"cep_destino,omitempty" swaggertype:"string" example:"80010-000"`
+	Origem         string             `json:"origem,omitempty" validate:"max=50" example:"curitiba"`
+	Destinatario   *ContactRequest    `json:"destinatario,omitempty"`
+	Remetente      *ContactRequest    `json:"remetente,omitempty"`
+	Dimensoes      *DimensionsRequest `json:"dimensoes,omitempty"`
+	// PoliticaContratacao, quando informada, cota e contrata o frete na criação
+	PoliticaContratacao *HirePolicyRequest `json:"politica_contratacao,omitempty"`
+}
+
+// ItemRequest representa uma mercadoria do pacote
+// @Description Mercadoria do pacote, com os dados da declaração de conteúdo e da nota fiscal. O SKU e o NCM são opcionais; o NCM aceita o código com ou sem pontos.
+type ItemRequest struct {
+	SKU            string   `json:"sku,omitempty" validate:"max=50" example:"CAM-G-AZUL"`
+	Descricao      string   `json:"descricao" validate:"required,min=2,max=120" example:"Camisa azul tamanho G"`
+	Quantidade     int      `json:"quantidade" validate:"required,gt=0,lte=10000" example:"2"`
+	PesoUnitarioKg float64  `json:"peso_unitario_kg" validate:"required,gt=0,lte=1000" example:"0.3"`
+	ValorUnitario  vo.Money `json:"valor_unitario" validate:"required,gt=0" swaggertype:"number" example:"89.90"`
+	NCM            vo.NCM   `json:"ncm,omitempty" swaggertype:"string" example:"6205.20.00"`
+}
+
+// ContactRequest representa o destinatário ou o remetente do pacote
+// @Description Quem recebe ou envia o pacote. O documento é um CPF ou um CNPJ, com ou sem pontuação, e tem os dígitos verificadores conferidos; o telefone é opcional e leva o DDD.
+type ContactRequest struct {
+	Nome      string         `json:"nome" validate:"required,min=2,max=100" example:"Maria da Silva"`
+	Documento vo.Document    `json:"documento" validate:"required" swaggertype:"string" example:"529.982.247-25"`
+	Telefone  vo.Phone       `json:"telefone,omitempty" swaggertype:"string" example:"(41) 99876-5432"`
+	Endereco  AddressRequest `json:"endereco"`
+}
+
+// AddressRequest representa o endereço do destinatário ou do remetente
+// @Description Endereço de entrega ou de coleta. Use S/N no número de endereços sem número.
+type AddressRequest struct {
+	Logradouro  string `json:"logradouro" validate:"required,max=150" example:"Rua XV de Novembro"`
+	Numero      string `json:"numero" validate:"required,max=10" example:"1000"`
+	Complemento string `json:"complemento,omitempty" validate:"max=100" example:"Apto 12"`
+	Bairro      string `json:"bairro" validate:"required,max=100" example:"Centro"`
+	Cidade      string `json:"cidade" validate:"required,max=100" example:"Curitiba"`
+	CEP         vo.CEP `json:"cep" validate:"required" swaggertype:"string" example:"80010-000"`
+}
+
+// DimensionsRequest representa as medidas da embalagem
+// @Description Medidas da embalagem em centímetros, usadas no cálculo do peso cúbico
+type DimensionsRequest struct {
+	ComprimentoCm float64 `json:"comprimento_cm" validate:"required,gt=0,lte=500" example:"40"`
+	LarguraCm     float64 `json:"largura_cm" validate:"required,gt=0,lte=500" example:"30"`
+	AlturaCm      float64 `json:"altura_cm" validate:"required,gt=0,lte=500" example:"20"`
+}
+
+// HirePolicyRequest representa a política para contratar o frete na criação do pacote
+// @Description Política de contratação automática: cheapest (mais barato), fastest (mais rápido), max_days (mais barato com prazo até prazo_maximo_dias) ou max_price (mais rápido com preço até preco_maximo)
+type HirePolicyRequest struct {
+	Tipo            string   `json:"tipo" validate:"required,oneof=cheapest fastest max_days max_price" example:"max_days"`
+	PrazoMaximoDias int      `json:"prazo_maximo_dias,omitempty" validate:"required_if=Tipo max_days,gte=0" example:"5"`
+	PrecoMaximo     vo.Money `json:"preco_maximo,omitempty" validate:"required_if=Tipo max_price,gte=0" swaggertype:"number" example:"50.00"`
 }
 
 // ShippingsQuoteRequest representa a requisição para obter cotações de frete
 // @Description Dados necessários para obter cotações de frete
 type ShippingsQuoteRequest struct {
 	PackageID string `json:"package_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Sort      string `json:"sort" example:"cheapest"`
 }
 
 // HireCarrierRequest representa a requisição para contratar uma transportadora
-// @Description Dados necessários para contratar uma transportadora
+// @Description Dados necessários para contratar uma transportadora: quote_id contrata pelo preço cotado e carrier_id cota novamente. O destinatário só é aceito, e é obrigatório, para pacotes criados sem ele.
 type HireCarrierRequest struct {
-	PackageID string `json:"package_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
-	CarrierID string `json:"carrier_id" validate:"required" example:"nebulix"`
+	PackageID    string          `json:"package_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	CarrierID    string          `json:"carrier_id,omitempty" validate:"required_without=QuoteID,excluded_with=QuoteID" example:"nebulix"`
+	QuoteID      string          `json:"quote_id,omitempty" validate:"required_without=CarrierID" example:"9b2f4c1e-7d3a-4e8b-a1c5-2f6d8e0b3a7c"`
+	Ator         string          `json:"ator" validate:"max=100" example:"operador.joao"`
+	Destinatario *ContactRequest `json:"destinatario,omitempty"`
 }
 
 // UpdateStatusRequest representa a requisição para atualizar o status de um pacote
 // @Description Dados necessários para atualizar o status de um pacote
 type UpdateStatusRequest struct {
-	PackageID string `json:"package_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
-	Status    string `json:"status" validate:"required" example:"enviado"`
+	PackageID  string `json:"package_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Status     string `json:"status" validate:"required" example:"enviado"`
+	Ator       string `json:"ator" validate:"max=100" example:"scanner.cd-curitiba"`
+	Observacao string `json:"observacao" validate:"max=500" example:"Saiu do centro de distribuição"`
+}
+
+// ListPackagesRequest representa os filtros, a ordenação e a paginação da listagem de pacotes
+// @Description Filtros opcionais da listagem de pacotes
+type ListPackagesRequest struct {
+	Status           string  `query:"status" example:"enviado,entregue"`
+	RegiaoDestino    string  `query:"regiao_destino" example:"sul"`
+	EstadoDestino    string  `query:"estado_destino" validate:"omitempty,len=2,alpha" example:"PR"`
+	TransportadoraID string  `query:"transportadora_id" example:"nebulix"`
+	CriadoDe         string  `query:"criado_de" example:"2025-01-01"`
+	CriadoAte        string  `query:"criado_ate" example:"2025-01-31T23:59:59Z"`
+	AtualizadoDe     string  `query:"atualizado_de" example:"2025-01-01"`
+	AtualizadoAte    string  `query:"atualizado_ate" example:"2025-01-31"`
+	PesoMin          float64 `query:"peso_min" validate:"gte=0" example:"0.5"`
+	PesoMax          float64 `query:"peso_max" validate:"gte=0" example:"30"`
+	Ordenar          string  `query:"ordenar" example:"-criado_em"`
+	Limite           int     `query:"limite" validate:"gte=0,lte=100" example:"20"`
+	Cursor           string  `query:"cursor"`
 }
 
 // End Requests
@@ -33,22 +119,151 @@ type UpdateStatusRequest struct {
 // PackageResponse representa a resposta de um pacote
 // @Description Resposta com os dados de um pacote
 type PackageResponse struct {
-	ID            string                 `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
-	Product       string                 `json:"produto" example:"Camisa tamanho G"`
-	WeightKg      float64                `json:"peso_kg" example:"0.6"`
-	EstadoDestino string                 `json:"estado_destino" example:"PR"`
-	RegiaoDestino string                 `json:"regiao_destino" example:"sul"`
-	Status        string                 `json:"status" example:"criado"`
-	Shipping      *ShippingQuoteResponse `json:"entrega,omitempty"`
+	ID        string              `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Product   string              `json:"produto" example:"Camisa tamanho G"`
+	WeightKg  float64             `json:"peso_kg" example:"0.6"`
+	Dimensoes *DimensionsResponse `json:"dimensoes,omitempty"`
+	Itens     []ItemResponse      `json:"itens,omitempty"`
+	// ValorDeclarado é omitido em pacotes sem valor declarado
+	ValorDeclarado *vo.Money              `json:"valor_declarado,omitempty" swaggertype:"number" example:"179.80"`
+	EstadoDestino  string                 `json:"estado_destino" example:"PR"`
+	CEPDestino     vo.CEP                 `json:"cep_destino,omitempty" swaggertype:"string" example:"80010-000"`
+	RegiaoDestino  string                 `json:"regiao_destino" example:"sul"`
+	Origem         *WarehouseResponse     `json:"origem,omitempty"`
+	Destinatario   *ContactResponse       `json:"destinatario,omitempty"`
+	Remetente      *ContactResponse       `json:"remetente,omitempty"`
+	Status         string                 `json:"status" example:"criado"`
+	Shipping       *ShippingQuoteResponse `json:"entrega,omitempty"`
+	Atrasado       bool                   `json:"atrasado" example:"false"`
+	AtrasadoEm     *time.Time             `json:"atrasado_em,omitempty" example:"2025-01-22T00:05:00Z"`
+	Historico      []StatusEventResponse  `json:"historico"`
+	Versao         int                    `json:"versao" example:"3"`
+	CriadoEm       time.Time              `json:"criado_em" example:"2025-01-15T14:30:00Z"`
+	AtualizadoEm   time.Time              `json:"atualizado_em" example:"2025-01-16T09:10:00Z"`
+}
+
+// ItemResponse representa uma mercadoria do pacote
+// @Description Mercadoria do pacote com o peso e o valor de todas as unidades
+type ItemResponse struct {
+	SKU            string   `json:"sku,omitempty" example:"CAM-G-AZUL"`
+	Descricao      string   `json:"descricao" example:"Camisa azul tamanho G"`
+	Quantidade     int      `json:"quantidade" example:"2"`
+	PesoUnitarioKg float64  `json:"peso_unitario_kg" example:"0.3"`
+	ValorUnitario  vo.Money `json:"valor_unitario" swaggertype:"number" example:"89.90"`
+	NCM            vo.NCM   `json:"ncm,omitempty" swaggertype:"string" example:"6205.20.00"`
+	PesoTotalKg    float64  `json:"peso_total_kg" example:"0.6"`
+	ValorTotal     vo.Money `json:"valor_total" swaggertype:"number" example:"179.80"`
+}
+
+// ContactResponse representa o destinatário ou o remetente do pacote
+// @Description Quem recebe ou envia o pacote. Sem uma chave privilegiada no header X-API-Key, os dados de pessoas físicas vêm mascarados: o primeiro nome com as iniciais dos sobrenomes, o CPF sem os três primeiros dígitos e os verificadores, o telefone só com o DDD e os quatro últimos dígitos e o endereço só com o bairro, a cidade e o início do CEP.
+type ContactResponse struct {
+	Nome          string          `json:"nome" example:"Maria S."`
+	TipoDocumento string          `json:"tipo_documento" example:"cpf"`
+	Documento     string          `json:"documento" example:"***.982.247-**"`
+	Telefone      string          `json:"telefone,omitempty" example:"(41) *****-5432"`
+	Endereco      AddressResponse `json:"endereco"`
+	Mascarado     bool            `json:"mascarado" example:"true"`
+}
+
+// AddressResponse representa o endereço do destinatário ou do remetente
+// @Description Endereço de entrega ou de coleta, mascarado com *** nos campos ocultos
+type AddressResponse struct {
+	Logradouro  string `json:"logradouro" example:"***"`
+	Numero      string `json:"numero" example:"***"`
+	Complemento string `json:"complemento,omitempty" example:"***"`
+	Bairro      string `json:"bairro" example:"Centro"`
+	Cidade      string `json:"cidade" example:"Curitiba"`
+	CEP         string `json:"cep" example:"80010-***"`
+}
+
+// DimensionsResponse representa as medidas da embalagem
+// @Description Medidas da embalagem em centímetros
+type DimensionsResponse struct {
+	ComprimentoCm float64 `json:"comprimento_cm" example:"40"`
+	LarguraCm     float64 `json:"largura_cm" example:"30"`
+	AlturaCm      float64 `json:"altura_cm" example:"20"`
+}
+
+// PackageListResponse representa uma página da listagem de pacotes
+// @Description Pacotes encontrados e cursor da próxima página
+type PackageListResponse struct {
+	Pacotes       []PackageResponse `json:"pacotes"`
+	ProximoCursor string            `json:"proximo_cursor,omitempty" example:"eyJzIjoiY3JpYWRvX2VtIn0"`
+}
+
+// StatusEventResponse representa um evento do histórico de status
+// @Description Mudança de status registrada no histórico do pacote
+type StatusEventResponse struct {
+	Status     string    `json:"status" example:"coletado"`
+	DataHora   time.Time `json:"data_hora" example:"2025-01-15T14:30:00Z"`
+	Ator       string    `json:"ator" example:"scanner.cd-curitiba"`
+	Observacao string    `json:"observacao,omitempty" example:"Coletado no CD Curitiba"`
 }
 
 // ShippingQuoteResponse representa uma cotação de frete
 // @Description Dados de uma cotação de frete
 type ShippingQuoteResponse struct {
-	Transportadora    string  `json:"transportadora" example:"Nebulix Logística"`
-	PrecoEstimado     float64 `json:"preco_estimado" example:"42.50"`
-	PrazoEstimadoDias int     `json:"prazo_estimado_dias" example:"4"`
-	TransportadoraID  string  `json:"transportadora_id" example:"nebulix"`
+	Transportadora    string                 `json:"transportadora" example:"Nebulix Logística"`
+	PrecoEstimado     vo.Money               `json:"preco_estimado" swaggertype:"number" example:"42.50"`
+	Moeda             string                 `json:"moeda" example:"BRL"`
+	Composicao        PriceBreakdownResponse `json:"composicao"`
+	PrazoEstimadoDias int                    `json:"prazo_estimado_dias" example:"4"`
+	TransportadoraID  string                 `json:"transportadora_id" example:"nebulix"`
+	// DataEntregaEstimada conta o prazo em dias úteis a partir da contratação
+	DataEntregaEstimada string `json:"data_entrega_estimada,omitempty" example:"2025-01-21"`
+}
+
+// PriceBreakdownResponse detalha o preço de uma cotação
+// @Description Composição do preço estimado: o frete pelo peso cobrado, o seguro ad valorem sobre o valor declarado e as taxas adicionais da transportadora. Transportadoras cotadas pela própria API informam só o preço total, mostrado como frete.
+type PriceBreakdownResponse struct {
+	Frete      vo.Money            `json:"frete" swaggertype:"number" example:"33.50"`
+	Seguro     vo.Money            `json:"seguro" swaggertype:"number" example:"9.00"`
+	Adicionais []SurchargeResponse `json:"adicionais"`
+}
+
+// SurchargeResponse representa uma taxa adicional da transportadora
+// @Description Taxa adicional cobrada pela transportadora além do frete e do seguro
+type SurchargeResponse struct {
+	Nome  string   `json:"nome" example:"coleta"`
+	Valor vo.Money `json:"valor" swaggertype:"number" example:"4.90"`
+}
+
+// QuoteResponse representa uma cotação registrada, contratável pelo ID até expirar
+// @Description Cotação de frete com preço garantido até valida_ate
+type QuoteResponse struct {
+	CotacaoID         string                 `json:"cotacao_id" example:"9b2f4c1e-7d3a-4e8b-a1c5-2f6d8e0b3a7c"`
+	Transportadora    string                 `json:"transportadora" example:"Nebulix Logística"`
+	PrecoEstimado     vo.Money               `json:"preco_estimado" swaggertype:"number" example:"42.50"`
+	Moeda             string                 `json:"moeda" example:"BRL"`
+	Composicao        PriceBreakdownResponse `json:"composicao"`
+	PrazoEstimadoDias int                    `json:"prazo_estimado_dias" example:"4"`
+	TransportadoraID  string                 `json:"transportadora_id" example:"nebulix"`
+	ValidaAte         time.Time              `json:"valida_ate" example:"2025-01-15T15:00:00Z"`
+}
+
+// ShippingQuotesResponse representa o resultado de uma cotação de frete
+// @Description Cotações obtidas, na ordem pedida em sort (fastest, cheapest, best_value ou reliable; por padrão, pelo menor prazo), e transportadoras que não conseguiram cotar
+type ShippingQuotesResponse struct {
+	Cotacoes []QuoteResponse        `json:"cotacoes"`
+	Falhas   []QuoteFailureResponse `json:"falhas"`
+}
+
+// QuoteFailureResponse representa uma transportadora que não conseguiu cotar o frete
+// @Description Transportadora que falhou na cotação e o motivo
+type QuoteFailureResponse struct {
+	TransportadoraID string `json:"transportadora_id" example:"moventra"`
+	Transportadora   string `json:"transportadora" example:"Moventra Express"`
+	Motivo           string `json:"motivo" example:"Carrier did not answer in time"`
+}
+
+// PackageTransitionsResponse representa os próximos status permitidos de um pacote
+// @Description Status atual e transições de status permitidas para um pacote
+type PackageTransitionsResponse struct {
+	ID             string   `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	StatusAtual    string   `json:"status_atual" example:"coletado"`
+	ProximosStatus []string `json:"proximos_status" example:"enviado"`
+	Terminal       bool     `json:"terminal" example:"false"`
 }
 
 // End Responses
@@ -68,4 +283,6 @@ type SuccessResponse struct {
 type CreatePackageResponse struct {
 	Message string `json:"message" example:"Package created successfully"`
 	ID      string `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	// Entrega é o frete contratado pela política de contratação, se informada
+	Entrega *ShippingQuoteResponse `json:"entrega,omitempty"`
 }
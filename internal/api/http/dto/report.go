@@ -0,0 +1,83 @@
+package dto
+
+import "time"
+
+// LateReportRequest representa os filtros do relatório de entregas atrasadas
+// @Description Filtros opcionais do relatório de entregas atrasadas
+type LateReportRequest struct {
+	TransportadoraID string `query:"transportadora_id" example:"nebulix"`
+	RegiaoDestino    string `query:"regiao_destino" example:"sul"`
+	CriadoDe         string `query:"criado_de" example:"2025-01-01"`
+	CriadoAte        string `query:"criado_ate" example:"2025-01-31"`
+}
+
+// CarrierReportRequest representa os filtros das métricas de transportadoras
+// @Description Filtros opcionais dos pacotes considerados nas métricas
+type CarrierReportRequest struct {
+	RegiaoDestino string `query:"regiao_destino" example:"sul"`
+	CriadoDe      string `query:"criado_de" example:"2025-01-01"`
+	CriadoAte     string `query:"criado_ate" example:"2025-01-31"`
+}
+
+// End Requests
+
+// LateReportResponse representa o relatório de entregas atrasadas
+// @Description Pacotes que passaram da data de entrega prometida, agrupados por transportadora e região
+type LateReportResponse struct {
+	TotalPacotes int                         `json:"total_pacotes" example:"3"`
+	Grupos       []LateDeliveryGroupResponse `json:"grupos"`
+}
+
+// LateDeliveryGroupResponse representa os atrasos de uma transportadora em uma região
+// @Description Pacotes atrasados de uma transportadora em uma região, os mais atrasados primeiro
+type LateDeliveryGroupResponse struct {
+	TransportadoraID  string                `json:"transportadora_id" example:"nebulix"`
+	Transportadora    string                `json:"transportadora" example:"Nebulix Logística"`
+	RegiaoDestino     string                `json:"regiao_destino" example:"sul"`
+	QuantidadePacotes int                   `json:"quantidade_pacotes" example:"2"`
+	TotalDiasAtraso   int                   `json:"total_dias_atraso" example:"5"`
+	Pacotes           []LatePackageResponse `json:"pacotes"`
+}
+
+// LatePackageResponse representa um pacote atrasado
+// @Description Pacote atrasado com a data prometida, quando foi marcado e os dias de atraso até a entrega ou até agora
+type LatePackageResponse struct {
+	ID                  string    `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	EstadoDestino       string    `json:"estado_destino" example:"PR"`
+	Status              string    `json:"status" example:"entregue"`
+	PrazoEstimadoDias   int       `json:"prazo_estimado_dias" example:"4"`
+	DataEntregaEstimada string    `json:"data_entrega_estimada,omitempty" example:"2025-01-21"`
+	AtrasadoEm          time.Time `json:"atrasado_em" example:"2025-01-22T00:05:00Z"`
+	DiasAtraso          int       `json:"dias_atraso" example:"3"`
+}
+
+// CarrierReportResponse representa as métricas de todas as transportadoras
+// @Description Métricas de desempenho das transportadoras, ordenadas pelo ID
+type CarrierReportResponse struct {
+	Transportadoras []CarrierMetricsResponse `json:"transportadoras"`
+}
+
+// CarrierMetricsResponse representa o desempenho de uma transportadora
+// @Description Desempenho calculado pelo histórico de status dos pacotes contratados. As taxas vão de 0 a 1 e os dias são corridos, da contratação à data prometida ou à entrega.
+type CarrierMetricsResponse struct {
+	TransportadoraID    string                 `json:"transportadora_id" example:"nebulix"`
+	Transportadora      string                 `json:"transportadora" example:"Nebulix Logística"`
+	PacotesContratados  int                    `json:"pacotes_contratados" example:"40"`
+	Entregues           int                    `json:"entregues" example:"30"`
+	EntreguesNoPrazo    int                    `json:"entregues_no_prazo" example:"27"`
+	Extraviados         int                    `json:"extraviados" example:"2"`
+	EmTransito          int                    `json:"em_transito" example:"8"`
+	TaxaNoPrazo         float64                `json:"taxa_no_prazo" example:"0.9"`
+	TaxaExtravio        float64                `json:"taxa_extravio" example:"0.0625"`
+	MediaDiasPrometidos float64                `json:"media_dias_prometidos" example:"5.2"`
+	MediaDiasReais      float64                `json:"media_dias_reais" example:"4.8"`
+	Confiabilidade      float64                `json:"confiabilidade" example:"0.8235"`
+	VolumePorRegiao     []RegionVolumeResponse `json:"volume_por_regiao"`
+}
+
+// RegionVolumeResponse representa os pacotes contratados para uma região
+// @Description Pacotes contratados para uma região de destino
+type RegionVolumeResponse struct {
+	Regiao  string `json:"regiao" example:"sul"`
+	Pacotes int    `json:"pacotes" example:"25"`
+}
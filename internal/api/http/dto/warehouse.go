@@ -0,0 +1,13 @@
+package dto
+
+import "github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
+
+// WarehouseResponse representa um armazém de origem
+// @Description Armazém de onde os pacotes são despachados; a região de origem seleciona os preços das transportadoras
+type WarehouseResponse struct {
+	ID     string `json:"id" example:"curitiba"`
+	Nome   string `json:"nome" example:"CD Curitiba"`
+	Estado string `json:"estado" example:"PR"`
+	CEP    vo.CEP `json:"cep" swaggertype:"string" example:"81460-000"`
+	Regiao string `json:"regiao" example:"sul"`
+}
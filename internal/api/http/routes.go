@@ -3,6 +3,7 @@ package http
 import (
 	"net/http"
 
+	"github.com/foliveiracamara/delivery-manager-api/internal/api/middlewares"
 	"github.com/labstack/echo/v4"
 	echoSwagger "github.com/swaggo/echo-swagger"
 )
@@ -25,12 +26,44 @@ func (s *Server) setupRoutes(cm *ControllerManager) {
 	mainRouter := s.e.Group("")
 
 	packageRouter := mainRouter.Group("/package")
+	packageRouter.GET("", cm.PackageController.List)
+	packageRouter.GET("/", cm.PackageController.List)
 	packageRouter.GET("/:id", cm.PackageController.Get)
 	packageRouter.POST("/", cm.PackageController.Create)
+	packageRouter.GET("/:id/history", cm.PackageController.GetHistory)
+	packageRouter.GET("/:id/transitions", cm.PackageController.GetTransitions)
 	packageRouter.POST("/:id/quote", cm.PackageController.QuoteShippings)
 	packageRouter.POST("/hire-carrier", cm.PackageController.HireCarrier)
 	packageRouter.PUT("/status", cm.PackageController.UpdateStatus)
 
+	// As alterações do catálogo exigem uma chave privilegiada: elas mudam os preços
+	// cotados e contratados a partir de então
+	admin := middlewares.RequirePrivileged()
+
+	carrierRouter := mainRouter.Group("/carrier")
+	carrierRouter.GET("", cm.CarrierController.List)
+	carrierRouter.GET("/", cm.CarrierController.List)
+	carrierRouter.GET("/:id", cm.CarrierController.Get)
+	carrierRouter.POST("/", cm.CarrierController.Create, admin)
+	carrierRouter.PUT("/:id", cm.CarrierController.Update, admin)
+	carrierRouter.DELETE("/:id", cm.CarrierController.Delete, admin)
+	carrierRouter.POST("/:id/activate", cm.CarrierController.Activate, admin)
+	carrierRouter.POST("/:id/deactivate", cm.CarrierController.Deactivate, admin)
+	carrierRouter.GET("/:id/regions", cm.CarrierController.ListRegions)
+	carrierRouter.POST("/:id/regions", cm.CarrierController.AddRegion, admin)
+	carrierRouter.PUT("/:id/regions/:region", cm.CarrierController.UpdateRegion, admin)
+	carrierRouter.DELETE("/:id/regions/:region", cm.CarrierController.RemoveRegion, admin)
+	carrierRouter.GET("/:id/metrics", cm.ReportController.CarrierMetrics)
+
+	warehouseRouter := mainRouter.Group("/warehouse")
+	warehouseRouter.GET("", cm.WarehouseController.List)
+	warehouseRouter.GET("/", cm.WarehouseController.List)
+	warehouseRouter.GET("/:id", cm.WarehouseController.Get)
+
+	reportRouter := mainRouter.Group("/reports")
+	reportRouter.GET("/late", cm.ReportController.Late)
+	reportRouter.GET("/carriers", cm.ReportController.Carriers)
+
 	mainRouter.GET("/health", healthCheck)
 
 	// Swagger documentation
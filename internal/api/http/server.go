@@ -23,7 +23,7 @@ func New(cfg *config.Config, controllerManager *ControllerManager) *Server {
 		e: echo.New(),
 	}
 
-	server.setupMiddlewares()
+	server.setupMiddlewares(cfg)
 	server.setupRoutes(controllerManager)
 
 	server.e.Server.Addr = fmt.Sprintf(":%d", cfg.Server.Port)
@@ -41,7 +41,7 @@ func (s *Server) Shutdown(ctx context.Context) error {
 	return s.e.Shutdown(ctx)
 }
 
-func (s *Server) setupMiddlewares() {
+func (s *Server) setupMiddlewares(cfg *config.Config) {
 	s.e.Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
 		Format: "method=${method}, uri=${uri}, status=${status}\n",
 	}))
@@ -53,6 +53,7 @@ func (s *Server) setupMiddlewares() {
 	s.e.Use(middlewares.BodyLimitMiddleware())
 	s.e.Use(middlewares.TimeoutMiddleware())
 	s.e.Use(middlewares.RateLimitMiddleware(50, time.Minute))
+	s.e.Use(middlewares.PrivilegedAccess(cfg.Auth.PrivilegedAPIKeys))
 
 	s.e.HTTPErrorHandler = middlewares.ErrorHandler
 }
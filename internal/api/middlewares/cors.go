@@ -10,9 +10,10 @@ import (
 // CORSMiddleware configura CORS básico
 func CORSMiddleware() echo.MiddlewareFunc {
 	return middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins: []string{"*"}, // Em produção devemos especificar
-		AllowMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
-		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept},
-		MaxAge:       86400,
+		AllowOrigins:  []string{"*"}, // Em produção devemos especificar
+		AllowMethods:  []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
+		AllowHeaders:  []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, "If-Match", HeaderAPIKey},
+		ExposeHeaders: []string{"ETag"},
+		MaxAge:        86400,
 	})
-}
\ No newline at end of file
+}
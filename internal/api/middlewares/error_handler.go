@@ -1,16 +1,19 @@
 package middlewares
 
 import (
+	"errors"
 	"net/http"
 
 	apperr "github.com/foliveiracamara/delivery-manager-api/internal/shared/apperror"
 	"github.com/labstack/echo/v4"
 )
 
-// ErrorHandler é um middleware que trata AppErr e retorna os status codes HTTP apropriados
+// ErrorHandler é um middleware que trata AppErr e retorna os status codes HTTP apropriados.
+// Erros tipados que embutem um AppErr são serializados por completo, preservando seus detalhes.
 func ErrorHandler(err error, c echo.Context) {
-	if appErr, ok := err.(*apperr.AppErr); ok {
-		c.JSON(appErr.Code, appErr)
+	var appErr *apperr.AppErr
+	if errors.As(err, &appErr) {
+		c.JSON(appErr.Code, err)
 		return
 	}
 
@@ -0,0 +1,60 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderAPIKey = "X-API-Key"
+
+	privilegedKey = "privileged"
+)
+
+// PrivilegedAccess marca como privilegiadas as requisições com uma das chaves configuradas
+// no header X-API-Key. Requisições sem o header seguem sem privilégio e recebem os dados
+// pessoais mascarados; uma chave desconhecida é rejeitada com 401.
+func PrivilegedAccess(keys []string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := c.Request().Header.Get(HeaderAPIKey)
+			if key == "" {
+				return next(c)
+			}
+
+			for _, allowed := range keys {
+				if subtle.ConstantTimeCompare([]byte(key), []byte(allowed)) == 1 {
+					c.Set(privilegedKey, true)
+					return next(c)
+				}
+			}
+
+			return c.JSON(http.StatusUnauthorized, map[string]string{
+				"error": "Invalid API key",
+			})
+		}
+	}
+}
+
+// RequirePrivileged rejeita com 401 as requisições sem uma chave privilegiada. Deve ser
+// usado nas rotas após PrivilegedAccess, que valida a chave.
+func RequirePrivileged() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !IsPrivileged(c) {
+				return c.JSON(http.StatusUnauthorized, map[string]string{
+					"error": "API key required",
+				})
+			}
+			return next(c)
+		}
+	}
+}
+
+// IsPrivileged indica se a requisição trouxe uma chave privilegiada
+func IsPrivileged(c echo.Context) bool {
+	privileged, _ := c.Get(privilegedKey).(bool)
+	return privileged
+}
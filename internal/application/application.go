@@ -35,7 +35,7 @@ func Start(ctx context.Context, starter any) {
 }
 
 func hook() any {
-	return func(ctx context.Context, lc fx.Lifecycle, api api.Api) {
+	return func(ctx context.Context, lc fx.Lifecycle, api api.Api, overdue *OverdueJob, quotePurge *QuotePurgeJob, reliability *ReliabilityJob) {
 		lc.Append(fx.Hook{
 			OnStart: func(ctx context.Context) error {
 				go func() {
@@ -44,9 +44,15 @@ func hook() any {
 						panic(err)
 					}
 				}()
+				overdue.Start()
+				quotePurge.Start()
+				reliability.Start()
 				return nil
 			},
 			OnStop: func(ctx context.Context) error {
+				overdue.Stop()
+				quotePurge.Stop()
+				reliability.Stop()
 				return api.Shutdown(ctx)
 			},
 		})
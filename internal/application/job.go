@@ -0,0 +1,58 @@
+package application
+
+import "time"
+
+// periodicJob runs a scan right away and then once per interval until Stop. A zero
+// interval disables the job.
+type periodicJob struct {
+	interval time.Duration
+	scan     func()
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+func newPeriodicJob(interval time.Duration, scan func()) *periodicJob {
+	return &periodicJob{
+		interval: interval,
+		scan:     scan,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start runs a first scan right away and then one per interval until Stop
+func (j *periodicJob) Start() {
+	if j.interval <= 0 {
+		close(j.done)
+		return
+	}
+
+	go j.run()
+}
+
+// Stop waits for the scan in progress, if any, to finish
+func (j *periodicJob) Stop() {
+	select {
+	case <-j.stop:
+	default:
+		close(j.stop)
+	}
+	<-j.done
+}
+
+func (j *periodicJob) run() {
+	defer close(j.done)
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.scan()
+
+		select {
+		case <-j.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package application
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/application/usecase"
+	"github.com/foliveiracamara/delivery-manager-api/internal/infrastructure/config"
+)
+
+// OverdueJob periodically marks the packages in transit that passed their promised
+// delivery date. A scan that fails is logged and retried on the next tick.
+type OverdueJob struct {
+	*periodicJob
+	reports *usecase.ReportUseCase
+}
+
+// NewOverdueJob scans every sla.scan_interval; a zero interval disables the job
+func NewOverdueJob(cfg *config.Config, reports *usecase.ReportUseCase) *OverdueJob {
+	job := &OverdueJob{reports: reports}
+	job.periodicJob = newPeriodicJob(cfg.SLA.ScanInterval, job.scan)
+	return job
+}
+
+func (j *OverdueJob) scan() {
+	marked, err := j.reports.MarkOverdue(time.Now())
+	if err != nil {
+		slog.Error("marking overdue packages", "marked", marked, "error", err)
+		return
+	}
+	if marked > 0 {
+		slog.Info("packages marked as overdue", "marked", marked)
+	}
+}
@@ -1,9 +1,15 @@
 package application
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+
 	"github.com/foliveiracamara/delivery-manager-api/internal/api/http"
 	"github.com/foliveiracamara/delivery-manager-api/internal/application/usecase"
 	"github.com/foliveiracamara/delivery-manager-api/internal/domain"
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
 	"github.com/foliveiracamara/delivery-manager-api/internal/infrastructure/config"
 	"github.com/foliveiracamara/delivery-manager-api/internal/infrastructure/integration"
 	"github.com/foliveiracamara/delivery-manager-api/internal/infrastructure/persistence"
@@ -15,17 +21,37 @@ func providers() []any {
 	globalDeps := []any{
 		config.New,
 
-		// Repository
-		fx.Annotate(persistence.NewInMemoryPackageRepository, fx.As(new(domain.PackageRepository))),
+		// Repositories
+		ProvideRepositories,
+
+		// Carrier Repository
+		fx.Annotate(
+			ProvideCarrierRepository,
+			fx.As(new(integration.CarrierRepository)),
+			fx.As(new(integration.WritableCarrierRepository)),
+		),
+
+		// Warehouse Repository
+		ProvideWarehouseRepository,
 
-		// Carrier Repository (MOCK)
-		integration.NewCarrierRepository,
+		// Carrier quotes
+		ProvideShippingQuoter,
 
 		// Services
-		service.NewPackageService,
+		ProvideHolidayCalendar,
+		ProvidePackageService,
+		ProvideReliabilityScores,
 
 		// Use Cases
-		ProvidePackageUseCase,
+		usecase.NewPackage,
+		usecase.NewCarrier,
+		usecase.NewReport,
+		usecase.NewWarehouse,
+
+		// Background jobs
+		NewOverdueJob,
+		NewQuotePurgeJob,
+		NewReliabilityJob,
 
 		// HTTP
 		http.NewControllerManager,
@@ -35,9 +61,125 @@ func providers() []any {
 	return globalDeps
 }
 
-func ProvidePackageUseCase() *usecase.PackageUseCase {
-	repository := persistence.NewInMemoryPackageRepository()
-	carrierRepo := integration.NewCarrierRepository()
-	service := service.NewPackageService(carrierRepo)
-	return usecase.NewPackage(repository, service)
+// Repositories groups the repositories backed by the storage configured in storage.driver
+type Repositories struct {
+	fx.Out
+
+	Packages domain.PackageRepository
+	Quotes   domain.QuoteRepository
+}
+
+// ProvideRepositories selects the storage configured in storage.driver. SQL drivers
+// share a single connection pool between the repositories.
+func ProvideRepositories(cfg *config.Config, lc fx.Lifecycle) (Repositories, error) {
+	var db *sql.DB
+	var err error
+
+	switch cfg.Storage.Driver {
+	case config.StorageDriverMemory:
+		return Repositories{
+			Packages: persistence.NewInMemoryPackageRepository(),
+			Quotes:   persistence.NewInMemoryQuoteRepository(),
+		}, nil
+
+	case config.StorageDriverPostgres:
+		db, err = persistence.OpenPostgres(cfg.Storage.Postgres)
+
+	case config.StorageDriverSQLite:
+		db, err = persistence.OpenSQLite(cfg.Storage.SQLite)
+
+	default:
+		return Repositories{}, fmt.Errorf("unknown storage driver: %s", cfg.Storage.Driver)
+	}
+	if err != nil {
+		return Repositories{}, err
+	}
+
+	closeOnStop(lc, db)
+	return Repositories{
+		Packages: persistence.NewSQLPackageRepository(db),
+		Quotes:   persistence.NewSQLQuoteRepository(db),
+	}, nil
+}
+
+// ProvideCarrierRepository loads the carrier catalog file, which also stores the changes
+// made through the API. If carriers.hot_reload is enabled, manual edits to the file are
+// picked up while the application runs.
+func ProvideCarrierRepository(cfg *config.Config, lc fx.Lifecycle) (*integration.CarrierRepositoryImpl, error) {
+	repo, err := integration.NewFileCarrierRepository(cfg.Carriers.CatalogPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if !cfg.Carriers.HotReload {
+		return repo, nil
+	}
+
+	watcher, err := integration.WatchCarrierCatalog(cfg.Carriers.CatalogPath, repo)
+	if err != nil {
+		return nil, fmt.Errorf("watching carrier catalog: %w", err)
+	}
+
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			return watcher.Close()
+		},
+	})
+	return repo, nil
+}
+
+// ProvideWarehouseRepository loads the warehouses set in warehouses, failing the startup
+// when one of them has an unknown state, a CEP outside the state or a repeated ID
+func ProvideWarehouseRepository(cfg *config.Config) (domain.WarehouseRepository, error) {
+	warehouses := make([]*domain.Warehouse, len(cfg.Warehouses))
+	for i, w := range cfg.Warehouses {
+		if slices.ContainsFunc(warehouses[:i], func(previous *domain.Warehouse) bool { return previous.ID == w.ID }) {
+			return nil, fmt.Errorf("loading warehouse %s: repeated ID", w.ID)
+		}
+
+		cep, err := vo.ParseCEP(w.CEP)
+		if err != nil {
+			return nil, fmt.Errorf("loading warehouse %s: %w", w.ID, err)
+		}
+		if warehouses[i], err = domain.NewWarehouse(w.ID, w.Name, w.State, cep); err != nil {
+			return nil, fmt.Errorf("loading warehouse %s: %w", w.ID, err)
+		}
+	}
+
+	return persistence.NewInMemoryWarehouseRepository(warehouses), nil
+}
+
+// ProvideShippingQuoter quotes carriers with a quote URL through their API and the
+// others through the catalog price table
+func ProvideShippingQuoter(cfg *config.Config) integration.ShippingQuoter {
+	return integration.NewCarrierQuoter(integration.NewHTTPQuoter(cfg.Carriers.HTTP))
+}
+
+// ProvideHolidayCalendar skips the built-in Brazilian holidays plus the days listed in
+// holidays.national and holidays.states when computing delivery dates
+func ProvideHolidayCalendar(cfg *config.Config) (domain.HolidayCalendar, error) {
+	calendar, err := domain.NewBrazilianCalendar(cfg.Holidays.National, cfg.Holidays.States)
+	if err != nil {
+		return nil, fmt.Errorf("loading holidays: %w", err)
+	}
+	return calendar, nil
+}
+
+// ProvidePackageService bounds the quote of each carrier by carriers.quote_timeout, keeps
+// quotes valid for carriers.quote_validity and promises delivery dates in business days
+func ProvidePackageService(cfg *config.Config, carrierRepo integration.CarrierRepository, quoter integration.ShippingQuoter, calendar domain.HolidayCalendar) *service.PackageService {
+	return service.NewPackageService(carrierRepo, quoter, cfg.Carriers.QuoteTimeout, cfg.Carriers.QuoteValidity, calendar)
+}
+
+// ProvideReliabilityScores keeps the carrier reliability scores for carriers.reliability_ttl
+func ProvideReliabilityScores(cfg *config.Config) *service.ReliabilityScores {
+	return service.NewReliabilityScores(cfg.Carriers.ReliabilityTTL)
+}
+
+func closeOnStop(lc fx.Lifecycle, db *sql.DB) {
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			return db.Close()
+		},
+	})
 }
@@ -0,0 +1,41 @@
+package application
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain"
+	"github.com/foliveiracamara/delivery-manager-api/internal/infrastructure/config"
+)
+
+// QuotePurgeJob periodically deletes the quotes expired for longer than
+// carriers.quote_retention. Recently expired quotes are kept so that hiring one still
+// answers 410 Gone instead of 404. A purge that fails is logged and retried on the next
+// tick.
+type QuotePurgeJob struct {
+	*periodicJob
+	quotes    domain.QuoteRepository
+	retention time.Duration
+}
+
+// NewQuotePurgeJob purges every carriers.quote_purge_interval; a zero interval disables
+// the job
+func NewQuotePurgeJob(cfg *config.Config, quotes domain.QuoteRepository) *QuotePurgeJob {
+	job := &QuotePurgeJob{
+		quotes:    quotes,
+		retention: cfg.Carriers.QuoteRetention,
+	}
+	job.periodicJob = newPeriodicJob(cfg.Carriers.QuotePurgeInterval, job.purge)
+	return job
+}
+
+func (j *QuotePurgeJob) purge() {
+	deleted, err := j.quotes.DeleteExpired(time.Now().Add(-j.retention))
+	if err != nil {
+		slog.Error("purging expired quotes", "error", err)
+		return
+	}
+	if deleted > 0 {
+		slog.Info("expired quotes purged", "deleted", deleted)
+	}
+}
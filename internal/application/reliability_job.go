@@ -0,0 +1,35 @@
+package application
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/application/usecase"
+	"github.com/foliveiracamara/delivery-manager-api/internal/infrastructure/config"
+)
+
+// ReliabilityJob periodically recomputes the carrier reliability scores from the finished
+// packages, so that quotes sorted by reliability read the stored scores instead of
+// scanning the delivery history. A refresh that fails is logged and retried on the next
+// tick; until then quotes use the previous scores while they are within
+// carriers.reliability_ttl.
+type ReliabilityJob struct {
+	*periodicJob
+	reports *usecase.ReportUseCase
+}
+
+// NewReliabilityJob refreshes every carriers.reliability_refresh; a zero interval
+// disables the job
+func NewReliabilityJob(cfg *config.Config, reports *usecase.ReportUseCase) *ReliabilityJob {
+	job := &ReliabilityJob{
+		reports: reports,
+	}
+	job.periodicJob = newPeriodicJob(cfg.Carriers.ReliabilityRefresh, job.refresh)
+	return job
+}
+
+func (j *ReliabilityJob) refresh() {
+	if err := j.reports.RefreshReliability(time.Now()); err != nil {
+		slog.Error("refreshing carrier reliability scores", "error", err)
+	}
+}
@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"slices"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/api/http/dto"
+	"github.com/foliveiracamara/delivery-manager-api/internal/infrastructure/integration"
+	apperr "github.com/foliveiracamara/delivery-manager-api/internal/shared/apperror"
+)
+
+type CarrierUseCase struct {
+	repository integration.WritableCarrierRepository
+}
+
+func NewCarrier(repository integration.WritableCarrierRepository) *CarrierUseCase {
+	return &CarrierUseCase{
+		repository: repository,
+	}
+}
+
+// List retorna todas as transportadoras, inclusive as inativas
+func (s CarrierUseCase) List() []*integration.Carrier {
+	return s.repository.GetAll()
+}
+
+func (s CarrierUseCase) Get(id string) (*integration.Carrier, error) {
+	return s.repository.GetByID(id)
+}
+
+func (s CarrierUseCase) Create(req dto.CreateCarrierRequest) (*integration.Carrier, error) {
+	carrier := integration.NewCarrier(req.ID, req.Nome, toCarrierRegions(req.Regioes))
+	carrier.MaxWeightKg = req.PesoMaximoKg
+	carrier.QuoteURL = req.CotacaoURL
+
+	if err := s.repository.Create(carrier); err != nil {
+		return nil, err
+	}
+
+	return carrier, nil
+}
+
+// Update substitui o nome, as regiões, o peso máximo e a API de cotação da transportadora.
+// Pacotes já contratados mantêm o preço e o prazo cotados na contratação.
+func (s CarrierUseCase) Update(id string, req dto.UpdateCarrierRequest) (*integration.Carrier, error) {
+	return s.repository.Update(id, func(carrier *integration.Carrier) error {
+		carrier.Name = req.Nome
+		carrier.Regions = toCarrierRegions(req.Regioes)
+		carrier.MaxWeightKg = req.PesoMaximoKg
+		carrier.QuoteURL = req.CotacaoURL
+		return nil
+	})
+}
+
+// SetActive ativa ou desativa a transportadora; inativas não são cotadas nem contratadas
+func (s CarrierUseCase) SetActive(id string, active bool) (*integration.Carrier, error) {
+	return s.repository.Update(id, func(carrier *integration.Carrier) error {
+		carrier.Inactive = !active
+		return nil
+	})
+}
+
+func (s CarrierUseCase) Delete(id string) error {
+	return s.repository.Delete(id)
+}
+
+func (s CarrierUseCase) AddRegion(id string, req dto.CarrierRegionRequest) (*integration.Carrier, error) {
+	return s.repository.Update(id, func(carrier *integration.Carrier) error {
+		if carrier.IsAvailableForRegion(req.Regiao) {
+			return apperr.NewConflictError("Carrier already serves region: " + req.Regiao)
+		}
+
+		carrier.Regions = append(carrier.Regions, toCarrierRegion(req))
+		return nil
+	})
+}
+
+func (s CarrierUseCase) UpdateRegion(id, region string, req dto.UpdateCarrierRegionRequest) (*integration.Carrier, error) {
+	return s.repository.Update(id, func(carrier *integration.Carrier) error {
+		i, err := regionIndex(carrier, region)
+		if err != nil {
+			return err
+		}
+
+		carrier.Regions[i] = toCarrierRegion(dto.CarrierRegionRequest{
+			Regiao:              region,
+			PrazoEstimadoDias:   req.PrazoEstimadoDias,
+			PrecoPorKg:          req.PrecoPorKg,
+			FaixasPeso:          req.FaixasPeso,
+			ValorMinimo:         req.ValorMinimo,
+			FatorCubagem:        req.FatorCubagem,
+			Origens:             req.Origens,
+			FaixasCEP:           req.FaixasCEP,
+			AdValoremPercentual: req.AdValoremPercentual,
+			SeguroMinimo:        req.SeguroMinimo,
+			TaxasAdicionais:     req.TaxasAdicionais,
+		})
+		return nil
+	})
+}
+
+func (s CarrierUseCase) RemoveRegion(id, region string) (*integration.Carrier, error) {
+	return s.repository.Update(id, func(carrier *integration.Carrier) error {
+		i, err := regionIndex(carrier, region)
+		if err != nil {
+			return err
+		}
+
+		if len(carrier.Regions) == 1 {
+			return apperr.NewBadRequestError("Carrier must serve at least one region; deactivate it instead")
+		}
+
+		carrier.Regions = slices.Delete(carrier.Regions, i, i+1)
+		return nil
+	})
+}
+
+func regionIndex(carrier *integration.Carrier, region string) (int, error) {
+	i := slices.IndexFunc(carrier.Regions, func(r integration.CarrierRegion) bool { return r.Region == region })
+	if i < 0 {
+		return 0, apperr.NewNotFoundError("Carrier does not serve region: " + region)
+	}
+	return i, nil
+}
+
+func toCarrierRegions(regions []dto.CarrierRegionRequest) []integration.CarrierRegion {
+	result := make([]integration.CarrierRegion, len(regions))
+	for i, region := range regions {
+		result[i] = toCarrierRegion(region)
+	}
+	return result
+}
+
+func toCarrierRegion(req dto.CarrierRegionRequest) integration.CarrierRegion {
+	region := integration.CarrierRegion{
+		Region:           req.Regiao,
+		EstimatedDays:    req.PrazoEstimadoDias,
+		PricePerKg:       req.PrecoPorKg,
+		MinimumCharge:    req.ValorMinimo,
+		CubingFactor:     req.FatorCubagem,
+		WeightBands:      toWeightBands(req.FaixasPeso),
+		AdValoremPercent: req.AdValoremPercentual,
+		MinimumInsurance: req.SeguroMinimo,
+	}
+	for _, rate := range req.Origens {
+		region.Origins = append(region.Origins, integration.OriginRate{
+			Origin:        rate.Origem,
+			Unserved:      rate.NaoAtendida,
+			EstimatedDays: rate.PrazoEstimadoDias,
+			PricePerKg:    rate.PrecoPorKg,
+			WeightBands:   toWeightBands(rate.FaixasPeso),
+			MinimumCharge: rate.ValorMinimo,
+		})
+	}
+	for _, cepRange := range req.FaixasCEP {
+		region.CEPRanges = append(region.CEPRanges, integration.CEPRange{
+			Name:          cepRange.Nome,
+			From:          cepRange.CEPInicio,
+			To:            cepRange.CEPFim,
+			Unserved:      cepRange.NaoAtendida,
+			EstimatedDays: cepRange.PrazoEstimadoDias,
+			PricePerKg:    cepRange.PrecoPorKg,
+			WeightBands:   toWeightBands(cepRange.FaixasPeso),
+			MinimumCharge: cepRange.ValorMinimo,
+		})
+	}
+	for _, surcharge := range req.TaxasAdicionais {
+		region.Surcharges = append(region.Surcharges, integration.Surcharge{
+			Name:   surcharge.Nome,
+			Amount: surcharge.Valor,
+		})
+	}
+	return region
+}
+
+func toWeightBands(bands []dto.WeightBandRequest) []integration.WeightBand {
+	var result []integration.WeightBand
+	for _, band := range bands {
+		result = append(result, integration.WeightBand{
+			UpToKg:     band.AteKg,
+			FixedPrice: band.PrecoFixo,
+			PricePerKg: band.PrecoPorKg,
+		})
+	}
+	return result
+}
@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/api/http/dto"
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
+	"github.com/foliveiracamara/delivery-manager-api/internal/infrastructure/integration"
+	"github.com/foliveiracamara/delivery-manager-api/internal/infrastructure/persistence"
+	"github.com/foliveiracamara/delivery-manager-api/internal/service"
+	apperr "github.com/foliveiracamara/delivery-manager-api/internal/shared/apperror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCarrierUseCase() *CarrierUseCase {
+	return NewCarrier(newCarrierRepository())
+}
+
+func TestCarrierUseCase_Regions(t *testing.T) {
+	t.Run("should add a region", func(t *testing.T) {
+		uc := newCarrierUseCase()
+
+		carrier, err := uc.AddRegion("nebulix", dto.CarrierRegionRequest{Regiao: "centro-oeste", PrazoEstimadoDias: 6, PrecoPorKg: vo.NewMoney(710)})
+
+		require.NoError(t, err)
+		region, ok := carrier.GetRegionInfo("centro-oeste")
+		assert.True(t, ok)
+		assert.Equal(t, vo.NewMoney(710), region.PricePerKg)
+	})
+
+	t.Run("should update a region", func(t *testing.T) {
+		uc := newCarrierUseCase()
+
+		carrier, err := uc.UpdateRegion("nebulix", "sul", dto.UpdateCarrierRegionRequest{PrazoEstimadoDias: 5, PrecoPorKg: vo.NewMoney(640), FatorCubagem: 250})
+
+		require.NoError(t, err)
+		region, _ := carrier.GetRegionInfo("sul")
+		assert.Equal(t, 5, region.EstimatedDays)
+		assert.Equal(t, vo.NewMoney(640), region.PricePerKg)
+		assert.Equal(t, 250.0, region.CubingFactor)
+	})
+
+	t.Run("should replace a per kg table with weight bands", func(t *testing.T) {
+		uc := newCarrierUseCase()
+
+		carrier, err := uc.UpdateRegion("nebulix", "sul", dto.UpdateCarrierRegionRequest{
+			PrazoEstimadoDias: 4,
+			ValorMinimo:       vo.NewMoney(1290),
+			FaixasPeso: []dto.WeightBandRequest{
+				{AteKg: 1, PrecoFixo: vo.NewMoney(1290)},
+				{PrecoFixo: vo.NewMoney(1290), PrecoPorKg: vo.NewMoney(435)},
+			},
+		})
+
+		require.NoError(t, err)
+		region, _ := carrier.GetRegionInfo("sul")
+		assert.Zero(t, region.PricePerKg)
+		assert.Equal(t, vo.NewMoney(1290), region.MinimumCharge)
+		assert.Equal(t, []integration.WeightBand{{UpToKg: 1, FixedPrice: vo.NewMoney(1290)}, {FixedPrice: vo.NewMoney(1290), PricePerKg: vo.NewMoney(435)}}, region.WeightBands)
+	})
+
+	t.Run("should set the insurance and the surcharges of a region", func(t *testing.T) {
+		uc := newCarrierUseCase()
+
+		carrier, err := uc.UpdateRegion("nebulix", "sul", dto.UpdateCarrierRegionRequest{
+			PrazoEstimadoDias:   4,
+			PrecoPorKg:          vo.NewMoney(590),
+			AdValoremPercentual: 0.5,
+			SeguroMinimo:        vo.NewMoney(200),
+			TaxasAdicionais:     []dto.SurchargeRequest{{Nome: "coleta", Valor: vo.NewMoney(490)}},
+		})
+
+		require.NoError(t, err)
+		region, _ := carrier.GetRegionInfo("sul")
+		assert.Equal(t, 0.5, region.AdValoremPercent)
+		assert.Equal(t, vo.NewMoney(200), region.MinimumInsurance)
+		assert.Equal(t, []integration.Surcharge{{Name: "coleta", Amount: vo.NewMoney(490)}}, region.Surcharges)
+	})
+
+	t.Run("should remove a region", func(t *testing.T) {
+		uc := newCarrierUseCase()
+
+		carrier, err := uc.RemoveRegion("nebulix", "sul")
+
+		require.NoError(t, err)
+		assert.False(t, carrier.IsAvailableForRegion("sul"))
+		assert.True(t, carrier.IsAvailableForRegion("sudeste"))
+	})
+
+	t.Run("should reject invalid region changes", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			change   func(uc *CarrierUseCase) error
+			code     int
+			expected string
+		}{
+			{
+				name: "duplicated region",
+				change: func(uc *CarrierUseCase) error {
+					_, err := uc.AddRegion("nebulix", dto.CarrierRegionRequest{Regiao: "sul", PrazoEstimadoDias: 4, PrecoPorKg: vo.NewMoney(590)})
+					return err
+				},
+				code:     http.StatusConflict,
+				expected: "Carrier already serves region: sul",
+			},
+			{
+				name: "update region not served",
+				change: func(uc *CarrierUseCase) error {
+					_, err := uc.UpdateRegion("nebulix", "norte", dto.UpdateCarrierRegionRequest{PrazoEstimadoDias: 4, PrecoPorKg: vo.NewMoney(590)})
+					return err
+				},
+				code:     http.StatusNotFound,
+				expected: "Carrier does not serve region: norte",
+			},
+			{
+				name: "weight bands out of order",
+				change: func(uc *CarrierUseCase) error {
+					_, err := uc.AddRegion("nebulix", dto.CarrierRegionRequest{
+						Regiao:            "norte",
+						PrazoEstimadoDias: 9,
+						FaixasPeso:        []dto.WeightBandRequest{{AteKg: 5, PrecoFixo: vo.NewMoney(2000)}, {AteKg: 1, PrecoFixo: vo.NewMoney(1500)}, {PrecoFixo: vo.NewMoney(2000), PrecoPorKg: vo.NewMoney(600)}},
+					})
+					return err
+				},
+				code:     http.StatusBadRequest,
+				expected: "WeightBands[1].UpToKg",
+			},
+			{
+				name: "remove last region",
+				change: func(uc *CarrierUseCase) error {
+					_, err := uc.RemoveRegion("moventra", "nordeste")
+					return err
+				},
+				code:     http.StatusBadRequest,
+				expected: "Carrier must serve at least one region",
+			},
+			{
+				name: "unknown carrier",
+				change: func(uc *CarrierUseCase) error {
+					_, err := uc.RemoveRegion("unknown", "sul")
+					return err
+				},
+				code:     http.StatusNotFound,
+				expected: "Carrier not found",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				err := tt.change(newCarrierUseCase())
+
+				var appErr *apperr.AppErr
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.code, appErr.Code)
+				assert.Contains(t, appErr.Message, tt.expected)
+			})
+		}
+	})
+}
+
+// Alterar ou desativar uma transportadora não pode mudar o frete já contratado
+func TestCarrierUseCase_HiredShipmentsKeepTheirQuote(t *testing.T) {
+	carriers := newCarrierRepository().(*integration.CarrierRepositoryImpl)
+	packages := NewPackage(persistence.NewInMemoryPackageRepository(), persistence.NewInMemoryQuoteRepository(), newWarehouseRepository(), service.NewPackageService(carriers, integration.TableQuoter{}, 0, 0, nil), service.NewReliabilityScores(0))
+	admin := NewCarrier(carriers)
+
+	id := createPackage(t, packages, dto.PackageRequest{Product: "Camisa", WeightKg: 2, EstadoDestino: "PR", Destinatario: newRecipient()})
+	_, err := packages.HireCarrier(context.Background(), id, "nebulix", "checkout", nil, 0)
+	require.NoError(t, err)
+
+	_, err = admin.UpdateRegion("nebulix", "sul", dto.UpdateCarrierRegionRequest{PrazoEstimadoDias: 9, PrecoPorKg: vo.NewMoney(2000)})
+	require.NoError(t, err)
+	_, err = admin.SetActive("nebulix", false)
+	require.NoError(t, err)
+
+	pkg, err := packages.Get(id)
+	require.NoError(t, err)
+	assert.Equal(t, vo.NewMoney(1180), pkg.Shipping.EstimatedPrice)
+	assert.Equal(t, 4, pkg.Shipping.EstimatedDays)
+
+	t.Run("inactive carrier is no longer quoted nor hired", func(t *testing.T) {
+		other := createPackage(t, packages, dto.PackageRequest{Product: "Camisa", WeightKg: 2, EstadoDestino: "PR", Destinatario: newRecipient()})
+
+		quotes, failures, err := packages.QuoteShipping(context.Background(), other, "")
+		require.NoError(t, err)
+		assert.Empty(t, quotes)
+		assert.Empty(t, failures)
+
+		_, err = packages.HireCarrier(context.Background(), other, "nebulix", "checkout", nil, 0)
+		assert.ErrorContains(t, err, "Carrier is inactive")
+	})
+}
@@ -1,6 +1,11 @@
 package usecase
 
 import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/foliveiracamara/delivery-manager-api/internal/api/http/dto"
 	"github.com/foliveiracamara/delivery-manager-api/internal/domain"
 	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
@@ -9,40 +14,175 @@ import (
 )
 
 type PackageUseCase struct {
-	repository domain.PackageRepository
-	service    *service.PackageService
+	repository  domain.PackageRepository
+	quotes      domain.QuoteRepository
+	warehouses  domain.WarehouseRepository
+	service     *service.PackageService
+	reliability *service.ReliabilityScores
 }
 
-func NewPackage(repository domain.PackageRepository, service *service.PackageService) *PackageUseCase {
+func NewPackage(repository domain.PackageRepository, quotes domain.QuoteRepository, warehouses domain.WarehouseRepository, service *service.PackageService, reliability *service.ReliabilityScores) *PackageUseCase {
 	return &PackageUseCase{
-		repository: repository,
-		service:    service,
+		repository:  repository,
+		quotes:      quotes,
+		warehouses:  warehouses,
+		service:     service,
+		reliability: reliability,
 	}
 }
 
-func (s PackageUseCase) Create(dto dto.PackageRequest) (id string, err error) {
+// Create cria o pacote. Sem CEP de destino, vale o CEP do endereço do destinatário, e sem
+// estado de destino, o estado vem do CEP. A origem, quando informada, é o ID de um dos
+// armazéns cadastrados. O destinatário é opcional na criação, mas com política de
+// contratação o frete é cotado e contratado na mesma chamada e ele passa a ser exigido;
+// se nenhuma transportadora atender a política, o pacote não é criado.
+func (s PackageUseCase) Create(ctx context.Context, dto dto.PackageRequest) (*domain.Package, error) {
+	recipient, err := toContact(dto.Destinatario, "recipient")
+	if err != nil {
+		return nil, err
+	}
+	sender, err := toContact(dto.Remetente, "sender")
+	if err != nil {
+		return nil, err
+	}
+
+	cep := dto.CEPDestino
+	if cep.IsZero() && recipient != nil {
+		cep = recipient.Address.CEP
+	}
+
+	state := dto.EstadoDestino
+	if state == "" && cep.IsZero() {
+		return nil, apperr.NewBadRequestError("Destination state, destination CEP or recipient is required")
+	}
+	if state == "" {
+		var found bool
+		if state, found = domain.GetStateFromCEP(cep); !found {
+			return nil, apperr.NewBadRequestError("CEP not found in any state: " + cep.String())
+		}
+	}
+
 	// Convert state to region
-	region, exists := domain.GetRegionFromState(dto.EstadoDestino)
+	region, exists := domain.GetRegionFromState(state)
 	if !exists {
-		return "", apperr.NewBadRequestError("Invalid state: " + dto.EstadoDestino)
+		return nil, apperr.NewBadRequestError("Invalid state: " + state)
 	}
 
-	pkg, err := s.service.Create(&domain.Package{
+	items, err := toItems(dto.Itens)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &domain.Package{
 		Product:           dto.Product,
 		WeightKg:          dto.WeightKg,
 		DestinationRegion: region,
-		DestinationState:  dto.EstadoDestino,
-	})
+		DestinationState:  state,
+		DestinationCEP:    cep,
+		Recipient:         recipient,
+		Sender:            sender,
+		Items:             items,
+		DeclaredValue:     dto.ValorDeclarado,
+	}
+	if dto.Dimensoes != nil {
+		input.Dimensions = vo.NewDimensions(dto.Dimensoes.ComprimentoCm, dto.Dimensoes.LarguraCm, dto.Dimensoes.AlturaCm)
+	}
+	if dto.Origem != "" {
+		warehouse, err := s.warehouses.GetByID(dto.Origem)
+		if err != nil {
+			return nil, err
+		}
+		input.SetOrigin(*warehouse)
+	}
+
+	pkg, err := s.service.Create(input)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	if req := dto.PoliticaContratacao; req != nil {
+		policy, err := service.NewHirePolicy(req.Tipo, req.PrazoMaximoDias, req.PrecoMaximo)
+		if err != nil {
+			return nil, err
+		}
+		if err := requireRecipient(pkg, nil); err != nil {
+			return nil, err
+		}
+		if err := s.service.HireByPolicy(ctx, pkg, policy, domain.SystemActor); err != nil {
+			return nil, err
+		}
 	}
 
 	err = s.repository.Save(pkg)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	return pkg, nil
+}
+
+// requireRecipient exige o destinatário para contratar o frete. Pacotes criados sem ele
+// recebem o destinatário informado na contratação.
+func requireRecipient(pkg *domain.Package, req *dto.ContactRequest) error {
+	recipient, err := toContact(req, "recipient")
+	if err != nil {
+		return err
+	}
+
+	if recipient == nil {
+		if pkg.Recipient == nil {
+			return apperr.NewBadRequestError("Recipient is required to hire a carrier")
+		}
+		return nil
+	}
+
+	if pkg.Recipient != nil {
+		return apperr.NewConflictError("Package already has a recipient")
+	}
+	return pkg.SetRecipient(*recipient)
+}
+
+// toItems converte os itens da requisição, retornando nil quando não há itens
+func toItems(req []dto.ItemRequest) ([]vo.Item, error) {
+	if len(req) == 0 {
+		return nil, nil
+	}
+
+	items := make([]vo.Item, len(req))
+	for i, item := range req {
+		var err error
+		items[i], err = vo.NewItem(item.SKU, item.Descricao, item.Quantidade, item.PesoUnitarioKg, item.ValorUnitario, item.NCM)
+		if err != nil {
+			return nil, apperr.NewBadRequestError(fmt.Sprintf("Invalid item %d: %s", i+1, err))
+		}
+	}
+	return items, nil
+}
+
+// toContact converte o destinatário ou o remetente da requisição, identificado por role
+// nos erros, retornando nil quando ele não foi informado
+func toContact(req *dto.ContactRequest, role string) (*vo.Contact, error) {
+	if req == nil {
+		return nil, nil
+	}
+
+	address, err := vo.NewAddress(
+		req.Endereco.Logradouro,
+		req.Endereco.Numero,
+		req.Endereco.Complemento,
+		req.Endereco.Bairro,
+		req.Endereco.Cidade,
+		req.Endereco.CEP,
+	)
+	if err != nil {
+		return nil, apperr.NewBadRequestError("Invalid " + role + ": " + err.Error())
 	}
 
-	return pkg.ID, nil
+	contact, err := vo.NewContact(req.Nome, req.Documento, req.Telefone, address)
+	if err != nil {
+		return nil, apperr.NewBadRequestError("Invalid " + role + ": " + err.Error())
+	}
+	return &contact, nil
 }
 
 func (s PackageUseCase) Get(id string) (*domain.Package, error) {
@@ -53,44 +193,226 @@ func (s PackageUseCase) Get(id string) (*domain.Package, error) {
 	return pkg, nil
 }
 
-func (s PackageUseCase) UpdateStatus(id string, status string) error {
+// UpdateStatus altera o status do pacote. expectedVersion vem do If-Match; 0 dispensa a verificação.
+func (s PackageUseCase) UpdateStatus(id, status, actor, note string, expectedVersion int) (*domain.Package, error) {
 	pkg, err := s.repository.GetByID(id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	err = s.service.UpdateStatus(pkg, domain.PackageStatus(status))
+	if err := checkVersion(pkg, expectedVersion); err != nil {
+		return nil, err
+	}
+
+	err = s.service.UpdateStatus(pkg, domain.PackageStatus(status), actor, note)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	err = s.repository.Save(pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	return pkg, nil
+}
+
+func (s PackageUseCase) List(req dto.ListPackagesRequest) (*domain.PackagePage, error) {
+	filter := domain.PackageFilter{
+		Region:      domain.DestinationRegion(req.RegiaoDestino),
+		State:       strings.ToUpper(req.EstadoDestino),
+		CarrierID:   req.TransportadoraID,
+		MinWeightKg: req.PesoMin,
+		MaxWeightKg: req.PesoMax,
+	}
+
+	if filter.State != "" {
+		if _, exists := domain.GetRegionFromState(filter.State); !exists {
+			return nil, apperr.NewBadRequestError("Invalid state: " + req.EstadoDestino)
+		}
+	}
+
+	if req.Status != "" {
+		for _, status := range strings.Split(req.Status, ",") {
+			filter.Statuses = append(filter.Statuses, domain.PackageStatus(strings.TrimSpace(status)))
+		}
+	}
+
+	var err error
+	for _, dateFilter := range []struct {
+		value  string
+		target *time.Time
+		endOf  bool
+	}{
+		{req.CriadoDe, &filter.CreatedFrom, false},
+		{req.CriadoAte, &filter.CreatedTo, true},
+		{req.AtualizadoDe, &filter.UpdatedFrom, false},
+		{req.AtualizadoAte, &filter.UpdatedTo, true},
+	} {
+		if *dateFilter.target, err = parseDateFilter(dateFilter.value, dateFilter.endOf); err != nil {
+			return nil, err
+		}
+	}
+
+	if filter.MaxWeightKg > 0 && filter.MinWeightKg > filter.MaxWeightKg {
+		return nil, apperr.NewBadRequestError("peso_min cannot be greater than peso_max")
+	}
+
+	// Sem ordenação explícita, os pacotes mais recentes vêm primeiro
+	sortBy, descending := domain.SortByCreatedAt, true
+	if req.Ordenar != "" {
+		descending = strings.HasPrefix(req.Ordenar, "-")
+		sortBy = domain.PackageSortField(strings.TrimPrefix(req.Ordenar, "-"))
 	}
 
-	return s.repository.Save(pkg)
+	query, err := domain.NewPackageQuery(filter, sortBy, descending, req.Limite, req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repository.List(query)
 }
 
-func (s PackageUseCase) QuoteShipping(id string) ([]vo.Shipping, error) {
+// parseDateFilter aceita RFC3339 ou apenas a data (AAAA-MM-DD). Datas sem horário usadas
+// como limite final incluem o dia inteiro.
+func parseDateFilter(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, apperr.NewBadRequestError("Invalid date: " + value + " (expected YYYY-MM-DD or RFC3339)")
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	return t, nil
+}
+
+func (s PackageUseCase) GetHistory(id string) ([]domain.StatusEvent, error) {
 	pkg, err := s.repository.GetByID(id)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.service.QuoteAvailableShippings(pkg)
+	return pkg.History, nil
 }
 
-func (s PackageUseCase) HireCarrier(id string, carrierID string) error {
+func (s PackageUseCase) GetTransitions(id string) (*domain.Package, []domain.PackageStatus, error) {
 	pkg, err := s.repository.GetByID(id)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	err = s.service.HireCarrier(pkg, carrierID)
+	return pkg, pkg.NextStatuses(), nil
+}
+
+// QuoteShipping registra as cotações obtidas, ordenadas por sort (fastest, cheapest,
+// best_value ou reliable; vazio ordena pelo prazo), e retorna também as transportadoras
+// que falharam. A ordenação reliable usa as notas de confiabilidade já calculadas pela
+// verificação periódica.
+func (s PackageUseCase) QuoteShipping(ctx context.Context, id, sort string) ([]*domain.Quote, []vo.QuoteFailure, error) {
+	ranking, err := service.ParseQuoteRanking(sort)
 	if err != nil {
-		return err
+		return nil, nil, err
+	}
+	if _, ok := ranking.(service.ReliableRanking); ok {
+		ranking = s.reliability.Ranking(time.Now())
+	}
+
+	pkg, err := s.repository.GetByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	shippings, failures := s.service.QuoteAvailableShippings(ctx, pkg, ranking)
+
+	quotes := s.service.NewQuotes(pkg, shippings)
+	for _, quote := range quotes {
+		if err := s.quotes.Save(quote); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return quotes, failures, nil
+}
+
+// HireCarrier contrata a transportadora. recipient informa o destinatário de pacotes
+// criados sem ele. expectedVersion vem do If-Match; 0 dispensa a verificação.
+func (s PackageUseCase) HireCarrier(ctx context.Context, id, carrierID, actor string, recipient *dto.ContactRequest, expectedVersion int) (*domain.Package, error) {
+	pkg, err := s.repository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkVersion(pkg, expectedVersion); err != nil {
+		return nil, err
+	}
+
+	if err := requireRecipient(pkg, recipient); err != nil {
+		return nil, err
+	}
+
+	err = s.service.HireCarrier(ctx, pkg, carrierID, actor)
+	if err != nil {
+		return nil, err
 	}
 
 	err = s.repository.Save(pkg)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	return pkg, nil
+}
+
+// HireQuote contrata o frete da cotação pelo preço cotado. recipient informa o
+// destinatário de pacotes criados sem ele. expectedVersion vem do If-Match; 0 dispensa a
+// verificação.
+func (s PackageUseCase) HireQuote(id, quoteID, actor string, recipient *dto.ContactRequest, expectedVersion int) (*domain.Package, error) {
+	pkg, err := s.repository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkVersion(pkg, expectedVersion); err != nil {
+		return nil, err
+	}
+
+	if err := requireRecipient(pkg, recipient); err != nil {
+		return nil, err
+	}
+
+	quote, err := s.quotes.GetByID(quoteID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.service.HireQuote(pkg, quote, actor)
+	if err != nil {
+		return nil, err
 	}
 
+	err = s.repository.Save(pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	return pkg, nil
+}
+
+// checkVersion rejeita a operação quando o cliente editou uma versão desatualizada do pacote
+func checkVersion(pkg *domain.Package, expectedVersion int) error {
+	if expectedVersion != 0 && pkg.Version != expectedVersion {
+		return apperr.NewPreconditionFailedError(fmt.Sprintf(
+			"Package version mismatch: expected %d, current is %d", expectedVersion, pkg.Version,
+		))
+	}
 	return nil
 }
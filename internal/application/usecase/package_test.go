@@ -0,0 +1,770 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/api/http/dto"
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain"
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
+	"github.com/foliveiracamara/delivery-manager-api/internal/infrastructure/integration"
+	"github.com/foliveiracamara/delivery-manager-api/internal/infrastructure/persistence"
+	"github.com/foliveiracamara/delivery-manager-api/internal/service"
+	apperr "github.com/foliveiracamara/delivery-manager-api/internal/shared/apperror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCarrierRepository() integration.WritableCarrierRepository {
+	return integration.NewCarrierRepository([]*integration.Carrier{
+		integration.NewCarrier("nebulix", "Nebulix Logística", []integration.CarrierRegion{
+			{Region: "sul", EstimatedDays: 4, PricePerKg: vo.NewMoney(590)},
+			{Region: "sudeste", EstimatedDays: 4, PricePerKg: vo.NewMoney(590)},
+		}),
+		integration.NewCarrier("moventra", "Moventra Express", []integration.CarrierRegion{
+			{Region: "nordeste", EstimatedDays: 10, PricePerKg: vo.NewMoney(950)},
+		}),
+	})
+}
+
+func newWarehouseRepository() domain.WarehouseRepository {
+	return persistence.NewInMemoryWarehouseRepository([]*domain.Warehouse{
+		{ID: "curitiba", Name: "CD Curitiba", State: "PR", CEP: "81460000"},
+		{ID: "recife", Name: "CD Recife", State: "PE", CEP: "50050000"},
+	})
+}
+
+// Run with -race: simulates concurrent handlers driving many packages through
+// create, quote, hire and status updates on the same repository
+func createPackage(t *testing.T, uc *PackageUseCase, req dto.PackageRequest) string {
+	t.Helper()
+
+	pkg, err := uc.Create(context.Background(), req)
+	require.NoError(t, err)
+	return pkg.ID
+}
+
+// newRecipient retorna um destinatário em Curitiba, válido para pacotes destinados ao PR
+func newRecipient() *dto.ContactRequest {
+	return &dto.ContactRequest{
+		Nome:      "Maria da Silva",
+		Documento: "52998224725",
+		Endereco: dto.AddressRequest{
+			Logradouro: "Rua XV de Novembro",
+			Numero:     "1000",
+			Bairro:     "Centro",
+			Cidade:     "Curitiba",
+			CEP:        "80010000",
+		},
+	}
+}
+
+func TestPackageUseCase_ConcurrentFlows(t *testing.T) {
+	uc := NewPackage(
+		persistence.NewInMemoryPackageRepository(),
+		persistence.NewInMemoryQuoteRepository(),
+		newWarehouseRepository(),
+		service.NewPackageService(newCarrierRepository(), integration.TableQuoter{}, 0, 0, nil),
+		service.NewReliabilityScores(0),
+	)
+
+	const packages = 30
+	ids := make(chan string, packages)
+
+	var wg sync.WaitGroup
+	for i := 0; i < packages; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			pkg, err := uc.Create(context.Background(), dto.PackageRequest{Product: "Camisa", WeightKg: 0.6, EstadoDestino: "PR", Destinatario: newRecipient()})
+			if !assert.NoError(t, err) {
+				return
+			}
+			id := pkg.ID
+			ids <- id
+
+			// Readers hit the same package while it is being updated
+			var readers sync.WaitGroup
+			for j := 0; j < 5; j++ {
+				readers.Add(1)
+				go func() {
+					defer readers.Done()
+					_, _, err := uc.QuoteShipping(context.Background(), id, "")
+					assert.NoError(t, err)
+					_, err = uc.GetHistory(id)
+					assert.NoError(t, err)
+				}()
+			}
+
+			_, err = uc.HireCarrier(context.Background(), id, "nebulix", "checkout", nil, 0)
+			assert.NoError(t, err)
+			_, err = uc.UpdateStatus(id, string(domain.StatusCollected), "scanner", "", 0)
+			assert.NoError(t, err)
+			_, err = uc.UpdateStatus(id, string(domain.StatusShipped), "scanner", "", 0)
+			assert.NoError(t, err)
+
+			readers.Wait()
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	for id := range ids {
+		pkg, err := uc.Get(id)
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusShipped, pkg.Status)
+		assert.Equal(t, "nebulix", pkg.Shipping.CarrierID)
+		assert.Len(t, pkg.History, 4)
+		assert.Equal(t, 4, pkg.Version)
+	}
+}
+
+func TestPackageUseCase_IfMatch(t *testing.T) {
+	uc := NewPackage(
+		persistence.NewInMemoryPackageRepository(),
+		persistence.NewInMemoryQuoteRepository(),
+		newWarehouseRepository(),
+		service.NewPackageService(newCarrierRepository(), integration.TableQuoter{}, 0, 0, nil),
+		service.NewReliabilityScores(0),
+	)
+
+	id := createPackage(t, uc, dto.PackageRequest{Product: "Camisa", WeightKg: 0.6, EstadoDestino: "PR", Destinatario: newRecipient()})
+
+	t.Run("should hire carrier when version matches", func(t *testing.T) {
+		pkg, err := uc.HireCarrier(context.Background(), id, "nebulix", "dashboard", nil, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, pkg.Version)
+	})
+
+	t.Run("should reject a stale version with precondition failed", func(t *testing.T) {
+		_, err := uc.UpdateStatus(id, string(domain.StatusCollected), "scanner", "", 1)
+
+		var appErr *apperr.AppErr
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusPreconditionFailed, appErr.Code)
+
+		pkg, err := uc.Get(id)
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusWaitingPickup, pkg.Status)
+	})
+
+	t.Run("should skip the check without If-Match", func(t *testing.T) {
+		pkg, err := uc.UpdateStatus(id, string(domain.StatusCollected), "scanner", "", 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, pkg.Version)
+	})
+}
+
+func TestPackageUseCase_List(t *testing.T) {
+	uc := NewPackage(
+		persistence.NewInMemoryPackageRepository(),
+		persistence.NewInMemoryQuoteRepository(),
+		newWarehouseRepository(),
+		service.NewPackageService(newCarrierRepository(), integration.TableQuoter{}, 0, 0, nil),
+		service.NewReliabilityScores(0),
+	)
+
+	for _, state := range []string{"SP", "PR", "BA"} {
+		createPackage(t, uc, dto.PackageRequest{Product: "Camisa", WeightKg: 0.6, EstadoDestino: state})
+	}
+
+	t.Run("should list newest first by default", func(t *testing.T) {
+		page, err := uc.List(dto.ListPackagesRequest{})
+
+		require.NoError(t, err)
+		require.Len(t, page.Packages, 3)
+		assert.Equal(t, "BA", page.Packages[0].DestinationState)
+		assert.Equal(t, "SP", page.Packages[2].DestinationState)
+	})
+
+	t.Run("should translate query parameters into filters", func(t *testing.T) {
+		today := time.Now().Format(time.DateOnly)
+		page, err := uc.List(dto.ListPackagesRequest{
+			Status:        "criado, enviado",
+			EstadoDestino: "pr",
+			CriadoDe:      today,
+			CriadoAte:     today,
+			Ordenar:       "peso_kg",
+		})
+
+		require.NoError(t, err)
+		require.Len(t, page.Packages, 1)
+		assert.Equal(t, "PR", page.Packages[0].DestinationState)
+	})
+
+	t.Run("should reject invalid parameters", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			req      dto.ListPackagesRequest
+			expected string
+		}{
+			{"unknown state", dto.ListPackagesRequest{EstadoDestino: "XX"}, "Invalid state: XX"},
+			{"bad date", dto.ListPackagesRequest{CriadoDe: "15/01/2025"}, "Invalid date: 15/01/2025"},
+			{"inverted weight range", dto.ListPackagesRequest{PesoMin: 10, PesoMax: 1}, "peso_min cannot be greater than peso_max"},
+			{"unknown sort", dto.ListPackagesRequest{Ordenar: "-produto"}, "Invalid sort field: produto"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := uc.List(tt.req)
+				assert.ErrorContains(t, err, tt.expected)
+			})
+		}
+	})
+}
+
+func TestPackageUseCase_HireQuote(t *testing.T) {
+	carriers := newCarrierRepository()
+	uc := NewPackage(
+		persistence.NewInMemoryPackageRepository(),
+		persistence.NewInMemoryQuoteRepository(),
+		newWarehouseRepository(),
+		service.NewPackageService(carriers, integration.TableQuoter{}, 0, 0, nil),
+		service.NewReliabilityScores(0),
+	)
+
+	quotePackage := func(t *testing.T) (string, *domain.Quote) {
+		id := createPackage(t, uc, dto.PackageRequest{Product: "Camisa", WeightKg: 2, EstadoDestino: "PR", Destinatario: newRecipient()})
+
+		quotes, _, err := uc.QuoteShipping(context.Background(), id, "")
+		require.NoError(t, err)
+		require.Len(t, quotes, 1)
+		return id, quotes[0]
+	}
+
+	t.Run("should hire at the quoted price even after a price change", func(t *testing.T) {
+		id, quote := quotePackage(t)
+		assert.Equal(t, vo.NewMoney(1180), quote.Shipping.EstimatedPrice)
+		assert.WithinDuration(t, time.Now().Add(domain.DefaultQuoteValidity), quote.ExpiresAt, time.Minute)
+
+		_, err := carriers.Update("nebulix", func(c *integration.Carrier) error {
+			c.Regions[0].PricePerKg = vo.NewMoney(2000)
+			return nil
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			carriers.Update("nebulix", func(c *integration.Carrier) error {
+				c.Regions[0].PricePerKg = vo.NewMoney(590)
+				return nil
+			})
+		})
+
+		pkg, err := uc.HireQuote(id, quote.ID, "checkout", nil, 0)
+
+		require.NoError(t, err)
+		expected := quote.Shipping
+		expected.EstimatedDeliveryDate = domain.AddBusinessDays(nil, pkg.UpdatedAt, expected.EstimatedDays, "PR")
+		assert.Equal(t, expected, *pkg.Shipping)
+		assert.Equal(t, domain.StatusWaitingPickup, pkg.Status)
+	})
+
+	t.Run("should reject invalid quotes", func(t *testing.T) {
+		id, quote := quotePackage(t)
+		otherID, _ := quotePackage(t)
+		hiredID, hiredQuote := quotePackage(t)
+		_, err := uc.HireQuote(hiredID, hiredQuote.ID, "checkout", nil, 0)
+		require.NoError(t, err)
+
+		tests := []struct {
+			name      string
+			packageID string
+			quoteID   string
+			code      int
+		}{
+			{"unknown quote", id, "nonexistent-id", http.StatusNotFound},
+			{"quote of another package", otherID, quote.ID, http.StatusBadRequest},
+			{"package already hired", hiredID, hiredQuote.ID, http.StatusConflict},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := uc.HireQuote(tt.packageID, tt.quoteID, "checkout", nil, 0)
+
+				var appErr *apperr.AppErr
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.code, appErr.Code)
+			})
+		}
+	})
+
+	t.Run("should reject expired quotes", func(t *testing.T) {
+		expiring := NewPackage(
+			persistence.NewInMemoryPackageRepository(),
+			persistence.NewInMemoryQuoteRepository(),
+			newWarehouseRepository(),
+			service.NewPackageService(carriers, integration.TableQuoter{}, 0, time.Millisecond, nil),
+			service.NewReliabilityScores(0),
+		)
+		id := createPackage(t, expiring, dto.PackageRequest{Product: "Camisa", WeightKg: 2, EstadoDestino: "PR", Destinatario: newRecipient()})
+		quotes, _, err := expiring.QuoteShipping(context.Background(), id, "")
+		require.NoError(t, err)
+		require.Len(t, quotes, 1)
+
+		time.Sleep(5 * time.Millisecond)
+		_, err = expiring.HireQuote(id, quotes[0].ID, "checkout", nil, 0)
+
+		var appErr *apperr.AppErr
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusGone, appErr.Code)
+
+		pkg, err := expiring.Get(id)
+		require.NoError(t, err)
+		assert.Nil(t, pkg.Shipping)
+	})
+}
+
+func TestPackageUseCase_HireRecipient(t *testing.T) {
+	uc := NewPackage(
+		persistence.NewInMemoryPackageRepository(),
+		persistence.NewInMemoryQuoteRepository(),
+		newWarehouseRepository(),
+		service.NewPackageService(newCarrierRepository(), integration.TableQuoter{}, 0, 0, nil),
+		service.NewReliabilityScores(0),
+	)
+
+	t.Run("should take the recipient at hire time", func(t *testing.T) {
+		id := createPackage(t, uc, dto.PackageRequest{Product: "Camisa", WeightKg: 2, EstadoDestino: "PR"})
+
+		pkg, err := uc.HireCarrier(context.Background(), id, "nebulix", "checkout", newRecipient(), 0)
+
+		require.NoError(t, err)
+		require.NotNil(t, pkg.Recipient)
+		assert.Equal(t, vo.CEP("80010000"), pkg.Recipient.Address.CEP)
+		assert.Equal(t, domain.StatusWaitingPickup, pkg.Status)
+	})
+
+	t.Run("should reject hiring without a recipient", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			create    dto.PackageRequest
+			recipient *dto.ContactRequest
+			code      int
+		}{
+			{"package without recipient", dto.PackageRequest{Product: "Camisa", WeightKg: 2, EstadoDestino: "PR"}, nil, http.StatusBadRequest},
+			{"recipient in another state", dto.PackageRequest{Product: "Camisa", WeightKg: 2, EstadoDestino: "SC"}, newRecipient(), http.StatusBadRequest},
+			{"package that already has a recipient", dto.PackageRequest{Product: "Camisa", WeightKg: 2, EstadoDestino: "PR", Destinatario: newRecipient()}, newRecipient(), http.StatusConflict},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				id := createPackage(t, uc, tt.create)
+
+				_, err := uc.HireCarrier(context.Background(), id, "nebulix", "checkout", tt.recipient, 0)
+
+				var appErr *apperr.AppErr
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.code, appErr.Code)
+
+				pkg, err := uc.Get(id)
+				require.NoError(t, err)
+				assert.Nil(t, pkg.Shipping)
+			})
+		}
+	})
+
+	t.Run("should require the recipient of a hire policy", func(t *testing.T) {
+		_, err := uc.Create(context.Background(), dto.PackageRequest{
+			Product: "Camisa", WeightKg: 2, EstadoDestino: "PR",
+			PoliticaContratacao: &dto.HirePolicyRequest{Tipo: "cheapest"},
+		})
+
+		assert.ErrorContains(t, err, "Recipient is required to hire a carrier")
+	})
+}
+
+func TestPackageUseCase_QuoteShippingSort(t *testing.T) {
+	carriers := integration.NewCarrierRepository([]*integration.Carrier{
+		integration.NewCarrier("rapida", "Rápida", []integration.CarrierRegion{{Region: "sul", EstimatedDays: 2, PricePerKg: vo.NewMoney(990)}}),
+		integration.NewCarrier("economica", "Econômica", []integration.CarrierRegion{{Region: "sul", EstimatedDays: 8, PricePerKg: vo.NewMoney(390)}}),
+	})
+	repo := persistence.NewInMemoryPackageRepository()
+	reliability := service.NewReliabilityScores(0)
+	uc := NewPackage(
+		repo,
+		persistence.NewInMemoryQuoteRepository(),
+		newWarehouseRepository(),
+		service.NewPackageService(carriers, integration.TableQuoter{}, 0, 0, nil),
+		reliability,
+	)
+	id := createPackage(t, uc, dto.PackageRequest{Product: "Camisa", WeightKg: 2, EstadoDestino: "PR"})
+
+	tests := []struct {
+		sort     string
+		expected string
+	}{
+		{sort: "", expected: "rapida"},
+		{sort: "fastest", expected: "rapida"},
+		{sort: "cheapest", expected: "economica"},
+		{sort: "reliable", expected: "rapida"},
+	}
+
+	for _, tt := range tests {
+		t.Run("sort "+tt.sort, func(t *testing.T) {
+			quotes, _, err := uc.QuoteShipping(context.Background(), id, tt.sort)
+
+			require.NoError(t, err)
+			require.Len(t, quotes, 2)
+			assert.Equal(t, tt.expected, quotes[0].Shipping.CarrierID)
+		})
+	}
+
+	t.Run("should rank by the delivery history", func(t *testing.T) {
+		lost, err := domain.NewPackage("Camisa", "PR", 2, domain.DestinationRegionSouth)
+		require.NoError(t, err)
+		lost.AssignShipping(vo.NewShippingQuote("Rápida", "rapida", vo.NewMoney(1980), 2), "operator")
+		for _, status := range []domain.PackageStatus{domain.StatusCollected, domain.StatusShipped, domain.StatusLost} {
+			require.NoError(t, lost.UpdateStatus(status, "operator", ""))
+		}
+		require.NoError(t, repo.Save(lost))
+		require.NoError(t, NewReport(repo, carriers, reliability).RefreshReliability(time.Now()))
+
+		quotes, _, err := uc.QuoteShipping(context.Background(), id, "reliable")
+
+		require.NoError(t, err)
+		require.Len(t, quotes, 2)
+		assert.Equal(t, "economica", quotes[0].Shipping.CarrierID)
+	})
+
+	t.Run("should reject an unknown sort", func(t *testing.T) {
+		_, _, err := uc.QuoteShipping(context.Background(), id, "price")
+
+		var appErr *apperr.AppErr
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+	})
+}
+
+func TestPackageUseCase_CreateWithHirePolicy(t *testing.T) {
+	carriers := integration.NewCarrierRepository([]*integration.Carrier{
+		integration.NewCarrier("rapida", "Rápida", []integration.CarrierRegion{{Region: "sul", EstimatedDays: 2, PricePerKg: vo.NewMoney(990)}}),
+		integration.NewCarrier("economica", "Econômica", []integration.CarrierRegion{{Region: "sul", EstimatedDays: 8, PricePerKg: vo.NewMoney(390)}}),
+	})
+	uc := NewPackage(
+		persistence.NewInMemoryPackageRepository(),
+		persistence.NewInMemoryQuoteRepository(),
+		newWarehouseRepository(),
+		service.NewPackageService(carriers, integration.TableQuoter{}, 0, 0, nil),
+		service.NewReliabilityScores(0),
+	)
+
+	t.Run("should create the package already hired", func(t *testing.T) {
+		pkg, err := uc.Create(context.Background(), dto.PackageRequest{
+			Product: "Camisa", WeightKg: 2, EstadoDestino: "PR", Destinatario: newRecipient(),
+			PoliticaContratacao: &dto.HirePolicyRequest{Tipo: "max_days", PrazoMaximoDias: 8},
+		})
+		require.NoError(t, err)
+
+		saved, err := uc.Get(pkg.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusWaitingPickup, saved.Status)
+		assert.Equal(t, "economica", saved.Shipping.CarrierID)
+		assert.Equal(t, vo.NewMoney(780), saved.Shipping.EstimatedPrice)
+		assert.Equal(t, domain.SystemActor, saved.History[len(saved.History)-1].Actor)
+	})
+
+	t.Run("should not create the package when the policy cannot be met", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			policy dto.HirePolicyRequest
+			code   int
+		}{
+			{"no carrier within max days", dto.HirePolicyRequest{Tipo: "max_days", PrazoMaximoDias: 1}, http.StatusUnprocessableEntity},
+			{"max price without price", dto.HirePolicyRequest{Tipo: "max_price"}, http.StatusBadRequest},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				before, err := uc.List(dto.ListPackagesRequest{})
+				require.NoError(t, err)
+
+				_, err = uc.Create(context.Background(), dto.PackageRequest{
+					Product: "Camisa", WeightKg: 2, EstadoDestino: "PR", Destinatario: newRecipient(),
+					PoliticaContratacao: &tt.policy,
+				})
+
+				var appErr *apperr.AppErr
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.code, appErr.Code)
+
+				after, err := uc.List(dto.ListPackagesRequest{})
+				require.NoError(t, err)
+				assert.Len(t, after.Packages, len(before.Packages))
+			})
+		}
+	})
+}
+
+func TestPackageUseCase_CreateWithCEP(t *testing.T) {
+	uc := NewPackage(
+		persistence.NewInMemoryPackageRepository(),
+		persistence.NewInMemoryQuoteRepository(),
+		newWarehouseRepository(),
+		service.NewPackageService(newCarrierRepository(), integration.TableQuoter{}, 0, 0, nil),
+		service.NewReliabilityScores(0),
+	)
+
+	t.Run("should find the state by the CEP", func(t *testing.T) {
+		pkg, err := uc.Create(context.Background(), dto.PackageRequest{Product: "Camisa", WeightKg: 2, CEPDestino: "80010000"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "PR", pkg.DestinationState)
+		assert.Equal(t, domain.DestinationRegionSouth, pkg.DestinationRegion)
+		assert.Equal(t, vo.CEP("80010000"), pkg.DestinationCEP)
+	})
+
+	t.Run("should reject invalid destinations", func(t *testing.T) {
+		tests := []struct {
+			name          string
+			req           dto.PackageRequest
+			expectedError string
+		}{
+			{
+				name:          "CEP in another state",
+				req:           dto.PackageRequest{Product: "Camisa", WeightKg: 2, EstadoDestino: "SC", CEPDestino: "80010000"},
+				expectedError: "CEP 80010-000 belongs to PR, not SC",
+			},
+			{
+				name:          "CEP outside every state",
+				req:           dto.PackageRequest{Product: "Camisa", WeightKg: 2, CEPDestino: "00000100"},
+				expectedError: "CEP not found in any state: 00000-100",
+			},
+			{
+				name:          "no destination",
+				req:           dto.PackageRequest{Product: "Camisa", WeightKg: 2},
+				expectedError: "Destination state, destination CEP or recipient is required",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := uc.Create(context.Background(), tt.req)
+
+				var appErr *apperr.AppErr
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, http.StatusBadRequest, appErr.Code)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			})
+		}
+	})
+}
+
+func TestPackageUseCase_CreateWithOrigin(t *testing.T) {
+	uc := NewPackage(
+		persistence.NewInMemoryPackageRepository(),
+		persistence.NewInMemoryQuoteRepository(),
+		newWarehouseRepository(),
+		service.NewPackageService(newCarrierRepository(), integration.TableQuoter{}, 0, 0, nil),
+		service.NewReliabilityScores(0),
+	)
+
+	t.Run("should ship from the warehouse", func(t *testing.T) {
+		pkg, err := uc.Create(context.Background(), dto.PackageRequest{Product: "Camisa", WeightKg: 2, EstadoDestino: "PR", Origem: "recife"})
+		require.NoError(t, err)
+
+		saved, err := uc.Get(pkg.ID)
+		require.NoError(t, err)
+		assert.Equal(t, &domain.Warehouse{ID: "recife", Name: "CD Recife", State: "PE", CEP: "50050000"}, saved.Origin)
+	})
+
+	t.Run("should reject an unknown warehouse", func(t *testing.T) {
+		_, err := uc.Create(context.Background(), dto.PackageRequest{Product: "Camisa", WeightKg: 2, EstadoDestino: "PR", Origem: "manaus"})
+
+		var appErr *apperr.AppErr
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.Code)
+		assert.Contains(t, err.Error(), "Warehouse not found: manaus")
+	})
+}
+
+func TestPackageUseCase_CreateWithItems(t *testing.T) {
+	uc := NewPackage(
+		persistence.NewInMemoryPackageRepository(),
+		persistence.NewInMemoryQuoteRepository(),
+		newWarehouseRepository(),
+		service.NewPackageService(newCarrierRepository(), integration.TableQuoter{}, 0, 0, nil),
+		service.NewReliabilityScores(0),
+	)
+
+	t.Run("should describe and weigh the package by its items", func(t *testing.T) {
+		pkg, err := uc.Create(context.Background(), dto.PackageRequest{
+			EstadoDestino: "PR",
+			Itens: []dto.ItemRequest{
+				{SKU: "CAM-G", Descricao: "Camisa azul", Quantidade: 2, PesoUnitarioKg: 0.3, ValorUnitario: vo.NewMoney(8990), NCM: "62052000"},
+				{Descricao: "Calça jeans", Quantidade: 1, PesoUnitarioKg: 0.7, ValorUnitario: vo.NewMoney(15950)},
+			},
+		})
+		require.NoError(t, err)
+
+		saved, err := uc.Get(pkg.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Camisa azul e mais 1 item", saved.Product)
+		assert.Equal(t, 1.3, saved.WeightKg)
+		require.Len(t, saved.Items, 2)
+		assert.Equal(t, vo.NCM("62052000"), saved.Items[0].NCM)
+		assert.Equal(t, vo.NewMoney(33930), saved.DeclaredValue)
+	})
+
+	t.Run("should keep the declared value informed without items", func(t *testing.T) {
+		pkg, err := uc.Create(context.Background(), dto.PackageRequest{Product: "Notebook", WeightKg: 2.1, EstadoDestino: "PR", ValorDeclarado: vo.NewMoney(459900)})
+		require.NoError(t, err)
+
+		saved, err := uc.Get(pkg.ID)
+		require.NoError(t, err)
+		assert.Equal(t, vo.NewMoney(459900), saved.DeclaredValue)
+	})
+
+	t.Run("should reject invalid items", func(t *testing.T) {
+		tests := []struct {
+			name          string
+			req           dto.PackageRequest
+			expectedError string
+		}{
+			{
+				name: "item without quantity",
+				req: dto.PackageRequest{EstadoDestino: "PR", Itens: []dto.ItemRequest{
+					{Descricao: "Camisa azul", PesoUnitarioKg: 0.3, ValorUnitario: vo.NewMoney(8990)},
+				}},
+				expectedError: "Invalid item 1: item quantity must be greater than zero",
+			},
+			{
+				name: "weight below the items",
+				req: dto.PackageRequest{EstadoDestino: "PR", WeightKg: 0.5, Itens: []dto.ItemRequest{
+					{Descricao: "Camisa azul", Quantidade: 2, PesoUnitarioKg: 0.3, ValorUnitario: vo.NewMoney(8990)},
+				}},
+				expectedError: "Package weight 0.5 kg is less than the items weight 0.6 kg",
+			},
+			{
+				name: "declared value below the items",
+				req: dto.PackageRequest{EstadoDestino: "PR", ValorDeclarado: vo.NewMoney(10000), Itens: []dto.ItemRequest{
+					{Descricao: "Camisa azul", Quantidade: 2, PesoUnitarioKg: 0.3, ValorUnitario: vo.NewMoney(8990)},
+				}},
+				expectedError: "Declared value R$ 100,00 is less than the items value R$ 179,80",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := uc.Create(context.Background(), tt.req)
+
+				var appErr *apperr.AppErr
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, http.StatusBadRequest, appErr.Code)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			})
+		}
+	})
+}
+
+func TestPackageUseCase_CreateWithRecipient(t *testing.T) {
+	uc := NewPackage(
+		persistence.NewInMemoryPackageRepository(),
+		persistence.NewInMemoryQuoteRepository(),
+		newWarehouseRepository(),
+		service.NewPackageService(newCarrierRepository(), integration.TableQuoter{}, 0, 0, nil),
+		service.NewReliabilityScores(0),
+	)
+
+	contact := func(name string, document vo.Document, cep vo.CEP) *dto.ContactRequest {
+		return &dto.ContactRequest{
+			Nome:      name,
+			Documento: document,
+			Telefone:  "41998765432",
+			Endereco: dto.AddressRequest{
+				Logradouro: " Rua XV de Novembro ",
+				Numero:     "1000",
+				Bairro:     "Centro",
+				Cidade:     "Curitiba",
+				CEP:        cep,
+			},
+		}
+	}
+
+	t.Run("should take the destination from the recipient address", func(t *testing.T) {
+		pkg, err := uc.Create(context.Background(), dto.PackageRequest{
+			Product:      "Camisa",
+			WeightKg:     2,
+			Destinatario: contact("Maria da Silva", "52998224725", "80010000"),
+			Remetente:    contact("Loja Exemplo Ltda", "11222333000181", "01310200"),
+		})
+		require.NoError(t, err)
+
+		saved, err := uc.Get(pkg.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "PR", saved.DestinationState)
+		assert.Equal(t, vo.CEP("80010000"), saved.DestinationCEP)
+		require.NotNil(t, saved.Recipient)
+		assert.Equal(t, "Maria da Silva", saved.Recipient.Name)
+		assert.Equal(t, "Rua XV de Novembro", saved.Recipient.Address.Street)
+		require.NotNil(t, saved.Sender)
+		assert.Equal(t, vo.Document("11222333000181"), saved.Sender.Document)
+	})
+
+	t.Run("should reject invalid contacts", func(t *testing.T) {
+		tests := []struct {
+			name          string
+			req           dto.PackageRequest
+			expectedError string
+		}{
+			{
+				name:          "recipient outside the destination CEP",
+				req:           dto.PackageRequest{Product: "Camisa", WeightKg: 2, CEPDestino: "80010000", Destinatario: contact("Maria da Silva", "52998224725", "80020310")},
+				expectedError: "Recipient CEP 80020-310 differs from the destination CEP 80010-000",
+			},
+			{
+				name:          "recipient outside the destination state",
+				req:           dto.PackageRequest{Product: "Camisa", WeightKg: 2, EstadoDestino: "SC", Destinatario: contact("Maria da Silva", "52998224725", "80010000")},
+				expectedError: "CEP 80010-000 belongs to PR, not SC",
+			},
+			{
+				name:          "recipient without name",
+				req:           dto.PackageRequest{Product: "Camisa", WeightKg: 2, Destinatario: contact(" ", "52998224725", "80010000")},
+				expectedError: "Invalid recipient: contact name is required",
+			},
+			{
+				name:          "sender without address",
+				req:           dto.PackageRequest{Product: "Camisa", WeightKg: 2, Destinatario: contact("Maria da Silva", "52998224725", "80010000"), Remetente: &dto.ContactRequest{Nome: "Loja", Documento: "11222333000181"}},
+				expectedError: "Invalid sender: address street is required",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := uc.Create(context.Background(), tt.req)
+
+				var appErr *apperr.AppErr
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, http.StatusBadRequest, appErr.Code)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			})
+		}
+	})
+}
+
+func TestParseDateFilter(t *testing.T) {
+	t.Run("should keep RFC3339 timestamps", func(t *testing.T) {
+		parsed, err := parseDateFilter("2025-01-15T10:30:00Z", true)
+
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC), parsed)
+	})
+
+	t.Run("should include the whole day for end dates", func(t *testing.T) {
+		parsed, err := parseDateFilter("2025-01-15", true)
+
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2025, 1, 15, 23, 59, 59, 999999999, time.UTC), parsed)
+	})
+
+	t.Run("should start at midnight for start dates", func(t *testing.T) {
+		parsed, err := parseDateFilter("2025-01-15", false)
+
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC), parsed)
+	})
+}
@@ -0,0 +1,211 @@
+package usecase
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/api/http/dto"
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain"
+	"github.com/foliveiracamara/delivery-manager-api/internal/infrastructure/integration"
+	"github.com/foliveiracamara/delivery-manager-api/internal/service"
+)
+
+// inTransitStatuses são os status com transportadora contratada e entrega pendente
+var inTransitStatuses = []domain.PackageStatus{
+	domain.StatusWaitingPickup,
+	domain.StatusCollected,
+	domain.StatusShipped,
+}
+
+// finishedStatuses são os status finais que alimentam a nota de confiabilidade
+var finishedStatuses = []domain.PackageStatus{
+	domain.StatusDelivered,
+	domain.StatusLost,
+}
+
+type ReportUseCase struct {
+	repository  domain.PackageRepository
+	carriers    integration.CarrierRepository
+	reliability *service.ReliabilityScores
+}
+
+func NewReport(repository domain.PackageRepository, carriers integration.CarrierRepository, reliability *service.ReliabilityScores) *ReportUseCase {
+	return &ReportUseCase{
+		repository:  repository,
+		carriers:    carriers,
+		reliability: reliability,
+	}
+}
+
+// MarkOverdue percorre os pacotes em trânsito e marca os que passaram do prazo de entrega,
+// retornando quantos foram marcados. A marca é gravada sem alterar a versão do pacote,
+// para não invalidar o ETag já lido pelos clientes. As falhas não interrompem a
+// varredura e são retornadas juntas no final.
+func (s ReportUseCase) MarkOverdue(now time.Time) (int, error) {
+	marked := 0
+	var failures []error
+
+	err := scanPackages(s.repository, domain.PackageFilter{Statuses: inTransitStatuses}, func(pkg *domain.Package) {
+		if !pkg.MarkOverdue(now) {
+			return
+		}
+
+		if err := s.repository.SaveOverdue(pkg.ID, *pkg.OverdueAt); err != nil {
+			failures = append(failures, err)
+			return
+		}
+		marked++
+	})
+	if err != nil {
+		return marked, err
+	}
+
+	return marked, errors.Join(failures...)
+}
+
+// Late agrupa por transportadora e região os pacotes marcados como atrasados, inclusive
+// os já entregues ou extraviados, para a cobrança de multas
+func (s ReportUseCase) Late(req dto.LateReportRequest, now time.Time) ([]domain.LateDeliveryGroup, error) {
+	filter := domain.PackageFilter{
+		Region:      domain.DestinationRegion(req.RegiaoDestino),
+		CarrierID:   req.TransportadoraID,
+		OverdueOnly: true,
+	}
+
+	var err error
+	if filter.CreatedFrom, err = parseDateFilter(req.CriadoDe, false); err != nil {
+		return nil, err
+	}
+	if filter.CreatedTo, err = parseDateFilter(req.CriadoAte, true); err != nil {
+		return nil, err
+	}
+
+	report := domain.NewLateDeliveryReport(now)
+	if err := scanPackages(s.repository, filter, report.Add); err != nil {
+		return nil, err
+	}
+
+	return report.Groups(), nil
+}
+
+// Carriers calcula as métricas de desempenho de todas as transportadoras do catálogo e das
+// que deixaram o catálogo mas têm pacotes contratados no período
+func (s ReportUseCase) Carriers(req dto.CarrierReportRequest) ([]domain.CarrierMetrics, error) {
+	filter, err := carrierReportFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	report := domain.NewCarrierMetricsReport()
+	if err := scanPackages(s.repository, filter, report.Add); err != nil {
+		return nil, err
+	}
+
+	metrics := report.Metrics()
+	for _, carrier := range s.carriers.GetAll() {
+		i, found := slices.BinarySearchFunc(metrics, carrier.ID, func(m domain.CarrierMetrics, id string) int {
+			return cmp.Compare(m.CarrierID, id)
+		})
+		if found {
+			metrics[i].CarrierName = carrier.Name
+			continue
+		}
+		metrics = slices.Insert(metrics, i, domain.CarrierMetrics{
+			CarrierID:   carrier.ID,
+			CarrierName: carrier.Name,
+			Regions:     map[domain.DestinationRegion]int{},
+		})
+	}
+
+	return metrics, nil
+}
+
+// Carrier calcula as métricas de desempenho de uma transportadora. Transportadoras fora do
+// catálogo só são encontradas se tiverem pacotes contratados no período.
+func (s ReportUseCase) Carrier(id string, req dto.CarrierReportRequest) (*domain.CarrierMetrics, error) {
+	filter, err := carrierReportFilter(req)
+	if err != nil {
+		return nil, err
+	}
+	filter.CarrierID = id
+
+	report := domain.NewCarrierMetricsReport()
+	if err := scanPackages(s.repository, filter, report.Add); err != nil {
+		return nil, err
+	}
+
+	carrier, err := s.carriers.GetByID(id)
+	metrics := report.Metrics()
+	if len(metrics) == 0 {
+		if err != nil {
+			return nil, err
+		}
+		metrics = append(metrics, domain.CarrierMetrics{
+			CarrierID: id,
+			Regions:   map[domain.DestinationRegion]int{},
+		})
+	}
+	if carrier != nil {
+		metrics[0].CarrierName = carrier.Name
+	}
+
+	return &metrics[0], nil
+}
+
+// RefreshReliability recalcula, pelos pacotes finalizados, as notas de confiabilidade
+// usadas na ordenação reliable das cotações
+func (s ReportUseCase) RefreshReliability(now time.Time) error {
+	report := domain.NewCarrierMetricsReport()
+	if err := scanPackages(s.repository, domain.PackageFilter{Statuses: finishedStatuses}, report.Add); err != nil {
+		return err
+	}
+
+	scores := map[string]float64{}
+	for _, metrics := range report.Metrics() {
+		scores[metrics.CarrierID] = metrics.ReliabilityScore()
+	}
+	s.reliability.Update(scores, now)
+	return nil
+}
+
+func carrierReportFilter(req dto.CarrierReportRequest) (domain.PackageFilter, error) {
+	filter := domain.PackageFilter{Region: domain.DestinationRegion(req.RegiaoDestino)}
+
+	var err error
+	if filter.CreatedFrom, err = parseDateFilter(req.CriadoDe, false); err != nil {
+		return filter, err
+	}
+	if filter.CreatedTo, err = parseDateFilter(req.CriadoAte, true); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+// scanPackages percorre todas as páginas da listagem com o filtro, das mais antigas às mais
+// recentes. Só uma página fica em memória por vez: visit deve guardar apenas o que precisa
+// de cada pacote.
+func scanPackages(repository domain.PackageRepository, filter domain.PackageFilter, visit func(pkg *domain.Package)) error {
+	query, err := domain.NewPackageQuery(filter, domain.SortByCreatedAt, false, domain.MaxPageSize, "")
+	if err != nil {
+		return err
+	}
+
+	for {
+		page, err := repository.List(query)
+		if err != nil {
+			return err
+		}
+		for _, pkg := range page.Packages {
+			visit(pkg)
+		}
+
+		if page.NextCursor == "" {
+			return nil
+		}
+		if query.After, err = domain.DecodePackageCursor(page.NextCursor); err != nil {
+			return err
+		}
+	}
+}
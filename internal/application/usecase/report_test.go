@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/api/http/dto"
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain"
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
+	"github.com/foliveiracamara/delivery-manager-api/internal/infrastructure/persistence"
+	"github.com/foliveiracamara/delivery-manager-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportUseCase_MarkOverdue(t *testing.T) {
+	repo := persistence.NewInMemoryPackageRepository()
+	uc := NewReport(repo, newCarrierRepository(), service.NewReliabilityScores(0))
+	now := time.Now()
+
+	save := func(state string, region domain.DestinationRegion, carrierID string, promised time.Time, statuses ...domain.PackageStatus) *domain.Package {
+		pkg, err := domain.NewPackage("Product", state, 1.0, region)
+		require.NoError(t, err)
+		if carrierID != "" {
+			shipping := vo.NewShippingQuote("Carrier "+carrierID, carrierID, vo.NewMoney(1000), 3)
+			shipping.EstimatedDeliveryDate = promised
+			pkg.AssignShipping(shipping, "operator")
+		}
+		for _, status := range statuses {
+			require.NoError(t, pkg.UpdateStatus(status, "operator", ""))
+		}
+		require.NoError(t, repo.Save(pkg))
+		return pkg
+	}
+
+	yesterday := now.AddDate(0, 0, -1)
+	tomorrow := now.AddDate(0, 0, 1)
+	lateSouth := save("PR", domain.DestinationRegionSouth, "nebulix", yesterday.AddDate(0, 0, -2), domain.StatusCollected, domain.StatusShipped)
+	lateWaiting := save("SP", domain.DestinationRegionSoutheast, "nebulix", yesterday)
+	lateNortheast := save("BA", domain.DestinationRegionNortheast, "moventra", yesterday, domain.StatusCollected)
+	onTime := save("SC", domain.DestinationRegionSouth, "nebulix", tomorrow)
+	delivered := save("RS", domain.DestinationRegionSouth, "nebulix", yesterday, domain.StatusCollected, domain.StatusShipped, domain.StatusDelivered)
+	notHired := save("MG", domain.DestinationRegionSoutheast, "", time.Time{})
+
+	t.Run("should mark only packages in transit past their deadline", func(t *testing.T) {
+		marked, err := uc.MarkOverdue(now)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, marked)
+		for _, pkg := range []*domain.Package{lateSouth, lateWaiting, lateNortheast} {
+			stored, err := repo.GetByID(pkg.ID)
+			require.NoError(t, err)
+			assert.NotNil(t, stored.OverdueAt, pkg.DestinationState)
+			// The ETag read before the scan stays valid for If-Match
+			assert.Equal(t, pkg.Version, stored.Version, pkg.DestinationState)
+		}
+		for _, pkg := range []*domain.Package{onTime, delivered, notHired} {
+			stored, err := repo.GetByID(pkg.ID)
+			require.NoError(t, err)
+			assert.Nil(t, stored.OverdueAt, pkg.DestinationState)
+		}
+	})
+
+	t.Run("should not mark the same package twice", func(t *testing.T) {
+		marked, err := uc.MarkOverdue(now.Add(time.Hour))
+
+		require.NoError(t, err)
+		assert.Zero(t, marked)
+	})
+
+	t.Run("should keep delivered packages in the report", func(t *testing.T) {
+		stored, err := repo.GetByID(lateSouth.ID)
+		require.NoError(t, err)
+		require.NoError(t, stored.UpdateStatus(domain.StatusDelivered, "operator", ""))
+		require.NoError(t, repo.Save(stored))
+
+		groups, err := uc.Late(dto.LateReportRequest{}, now)
+
+		require.NoError(t, err)
+		require.Len(t, groups, 3)
+		assert.Equal(t, "moventra", groups[0].CarrierID)
+		assert.Equal(t, domain.DestinationRegionSoutheast, groups[1].Region)
+		assert.Equal(t, domain.DestinationRegionSouth, groups[2].Region)
+		require.Len(t, groups[2].Deliveries, 1)
+		assert.Equal(t, lateSouth.ID, groups[2].Deliveries[0].PackageID)
+		assert.Equal(t, 3, groups[2].TotalLateDays)
+	})
+
+	t.Run("should filter the report", func(t *testing.T) {
+		today := now.Format(time.DateOnly)
+		tests := []struct {
+			name     string
+			req      dto.LateReportRequest
+			expected []string
+		}{
+			{"by carrier", dto.LateReportRequest{TransportadoraID: "moventra"}, []string{lateNortheast.ID}},
+			{"by region", dto.LateReportRequest{RegiaoDestino: "sudeste"}, []string{lateWaiting.ID}},
+			{"by creation date", dto.LateReportRequest{CriadoDe: today, CriadoAte: today}, []string{lateNortheast.ID, lateWaiting.ID, lateSouth.ID}},
+			{"created before", dto.LateReportRequest{CriadoAte: "2025-01-01"}, nil},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				groups, err := uc.Late(tt.req, now)
+				require.NoError(t, err)
+
+				var ids []string
+				for _, group := range groups {
+					for _, delivery := range group.Deliveries {
+						ids = append(ids, delivery.PackageID)
+					}
+				}
+				assert.Equal(t, tt.expected, ids)
+			})
+		}
+	})
+
+	t.Run("should reject invalid dates", func(t *testing.T) {
+		_, err := uc.Late(dto.LateReportRequest{CriadoDe: "15/01/2025"}, now)
+
+		assert.ErrorContains(t, err, "Invalid date: 15/01/2025")
+	})
+}
+
+func TestReportUseCase_Carriers(t *testing.T) {
+	repo := persistence.NewInMemoryPackageRepository()
+	uc := NewReport(repo, newCarrierRepository(), service.NewReliabilityScores(0))
+
+	save := func(carrierID string, region domain.DestinationRegion, statuses ...domain.PackageStatus) {
+		pkg, err := domain.NewPackage("Product", "PR", 1.0, region)
+		require.NoError(t, err)
+		shipping := vo.NewShippingQuote("Old Name", carrierID, vo.NewMoney(1000), 3)
+		shipping.EstimatedDeliveryDate = time.Now().AddDate(0, 0, 3)
+		pkg.AssignShipping(shipping, "operator")
+		for _, status := range statuses {
+			require.NoError(t, pkg.UpdateStatus(status, "operator", ""))
+		}
+		require.NoError(t, repo.Save(pkg))
+	}
+
+	save("nebulix", domain.DestinationRegionSouth, domain.StatusCollected, domain.StatusShipped, domain.StatusDelivered)
+	save("nebulix", domain.DestinationRegionSoutheast, domain.StatusCollected, domain.StatusShipped, domain.StatusLost)
+	save("nebulix", domain.DestinationRegionSouth)
+	save("retired", domain.DestinationRegionSouth, domain.StatusCollected, domain.StatusShipped, domain.StatusDelivered)
+
+	t.Run("should include catalog carriers without packages", func(t *testing.T) {
+		metrics, err := uc.Carriers(dto.CarrierReportRequest{})
+
+		require.NoError(t, err)
+		require.Len(t, metrics, 3)
+		assert.Equal(t, "moventra", metrics[0].CarrierID)
+		assert.Equal(t, "Moventra Express", metrics[0].CarrierName)
+		assert.Zero(t, metrics[0].Hired)
+
+		assert.Equal(t, "nebulix", metrics[1].CarrierID)
+		assert.Equal(t, "Nebulix Logística", metrics[1].CarrierName)
+		assert.Equal(t, 3, metrics[1].Hired)
+		assert.Equal(t, 1, metrics[1].OnTime)
+		assert.Equal(t, 1, metrics[1].Lost)
+
+		// fora do catálogo, mantém o nome registrado na contratação
+		assert.Equal(t, "retired", metrics[2].CarrierID)
+		assert.Equal(t, "Old Name", metrics[2].CarrierName)
+	})
+
+	t.Run("should filter by region", func(t *testing.T) {
+		metrics, err := uc.Carriers(dto.CarrierReportRequest{RegiaoDestino: "sudeste"})
+
+		require.NoError(t, err)
+		require.Len(t, metrics, 2)
+		assert.Equal(t, 1, metrics[1].Hired)
+		assert.Equal(t, 1, metrics[1].Lost)
+	})
+
+	t.Run("should return the metrics of one carrier", func(t *testing.T) {
+		metrics, err := uc.Carrier("nebulix", dto.CarrierReportRequest{})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Nebulix Logística", metrics.CarrierName)
+		assert.Equal(t, 3, metrics.Hired)
+		assert.Equal(t, 1, metrics.InTransit())
+	})
+
+	t.Run("should return empty metrics of a catalog carrier without packages", func(t *testing.T) {
+		metrics, err := uc.Carrier("moventra", dto.CarrierReportRequest{})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Moventra Express", metrics.CarrierName)
+		assert.Zero(t, metrics.Hired)
+	})
+
+	t.Run("should find carriers removed from the catalog by their packages", func(t *testing.T) {
+		metrics, err := uc.Carrier("retired", dto.CarrierReportRequest{})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, metrics.Delivered)
+	})
+
+	t.Run("should return not found for unknown carriers", func(t *testing.T) {
+		_, err := uc.Carrier("unknown", dto.CarrierReportRequest{})
+
+		assert.ErrorContains(t, err, "Carrier not found")
+	})
+}
@@ -0,0 +1,22 @@
+package usecase
+
+import "github.com/foliveiracamara/delivery-manager-api/internal/domain"
+
+type WarehouseUseCase struct {
+	repository domain.WarehouseRepository
+}
+
+func NewWarehouse(repository domain.WarehouseRepository) *WarehouseUseCase {
+	return &WarehouseUseCase{
+		repository: repository,
+	}
+}
+
+// List retorna os armazéns que podem ser informados como origem dos pacotes
+func (s WarehouseUseCase) List() []*domain.Warehouse {
+	return s.repository.GetAll()
+}
+
+func (s WarehouseUseCase) Get(id string) (*domain.Warehouse, error) {
+	return s.repository.GetByID(id)
+}
@@ -0,0 +1,190 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	// Embute a base de fusos horários para BrazilTimezone não depender do sistema
+	_ "time/tzdata"
+)
+
+// BrazilTimezone é o fuso horário em que os dias de entrega são contados. O dia da
+// contratação não pode depender do fuso do servidor: às 22h em São Paulo já é o dia
+// seguinte em UTC.
+var BrazilTimezone = mustLoadLocation("America/Sao_Paulo")
+
+// HolidayCalendar informa os dias sem expediente na entrega. O estado permite considerar
+// feriados estaduais além dos nacionais.
+type HolidayCalendar interface {
+	IsHoliday(day time.Time, state string) bool
+}
+
+// NationalHolidays são os feriados nacionais de data fixa, no formato MM-DD. A Sexta-feira
+// Santa, de data móvel, é calculada a cada ano pela Páscoa.
+var NationalHolidays = []string{
+	"01-01", // Confraternização Universal
+	"04-21", // Tiradentes
+	"05-01", // Dia do Trabalho
+	"09-07", // Independência
+	"10-12", // Nossa Senhora Aparecida
+	"11-02", // Finados
+	"11-15", // Proclamação da República
+	"11-20", // Dia Nacional de Zumbi e da Consciência Negra
+	"12-25", // Natal
+}
+
+// StateHolidays são os feriados estaduais de data fixa, no formato MM-DD
+var StateHolidays = map[string][]string{
+	"AL": {"09-16"},          // Emancipação Política de Alagoas
+	"AM": {"09-05"},          // Elevação do Amazonas à categoria de província
+	"AP": {"03-19"},          // São José
+	"BA": {"07-02"},          // Independência da Bahia
+	"CE": {"03-19", "03-25"}, // São José e Data Magna do Ceará
+	"DF": {"11-30"},          // Dia do Evangélico
+	"MA": {"07-28"},          // Adesão do Maranhão à Independência
+	"MS": {"10-11"},          // Criação do Estado
+	"PA": {"08-15"},          // Adesão do Pará à Independência
+	"PB": {"08-05"},          // Fundação do Estado
+	"PI": {"10-19"},          // Dia do Piauí
+	"PR": {"12-19"},          // Emancipação Política do Paraná
+	"RJ": {"04-23"},          // São Jorge
+	"RN": {"10-03"},          // Mártires de Cunhaú e Uruaçu
+	"RO": {"01-04"},          // Criação do Estado
+	"RR": {"10-05"},          // Criação do Estado
+	"RS": {"09-20"},          // Revolução Farroupilha
+	"SE": {"07-08"},          // Emancipação Política de Sergipe
+	"SP": {"07-09"},          // Revolução Constitucionalista
+	"TO": {"10-05"},          // Criação do Estado
+}
+
+// BrazilianCalendar considera os feriados nacionais, a Sexta-feira Santa e os feriados do
+// estado informado. Datas extras podem ser informadas como MM-DD, repetidas todo ano,
+// ou AAAA-MM-DD, apenas naquele dia (como um ponto facultativo de Carnaval).
+type BrazilianCalendar struct {
+	national map[string]bool
+	states   map[string]map[string]bool
+}
+
+// NewBrazilianCalendar cria o calendário com os feriados padrão e as datas extras
+func NewBrazilianCalendar(extraNational []string, extraStates map[string][]string) (*BrazilianCalendar, error) {
+	calendar := &BrazilianCalendar{
+		national: map[string]bool{},
+		states:   map[string]map[string]bool{},
+	}
+
+	if err := calendar.add("", NationalHolidays); err != nil {
+		return nil, err
+	}
+	if err := calendar.add("", extraNational); err != nil {
+		return nil, err
+	}
+	for state, days := range StateHolidays {
+		if err := calendar.add(state, days); err != nil {
+			return nil, err
+		}
+	}
+	for state, days := range extraStates {
+		if _, ok := StateToRegionMapping[strings.ToUpper(state)]; !ok {
+			return nil, fmt.Errorf("invalid holiday state: %s", state)
+		}
+		if err := calendar.add(state, days); err != nil {
+			return nil, err
+		}
+	}
+
+	return calendar, nil
+}
+
+// IsHoliday verifica se o dia é feriado nacional ou do estado
+func (c *BrazilianCalendar) IsHoliday(day time.Time, state string) bool {
+	friday := goodFriday(day.Year())
+	if day.Month() == friday.Month() && day.Day() == friday.Day() {
+		return true
+	}
+
+	keys := []string{day.Format("01-02"), day.Format(time.DateOnly)}
+	for _, key := range keys {
+		if c.national[key] || c.states[strings.ToUpper(state)][key] {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *BrazilianCalendar) add(state string, days []string) error {
+	target := c.national
+	if state != "" {
+		state = strings.ToUpper(state)
+		if c.states[state] == nil {
+			c.states[state] = map[string]bool{}
+		}
+		target = c.states[state]
+	}
+
+	for _, day := range days {
+		if !isHolidayDate(day) {
+			return fmt.Errorf("invalid holiday date %q: use MM-DD or YYYY-MM-DD", day)
+		}
+		target[day] = true
+	}
+	return nil
+}
+
+// isHolidayDate aceita MM-DD, validado em um ano bissexto para aceitar 29 de fevereiro,
+// ou AAAA-MM-DD
+func isHolidayDate(day string) bool {
+	if len(day) == len("01-02") {
+		day = "2000-" + day
+	}
+	_, err := time.Parse(time.DateOnly, day)
+	return err == nil
+}
+
+// AddBusinessDays conta os dias úteis a partir do dia seguinte a from, pulando fins de
+// semana e os feriados do calendário no estado, e retorna o dia resultante à meia-noite
+// de BrazilTimezone. Com zero dias, retorna o próprio dia de from.
+func AddBusinessDays(calendar HolidayCalendar, from time.Time, days int, state string) time.Time {
+	from = from.In(BrazilTimezone)
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, BrazilTimezone)
+	for added := 0; added < days; {
+		day = day.AddDate(0, 0, 1)
+		if IsBusinessDay(calendar, day, state) {
+			added++
+		}
+	}
+	return day
+}
+
+// IsBusinessDay verifica se o dia não é fim de semana nem feriado no estado
+func IsBusinessDay(calendar HolidayCalendar, day time.Time, state string) bool {
+	if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		return false
+	}
+	return calendar == nil || !calendar.IsHoliday(day, state)
+}
+
+// goodFriday retorna a Sexta-feira Santa, dois dias antes da Páscoa, calculada pelo
+// algoritmo de Meeus/Jones/Butcher para o calendário gregoriano
+func goodFriday(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -2)
+}
+
+func mustLoadLocation(name string) *time.Location {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return location
+}
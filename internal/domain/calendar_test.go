@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(value string) time.Time {
+	day, err := time.ParseInLocation(time.DateOnly, value, BrazilTimezone)
+	if err != nil {
+		panic(err)
+	}
+	return day
+}
+
+func TestAddBusinessDays(t *testing.T) {
+	calendar, err := NewBrazilianCalendar([]string{"2025-03-03", "2025-03-04"}, nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		from     time.Time
+		days     int
+		state    string
+		expected string
+	}{
+		{name: "skips the weekend", from: date("2025-01-10").Add(15 * time.Hour), days: 1, state: "PR", expected: "2025-01-13"},
+		{name: "zero days is the hire day", from: date("2025-01-11").Add(9 * time.Hour), days: 0, state: "PR", expected: "2025-01-11"},
+		{name: "hire on a weekend starts on monday", from: date("2025-01-11"), days: 2, state: "PR", expected: "2025-01-14"},
+		{name: "skips good friday and tiradentes", from: date("2025-04-17"), days: 1, state: "PR", expected: "2025-04-22"},
+		{name: "skips consciencia negra", from: date("2025-11-19"), days: 1, state: "BA", expected: "2025-11-21"},
+		{name: "skips holidays of the destination state", from: date("2025-07-08"), days: 1, state: "SP", expected: "2025-07-10"},
+		{name: "keeps holidays of other states", from: date("2025-07-08"), days: 1, state: "PR", expected: "2025-07-09"},
+		{name: "skips extra dates", from: date("2025-02-28"), days: 1, state: "PR", expected: "2025-03-05"},
+		{name: "spans several weeks", from: date("2025-12-19"), days: 10, state: "PR", expected: "2026-01-06"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, date(tt.expected), AddBusinessDays(calendar, tt.from, tt.days, tt.state))
+		})
+	}
+
+	t.Run("should count from the day of the hire in Brazil whatever the server timezone", func(t *testing.T) {
+		// 22h of thursday in São Paulo is already friday in UTC
+		from := date("2025-01-09").Add(22 * time.Hour).UTC()
+
+		assert.Equal(t, date("2025-01-10"), AddBusinessDays(calendar, from, 1, "PR"))
+	})
+
+	t.Run("should skip only weekends without a calendar", func(t *testing.T) {
+		assert.Equal(t, date("2025-04-21"), AddBusinessDays(nil, date("2025-04-17"), 2, "PR"))
+	})
+}
+
+func TestBrazilianCalendar_IsHoliday(t *testing.T) {
+	calendar, err := NewBrazilianCalendar(nil, map[string][]string{"pr": {"11-22"}})
+	require.NoError(t, err)
+
+	tests := []struct {
+		day      string
+		state    string
+		expected bool
+	}{
+		{day: "2024-03-29", state: "PR", expected: true}, // Sexta-feira Santa
+		{day: "2025-04-18", state: "PR", expected: true},
+		{day: "2026-04-03", state: "PR", expected: true},
+		{day: "2026-04-10", state: "PR", expected: false},
+		{day: "2025-12-25", state: "PR", expected: true},
+		{day: "2025-12-19", state: "PR", expected: true},
+		{day: "2025-12-19", state: "SP", expected: false},
+		{day: "2025-11-22", state: "PR", expected: true},
+		{day: "2025-11-22", state: "SC", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.day+" "+tt.state, func(t *testing.T) {
+			assert.Equal(t, tt.expected, calendar.IsHoliday(date(tt.day), tt.state))
+		})
+	}
+}
+
+func TestNewBrazilianCalendar(t *testing.T) {
+	t.Run("should accept february 29", func(t *testing.T) {
+		_, err := NewBrazilianCalendar([]string{"02-29"}, nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should reject invalid dates", func(t *testing.T) {
+		_, err := NewBrazilianCalendar([]string{"31/12"}, nil)
+		assert.ErrorContains(t, err, `invalid holiday date "31/12"`)
+
+		_, err = NewBrazilianCalendar(nil, map[string][]string{"SP": {"2025-02-30"}})
+		assert.ErrorContains(t, err, `invalid holiday date "2025-02-30"`)
+	})
+
+	t.Run("should reject unknown states", func(t *testing.T) {
+		_, err := NewBrazilianCalendar(nil, map[string][]string{"XX": {"01-02"}})
+		assert.ErrorContains(t, err, "invalid holiday state: XX")
+	})
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStateFromCEP(t *testing.T) {
+	tests := []struct {
+		cep      vo.CEP
+		expected string
+		found    bool
+	}{
+		{cep: "01310100", expected: "SP", found: true}, // Av. Paulista
+		{cep: "19999999", expected: "SP", found: true},
+		{cep: "69005010", expected: "AM", found: true}, // Manaus
+		{cep: "69301000", expected: "RR", found: true}, // Boa Vista
+		{cep: "69460000", expected: "AM", found: true}, // Coari, interior do Amazonas
+		{cep: "70040010", expected: "DF", found: true},
+		{cep: "72800000", expected: "GO", found: true},
+		{cep: "73000000", expected: "DF", found: true},
+		{cep: "80010000", expected: "PR", found: true},
+		{cep: "99999999", expected: "RS", found: true},
+		{cep: "00999999", found: false},
+		{cep: "", found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.cep), func(t *testing.T) {
+			state, found := GetStateFromCEP(tt.cep)
+
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.expected, state)
+		})
+	}
+
+	t.Run("every state has a range", func(t *testing.T) {
+		covered := map[string]bool{}
+		for _, r := range CEPStateRanges {
+			covered[r.State] = true
+			assert.True(t, r.From <= r.To, r.State)
+		}
+		for state := range StateToRegionMapping {
+			assert.True(t, covered[state], state)
+		}
+	})
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// SystemActor identifica alterações de status feitas pelo próprio sistema
+const SystemActor = "sistema"
+
+// StatusEvent representa uma mudança de status registrada no histórico do pacote
+type StatusEvent struct {
+	Status    PackageStatus `json:"status"`
+	Timestamp time.Time     `json:"data_hora"`
+	Actor     string        `json:"ator"`
+	Note      string        `json:"observacao,omitempty"`
+}
+
+// NewStatusEvent cria um novo evento de status, atribuindo ao sistema quando não há ator
+func NewStatusEvent(status PackageStatus, actor, note string, timestamp time.Time) StatusEvent {
+	if actor == "" {
+		actor = SystemActor
+	}
+
+	return StatusEvent{
+		Status:    status,
+		Timestamp: timestamp,
+		Actor:     actor,
+		Note:      note,
+	}
+}
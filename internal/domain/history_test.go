@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStatusEvent(t *testing.T) {
+	now := time.Now()
+
+	t.Run("should create event with actor and note", func(t *testing.T) {
+		event := NewStatusEvent(StatusCollected, "scanner", "Coletado no CD", now)
+
+		assert.Equal(t, StatusCollected, event.Status)
+		assert.Equal(t, "scanner", event.Actor)
+		assert.Equal(t, "Coletado no CD", event.Note)
+		assert.Equal(t, now, event.Timestamp)
+	})
+
+	t.Run("should default to system actor", func(t *testing.T) {
+		event := NewStatusEvent(StatusCreated, "", "", now)
+
+		assert.Equal(t, SystemActor, event.Actor)
+	})
+}
@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"time"
+)
+
+// CarrierMetrics resume o desempenho de uma transportadora nos pacotes contratados com ela
+type CarrierMetrics struct {
+	CarrierID   string
+	CarrierName string
+	// Hired conta os pacotes contratados, inclusive os ainda em trânsito
+	Hired     int
+	Delivered int
+	// OnTime conta as entregas feitas até a data prometida
+	OnTime int
+	Lost   int
+	// PromisedDays e ActualDays somam os dias corridos entre a contratação e a data
+	// prometida e entre a contratação e a entrega, nas TimedDeliveries entregas com
+	// contratação registrada no histórico
+	PromisedDays    int
+	ActualDays      int
+	TimedDeliveries int
+	// Regions conta os pacotes contratados por região de destino
+	Regions map[DestinationRegion]int
+}
+
+// Finished conta os pacotes que chegaram a um status final, entregues ou extraviados
+func (m CarrierMetrics) Finished() int {
+	return m.Delivered + m.Lost
+}
+
+// InTransit conta os pacotes contratados que ainda não chegaram a um status final
+func (m CarrierMetrics) InTransit() int {
+	return m.Hired - m.Finished()
+}
+
+// OnTimeRate é a fração das entregas feitas até a data prometida
+func (m CarrierMetrics) OnTimeRate() float64 {
+	return fraction(m.OnTime, m.Delivered)
+}
+
+// LostRate é a fração dos pacotes finalizados que foram extraviados
+func (m CarrierMetrics) LostRate() float64 {
+	return fraction(m.Lost, m.Finished())
+}
+
+// AveragePromisedDays é a média de dias corridos prometidos nas entregas medidas
+func (m CarrierMetrics) AveragePromisedDays() float64 {
+	return fraction(m.PromisedDays, m.TimedDeliveries)
+}
+
+// AverageActualDays é a média de dias corridos até a entrega nas entregas medidas
+func (m CarrierMetrics) AverageActualDays() float64 {
+	return fraction(m.ActualDays, m.TimedDeliveries)
+}
+
+// ReliabilityScore estima a chance de um pacote finalizado ter sido entregue no prazo,
+// entre 0 e 1. Extravios contam como falhas, e a suavização de Laplace, (no prazo + 1) /
+// (finalizados + 2), faz uma transportadora sem histórico valer 0,5 e evita que poucas
+// entregas levem a nota aos extremos.
+func (m CarrierMetrics) ReliabilityScore() float64 {
+	return float64(m.OnTime+1) / float64(m.Finished()+2)
+}
+
+// CarrierMetricsReport acumula as métricas das transportadoras à medida que os pacotes
+// são adicionados, para que a listagem seja percorrida página a página
+type CarrierMetricsReport struct {
+	index   map[string]int
+	metrics []CarrierMetrics
+}
+
+func NewCarrierMetricsReport() *CarrierMetricsReport {
+	return &CarrierMetricsReport{
+		index:   map[string]int{},
+		metrics: []CarrierMetrics{},
+	}
+}
+
+// Add contabiliza o pacote na transportadora contratada; pacotes sem frete são ignorados
+func (r *CarrierMetricsReport) Add(pkg *Package) {
+	if pkg.Shipping == nil {
+		return
+	}
+
+	i, ok := r.index[pkg.Shipping.CarrierID]
+	if !ok {
+		i = len(r.metrics)
+		r.index[pkg.Shipping.CarrierID] = i
+		r.metrics = append(r.metrics, CarrierMetrics{
+			CarrierID:   pkg.Shipping.CarrierID,
+			CarrierName: pkg.Shipping.CarrierName,
+			Regions:     map[DestinationRegion]int{},
+		})
+	}
+	r.metrics[i].add(pkg)
+}
+
+// Metrics retorna uma cópia das métricas de cada transportadora, ordenadas pelo ID da
+// transportadora; o relatório continua aceitando pacotes depois da consulta
+func (r *CarrierMetricsReport) Metrics() []CarrierMetrics {
+	metrics := slices.Clone(r.metrics)
+	for i := range metrics {
+		metrics[i].Regions = maps.Clone(metrics[i].Regions)
+	}
+	slices.SortFunc(metrics, func(a, b CarrierMetrics) int {
+		return cmp.Compare(a.CarrierID, b.CarrierID)
+	})
+	return metrics
+}
+
+// add contabiliza um pacote contratado com a transportadora
+func (m *CarrierMetrics) add(pkg *Package) {
+	m.Hired++
+	m.Regions[pkg.DestinationRegion]++
+
+	switch pkg.Status {
+	case StatusLost:
+		m.Lost++
+	case StatusDelivered:
+		m.Delivered++
+		deliveredAt, delivered := pkg.DeliveredAt()
+		deadline, promised := pkg.DeliveryDeadline()
+		if delivered && promised && deliveredAt.Before(deadline) {
+			m.OnTime++
+		}
+
+		hiredAt, hired := pkg.HiredAt()
+		if !delivered || !hired {
+			return
+		}
+		m.TimedDeliveries++
+		m.ActualDays += daysBetween(hiredAt, deliveredAt)
+		if promisedDate := pkg.Shipping.EstimatedDeliveryDate; !promisedDate.IsZero() {
+			m.PromisedDays += daysBetween(hiredAt, promisedDate)
+		} else {
+			m.PromisedDays += pkg.Shipping.EstimatedDays
+		}
+	}
+}
+
+// daysBetween conta os dias corridos entre as datas em BrazilTimezone, o fuso dos dias de entrega
+func daysBetween(from, to time.Time) int {
+	day := func(t time.Time) time.Time {
+		t = t.In(BrazilTimezone)
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return int(day(to).Sub(day(from)) / (24 * time.Hour))
+}
+
+func fraction(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total)
+}
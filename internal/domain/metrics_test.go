@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCarrierMetricsReport(t *testing.T) {
+	hiredAt := date("2025-01-13").Add(10 * time.Hour)
+	hired := func(carrierID string, region DestinationRegion, promised string, events ...StatusEvent) *Package {
+		shipping := vo.NewShippingQuote("Carrier "+carrierID, carrierID, vo.NewMoney(1000), 3)
+		if promised != "" {
+			shipping.EstimatedDeliveryDate = date(promised)
+		}
+		history := append([]StatusEvent{NewStatusEvent(StatusWaitingPickup, "", "", hiredAt)}, events...)
+		return &Package{
+			DestinationRegion: region,
+			Status:            history[len(history)-1].Status,
+			Shipping:          &shipping,
+			History:           history,
+		}
+	}
+	at := func(status PackageStatus, day string) StatusEvent {
+		return NewStatusEvent(status, "", "", date(day).Add(15*time.Hour))
+	}
+
+	report := NewCarrierMetricsReport()
+	for _, pkg := range []*Package{
+		// prometido para 16/01 (3 dias corridos), entregue em 15/01 (2 dias)
+		hired("nebulix", DestinationRegionSouth, "2025-01-16", at(StatusShipped, "2025-01-14"), at(StatusDelivered, "2025-01-15")),
+		// entregue no fim do dia prometido (3 dias)
+		hired("nebulix", DestinationRegionSouth, "2025-01-16", at(StatusDelivered, "2025-01-16")),
+		// entregue dois dias depois (5 dias)
+		hired("nebulix", DestinationRegionSoutheast, "2025-01-16", at(StatusDelivered, "2025-01-18")),
+		hired("nebulix", DestinationRegionSouth, "2025-01-16", at(StatusShipped, "2025-01-14"), at(StatusLost, "2025-01-20")),
+		hired("nebulix", DestinationRegionNortheast, "2025-01-16"),
+		// contratação sem data prometida usa o prazo em dias
+		hired("moventra", DestinationRegionNortheast, "", at(StatusDelivered, "2025-01-15")),
+		{DestinationRegion: DestinationRegionSouth, Status: StatusCreated},
+	} {
+		report.Add(pkg)
+	}
+
+	metrics := report.Metrics()
+	require.Len(t, metrics, 2)
+
+	moventra := metrics[0]
+	assert.Equal(t, "moventra", moventra.CarrierID)
+	assert.Equal(t, 1, moventra.OnTime)
+	assert.Equal(t, 3.0, moventra.AveragePromisedDays())
+	assert.Equal(t, 2.0, moventra.AverageActualDays())
+
+	nebulix := metrics[1]
+	assert.Equal(t, "nebulix", nebulix.CarrierID)
+	assert.Equal(t, "Carrier nebulix", nebulix.CarrierName)
+	assert.Equal(t, 5, nebulix.Hired)
+	assert.Equal(t, 3, nebulix.Delivered)
+	assert.Equal(t, 2, nebulix.OnTime)
+	assert.Equal(t, 1, nebulix.Lost)
+	assert.Equal(t, 1, nebulix.InTransit())
+	assert.InDelta(t, 2.0/3, nebulix.OnTimeRate(), 1e-9)
+	assert.Equal(t, 0.25, nebulix.LostRate())
+	assert.Equal(t, 3.0, nebulix.AveragePromisedDays())
+	assert.Equal(t, 10.0/3, nebulix.AverageActualDays())
+	assert.Equal(t, map[DestinationRegion]int{
+		DestinationRegionSouth:     3,
+		DestinationRegionSoutheast: 1,
+		DestinationRegionNortheast: 1,
+	}, nebulix.Regions)
+	// (2 no prazo + 1) / (4 finalizados + 2)
+	assert.Equal(t, 0.5, nebulix.ReliabilityScore())
+}
+
+func TestCarrierMetricsReport_AddAfterMetrics(t *testing.T) {
+	hired := func(carrierID string, region DestinationRegion) *Package {
+		shipping := vo.NewShippingQuote("Carrier "+carrierID, carrierID, vo.NewMoney(1000), 3)
+		return &Package{DestinationRegion: region, Status: StatusShipped, Shipping: &shipping}
+	}
+
+	report := NewCarrierMetricsReport()
+	report.Add(hired("nebulix", DestinationRegionSouth))
+	report.Add(hired("moventra", DestinationRegionNortheast))
+
+	first := report.Metrics()
+	require.Len(t, first, 2)
+	first[1].Regions[DestinationRegionNorth] = 10
+
+	report.Add(hired("nebulix", DestinationRegionSouth))
+	report.Add(hired("rotafacil", DestinationRegionSoutheast))
+
+	metrics := report.Metrics()
+	require.Len(t, metrics, 3)
+	assert.Equal(t, "moventra", metrics[0].CarrierID)
+	assert.Equal(t, 1, metrics[0].Hired)
+	assert.Equal(t, "nebulix", metrics[1].CarrierID)
+	assert.Equal(t, 2, metrics[1].Hired)
+	assert.Equal(t, map[DestinationRegion]int{DestinationRegionSouth: 2}, metrics[1].Regions)
+	assert.Equal(t, "rotafacil", metrics[2].CarrierID)
+	assert.Equal(t, 1, first[1].Hired, "metrics already returned must not change")
+}
+
+func TestCarrierMetrics_Rates(t *testing.T) {
+	t.Run("should be zero without packages", func(t *testing.T) {
+		metrics := CarrierMetrics{}
+
+		assert.Zero(t, metrics.OnTimeRate())
+		assert.Zero(t, metrics.LostRate())
+		assert.Zero(t, metrics.AverageActualDays())
+		assert.Equal(t, 0.5, metrics.ReliabilityScore())
+	})
+
+	t.Run("should keep few deliveries away from the extremes", func(t *testing.T) {
+		assert.InDelta(t, 2.0/3, CarrierMetrics{Delivered: 1, OnTime: 1}.ReliabilityScore(), 1e-9)
+		assert.InDelta(t, 1.0/3, CarrierMetrics{Lost: 1}.ReliabilityScore(), 1e-9)
+		assert.InDelta(t, 91.0/102, CarrierMetrics{Delivered: 100, OnTime: 90}.ReliabilityScore(), 1e-9)
+	})
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"fmt"
 	"slices"
 	"time"
 
@@ -9,14 +10,27 @@ import (
 	"github.com/google/uuid"
 )
 
+// MaxWeightKg é o maior peso aceito para um pacote, informado ou derivado dos itens
+const MaxWeightKg = 1000
+
 type Package struct {
 	ID                string            `json:"id"`
 	Product           string            `json:"produto"`
 	WeightKg          float64           `json:"peso_kg"`
+	Dimensions        vo.Dimensions     `json:"dimensoes"`
+	Items             []vo.Item         `json:"itens,omitempty"`
+	DeclaredValue     vo.Money          `json:"valor_declarado"`
 	DestinationRegion DestinationRegion `json:"regiao_destino"`
 	DestinationState  string            `json:"estado_destino"`
+	DestinationCEP    vo.CEP            `json:"cep_destino,omitempty"`
+	Origin            *Warehouse        `json:"origem,omitempty"`
+	Recipient         *vo.Contact       `json:"destinatario,omitempty"`
+	Sender            *vo.Contact       `json:"remetente,omitempty"`
 	Status            PackageStatus     `json:"status"`
 	Shipping          *vo.Shipping      `json:"shipping"`
+	History           []StatusEvent     `json:"historico"`
+	OverdueAt         *time.Time        `json:"atrasado_em,omitempty"`
+	Version           int               `json:"version"`
 	CreatedAt         time.Time         `json:"created_at"`
 	UpdatedAt         time.Time         `json:"updated_at"`
 }
@@ -35,6 +49,7 @@ func NewPackage(product, destinationState string, weightKg float64, destinationR
 		DestinationRegion: destinationRegion,
 		DestinationState:  destinationState,
 		Status:            StatusCreated,
+		History:           []StatusEvent{NewStatusEvent(StatusCreated, SystemActor, "", now)},
 		CreatedAt:         now,
 		UpdatedAt:         now,
 	}
@@ -42,43 +57,286 @@ func NewPackage(product, destinationState string, weightKg float64, destinationR
 	return &pkg, nil
 }
 
-// UpdateStatus atualiza o status do pacote
-func (p *Package) UpdateStatus(status PackageStatus) error {
+// SetDimensions informa as medidas da embalagem, usadas no cálculo do peso cúbico
+func (p *Package) SetDimensions(dimensions vo.Dimensions) error {
+	if !dimensions.IsValid() {
+		return apperr.NewBadRequestError("Package dimensions must all be greater than zero")
+	}
+
+	p.Dimensions = dimensions
+	return nil
+}
+
+// SetItems informa as mercadorias do pacote. Sem peso informado, o peso do pacote é a
+// soma dos itens, limitada a MaxWeightKg; com peso, ele não pode ser menor que a soma,
+// que não inclui a embalagem. O valor declarado segue a mesma regra com o valor dos
+// itens. Sem produto informado, o produto é a descrição do primeiro item.
+func (p *Package) SetItems(items []vo.Item) error {
+	if len(items) == 0 {
+		return apperr.NewBadRequestError("Package items must not be empty")
+	}
+	for i, item := range items {
+		if err := item.Validate(); err != nil {
+			return apperr.NewBadRequestError(fmt.Sprintf("Invalid item %d: %s", i+1, err))
+		}
+	}
+
+	itemsWeightKg := vo.ItemsWeightKg(items)
+	if p.WeightKg != 0 && p.WeightKg < itemsWeightKg {
+		return apperr.NewBadRequestError(fmt.Sprintf("Package weight %g kg is less than the items weight %g kg", p.WeightKg, itemsWeightKg))
+	}
+	if p.WeightKg == 0 && itemsWeightKg > MaxWeightKg {
+		return apperr.NewBadRequestError(fmt.Sprintf("Items weight %g kg exceeds the maximum package weight of %d kg", itemsWeightKg, MaxWeightKg))
+	}
+	itemsValue := vo.ItemsValue(items)
+	if !p.DeclaredValue.IsZero() && p.DeclaredValue.Compare(itemsValue) < 0 {
+		return newDeclaredValueBelowItemsError(p.DeclaredValue, itemsValue)
+	}
+
+	if p.WeightKg == 0 {
+		p.WeightKg = itemsWeightKg
+	}
+	if p.DeclaredValue.IsZero() {
+		p.DeclaredValue = itemsValue
+	}
+
+	if p.Product == "" {
+		p.Product = items[0].Description
+		switch others := len(items) - 1; {
+		case others == 1:
+			p.Product += " e mais 1 item"
+		case others > 1:
+			p.Product += fmt.Sprintf(" e mais %d itens", others)
+		}
+	}
+
+	p.Items = slices.Clone(items)
+	return nil
+}
+
+// SetDeclaredValue informa o valor declarado do conteúdo, sobre o qual as transportadoras
+// cobram o seguro ad valorem. Em pacotes com itens, ele não pode ser menor que o valor
+// dos itens.
+func (p *Package) SetDeclaredValue(value vo.Money) error {
+	if value.Cents() <= 0 {
+		return apperr.NewBadRequestError("Declared value must be greater than zero")
+	}
+	if itemsValue := vo.ItemsValue(p.Items); len(p.Items) > 0 && value.Compare(itemsValue) < 0 {
+		return newDeclaredValueBelowItemsError(value, itemsValue)
+	}
+
+	p.DeclaredValue = value
+	return nil
+}
+
+func newDeclaredValueBelowItemsError(value, itemsValue vo.Money) error {
+	return apperr.NewBadRequestError("Declared value " + value.String() + " is less than the items value " + itemsValue.String())
+}
+
+// SetDestinationCEP informa o CEP de destino, que precisa pertencer ao estado de destino
+// pela tabela de faixas dos Correios
+func (p *Package) SetDestinationCEP(cep vo.CEP) error {
+	if err := checkCEPState(cep, p.DestinationState); err != nil {
+		return err
+	}
+
+	p.DestinationCEP = cep
+	return nil
+}
+
+// SetOrigin informa o armazém de onde o pacote é despachado, guardando uma cópia dele
+func (p *Package) SetOrigin(warehouse Warehouse) {
+	p.Origin = &warehouse
+}
+
+// SetRecipient informa o destinatário. O endereço precisa estar no CEP de destino ou, em
+// pacotes sem CEP de destino, no estado de destino.
+func (p *Package) SetRecipient(contact vo.Contact) error {
+	if err := contact.Validate(); err != nil {
+		return apperr.NewBadRequestError("Invalid recipient: " + err.Error())
+	}
+
+	cep := contact.Address.CEP
+	if !p.DestinationCEP.IsZero() && cep != p.DestinationCEP {
+		return apperr.NewBadRequestError("Recipient CEP " + cep.String() + " differs from the destination CEP " + p.DestinationCEP.String())
+	}
+	if err := checkCEPState(cep, p.DestinationState); err != nil {
+		return err
+	}
+
+	p.Recipient = &contact
+	return nil
+}
+
+// SetSender informa o remetente, que precisa ter um CEP de algum estado
+func (p *Package) SetSender(contact vo.Contact) error {
+	if err := contact.Validate(); err != nil {
+		return apperr.NewBadRequestError("Invalid sender: " + err.Error())
+	}
+	if _, found := GetStateFromCEP(contact.Address.CEP); !found {
+		return apperr.NewBadRequestError("CEP not found in any state: " + contact.Address.CEP.String())
+	}
+
+	p.Sender = &contact
+	return nil
+}
+
+// OriginRegion retorna a região do armazém de origem, vazia em pacotes sem origem
+func (p Package) OriginRegion() DestinationRegion {
+	if p.Origin == nil {
+		return ""
+	}
+	return p.Origin.Region()
+}
+
+// Clone retorna uma cópia profunda do pacote, sem compartilhar frete ou histórico
+func (p Package) Clone() *Package {
+	clone := p
+	if p.Shipping != nil {
+		shipping := *p.Shipping
+		shipping.Breakdown.Surcharges = slices.Clone(p.Shipping.Breakdown.Surcharges)
+		clone.Shipping = &shipping
+	}
+	clone.History = slices.Clone(p.History)
+	clone.Items = slices.Clone(p.Items)
+	if p.Origin != nil {
+		origin := *p.Origin
+		clone.Origin = &origin
+	}
+	if p.Recipient != nil {
+		recipient := *p.Recipient
+		clone.Recipient = &recipient
+	}
+	if p.Sender != nil {
+		sender := *p.Sender
+		clone.Sender = &sender
+	}
+	if p.OverdueAt != nil {
+		overdueAt := *p.OverdueAt
+		clone.OverdueAt = &overdueAt
+	}
+	return &clone
+}
+
+// UpdateStatus atualiza o status do pacote e registra a mudança no histórico
+func (p *Package) UpdateStatus(status PackageStatus, actor, note string) error {
 	if !IsValidStatus(status) {
 		return apperr.NewBadRequestError("Invalid status")
 	}
 
+	if !CanTransition(p.Status, status) {
+		return NewInvalidStatusTransitionError(p.Status, status)
+	}
+
 	// Validação: status que requerem transportadora atrelada
 	statusesRequiringCarrier := []PackageStatus{
 		StatusWaitingPickup,
 		StatusCollected,
 		StatusShipped,
 		StatusDelivered,
-		StatusLost,          
+		StatusLost,
 	}
 
 	if slices.Contains(statusesRequiringCarrier, status) && p.Shipping == nil {
 		return apperr.NewBadRequestError("Package cannot be marked as '" + string(status) + "' without a carrier assigned")
 	}
 
-	p.Status = status
-	p.UpdatedAt = time.Now()
+	p.recordStatus(status, actor, note)
 
 	return nil
 }
 
-func (p Package) SortShippingsByDeliveryTime(shippings []vo.Shipping) []vo.Shipping {
-	slices.SortFunc(shippings, func(a, b vo.Shipping) int {
-		return a.EstimatedDays - b.EstimatedDays
-	})
-	return shippings
+// NextStatuses retorna os status para os quais o pacote pode transitar
+func (p Package) NextStatuses() []PackageStatus {
+	return AllowedTransitions(p.Status)
 }
 
-// AssignShipping atribui um frete ao pacote
-func (p *Package) AssignShipping(shipping vo.Shipping) {
+// AssignShipping atribui um frete ao pacote e registra a mudança no histórico
+func (p *Package) AssignShipping(shipping vo.Shipping, actor string) {
 	p.Shipping = &shipping
-	p.Status = StatusWaitingPickup
-	p.UpdatedAt = time.Now()
+	p.recordStatus(StatusWaitingPickup, actor, "Carrier hired: "+shipping.CarrierID)
+}
+
+// HiredAt retorna quando a transportadora foi contratada, pelo histórico de status
+func (p Package) HiredAt() (time.Time, bool) {
+	return p.reachedAt(StatusWaitingPickup)
+}
+
+// DeliveredAt retorna quando o pacote foi entregue, pelo histórico de status
+func (p Package) DeliveredAt() (time.Time, bool) {
+	return p.reachedAt(StatusDelivered)
+}
+
+// reachedAt retorna o primeiro registro do status no histórico
+func (p Package) reachedAt(status PackageStatus) (time.Time, bool) {
+	for _, event := range p.History {
+		if event.Status == status {
+			return event.Timestamp, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// DeliveryDeadline retorna o instante a partir do qual o pacote está atrasado: o fim do
+// dia de entrega prometido ou, para fretes contratados antes do cálculo da data, a
+// contratação somada a EstimatedDays dias corridos
+func (p Package) DeliveryDeadline() (time.Time, bool) {
+	if p.Shipping == nil {
+		return time.Time{}, false
+	}
+	if !p.Shipping.EstimatedDeliveryDate.IsZero() {
+		return p.Shipping.EstimatedDeliveryDate.AddDate(0, 0, 1), true
+	}
+
+	hiredAt, ok := p.HiredAt()
+	if !ok {
+		return time.Time{}, false
+	}
+	return hiredAt.AddDate(0, 0, p.Shipping.EstimatedDays), true
+}
+
+// IsOverdue indica se o pacote passou do prazo de entrega sem chegar a um status final
+func (p Package) IsOverdue(now time.Time) bool {
+	deadline, ok := p.DeliveryDeadline()
+	return ok && !IsTerminalStatus(p.Status) && !now.Before(deadline)
+}
+
+// MarkOverdue registra o atraso na primeira verificação depois do prazo e informa se o
+// pacote foi marcado agora. A marca é mantida depois da entrega ou do extravio, para a
+// cobrança de multas das transportadoras.
+func (p *Package) MarkOverdue(now time.Time) bool {
+	if p.OverdueAt != nil || !p.IsOverdue(now) {
+		return false
+	}
+
+	p.OverdueAt = &now
+	return true
+}
+
+// LateDays conta os dias de atraso até a entrega, o extravio ou, se o pacote ainda está
+// em trânsito, até now. Cada dia iniciado depois do prazo conta como um dia de atraso.
+func (p Package) LateDays(now time.Time) int {
+	deadline, ok := p.DeliveryDeadline()
+	if !ok {
+		return 0
+	}
+
+	end := now
+	if IsTerminalStatus(p.Status) && len(p.History) > 0 {
+		end = p.History[len(p.History)-1].Timestamp
+	}
+	if end.Before(deadline) {
+		return 0
+	}
+	return int(end.Sub(deadline)/(24*time.Hour)) + 1
+}
+
+// recordStatus aplica o status e adiciona o evento correspondente ao histórico
+func (p *Package) recordStatus(status PackageStatus, actor, note string) {
+	now := time.Now()
+	p.Status = status
+	p.UpdatedAt = now
+	p.History = append(p.History, NewStatusEvent(status, actor, note, now))
 }
 
 // IsValidStatus verifica se o status é válido
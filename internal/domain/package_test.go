@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"slices"
 	"testing"
 	"time"
 
@@ -111,6 +112,9 @@ func TestNewPackage(t *testing.T) {
 				assert.Equal(t, tt.weightKg, pkg.WeightKg)
 				assert.Equal(t, tt.expectedRegion, pkg.DestinationRegion)
 				assert.Equal(t, StatusCreated, pkg.Status)
+				require.Len(t, pkg.History, 1)
+				assert.Equal(t, StatusCreated, pkg.History[0].Status)
+				assert.Equal(t, SystemActor, pkg.History[0].Actor)
 				assert.NotZero(t, pkg.CreatedAt)
 				assert.NotZero(t, pkg.UpdatedAt)
 			}
@@ -119,95 +123,91 @@ func TestNewPackage(t *testing.T) {
 }
 
 func TestPackage_UpdateStatus(t *testing.T) {
+	withCarrier := func(p *Package) {
+		shipping := vo.NewShippingQuote("Test Carrier", "test-carrier", vo.NewMoney(2550), 5)
+		p.Shipping = &shipping
+	}
+
 	tests := []struct {
 		name         string
+		from         PackageStatus
 		status       PackageStatus
 		shouldError  bool
 		errorMessage string
 		setupPackage func(*Package)
 	}{
-		{
-			name:        "should update to created (no carrier needed)",
-			status:      StatusCreated,
-			shouldError: false,
-		},
 		{
 			name:         "should fail to update to waiting pickup without carrier",
+			from:         StatusCreated,
 			status:       StatusWaitingPickup,
 			shouldError:  true,
-			errorMessage: "Package cannot be marked as esperando_coleta without a carrier assigned",
+			errorMessage: "Package cannot be marked as 'esperando_coleta' without a carrier assigned",
 		},
 		{
-			name:        "should update to waiting pickup with carrier",
-			status:      StatusWaitingPickup,
-			shouldError: false,
-			setupPackage: func(p *Package) {
-				shipping := vo.NewShippingQuote("Test Carrier", "test-carrier", 25.50, 5)
-				p.Shipping = &shipping
-			},
+			name:         "should update to waiting pickup with carrier",
+			from:         StatusCreated,
+			status:       StatusWaitingPickup,
+			setupPackage: withCarrier,
 		},
 		{
-			name:         "should fail to update to collected without carrier",
+			name:         "should update to collected from waiting pickup",
+			from:         StatusWaitingPickup,
 			status:       StatusCollected,
-			shouldError:  true,
-			errorMessage: "Package cannot be marked as coletado without a carrier assigned",
+			setupPackage: withCarrier,
 		},
 		{
-			name:        "should update to collected with carrier",
-			status:      StatusCollected,
-			shouldError: false,
-			setupPackage: func(p *Package) {
-				shipping := vo.NewShippingQuote("Test Carrier", "test-carrier", 25.50, 5)
-				p.Shipping = &shipping
-			},
+			name:         "should update to shipped from collected",
+			from:         StatusCollected,
+			status:       StatusShipped,
+			setupPackage: withCarrier,
 		},
 		{
-			name:         "should fail to update to shipped without carrier",
-			status:       StatusShipped,
-			shouldError:  true,
-			errorMessage: "Package cannot be marked as enviado without a carrier assigned",
+			name:         "should update to delivered from shipped",
+			from:         StatusShipped,
+			status:       StatusDelivered,
+			setupPackage: withCarrier,
 		},
 		{
-			name:        "should update to shipped with carrier",
-			status:      StatusShipped,
-			shouldError: false,
-			setupPackage: func(p *Package) {
-				shipping := vo.NewShippingQuote("Test Carrier", "test-carrier", 25.50, 5)
-				p.Shipping = &shipping
-			},
+			name:         "should update to lost from shipped",
+			from:         StatusShipped,
+			status:       StatusLost,
+			setupPackage: withCarrier,
 		},
 		{
-			name:         "should fail to update to delivered without carrier",
+			name:         "should fail to skip from created to delivered",
+			from:         StatusCreated,
 			status:       StatusDelivered,
 			shouldError:  true,
-			errorMessage: "Package cannot be marked as entregue without a carrier assigned",
+			errorMessage: "Cannot change status from 'criado' to 'entregue'. Allowed: esperando_coleta",
+			setupPackage: withCarrier,
 		},
 		{
-			name:        "should update to delivered with carrier",
-			status:      StatusDelivered,
-			shouldError: false,
-			setupPackage: func(p *Package) {
-				shipping := vo.NewShippingQuote("Test Carrier", "test-carrier", 25.50, 5)
-				p.Shipping = &shipping
-			},
+			name:         "should fail to go back from delivered to created",
+			from:         StatusDelivered,
+			status:       StatusCreated,
+			shouldError:  true,
+			errorMessage: "'entregue' is a terminal status",
+			setupPackage: withCarrier,
 		},
 		{
-			name:         "should fail to update to lost without carrier",
-			status:       StatusLost,
+			name:         "should fail to leave lost status",
+			from:         StatusLost,
+			status:       StatusShipped,
 			shouldError:  true,
-			errorMessage: "Package cannot be marked as extraviado without a carrier assigned",
+			errorMessage: "'extraviado' is a terminal status",
+			setupPackage: withCarrier,
 		},
 		{
-			name:        "should update to lost with carrier",
-			status:      StatusLost,
-			shouldError: false,
-			setupPackage: func(p *Package) {
-				shipping := vo.NewShippingQuote("Test Carrier", "test-carrier", 25.50, 5)
-				p.Shipping = &shipping
-			},
+			name:         "should fail to repeat the current status",
+			from:         StatusCollected,
+			status:       StatusCollected,
+			shouldError:  true,
+			errorMessage: "Cannot change status from 'coletado' to 'coletado'",
+			setupPackage: withCarrier,
 		},
 		{
 			name:         "should fail with invalid status",
+			from:         StatusCreated,
 			status:       "invalid_status",
 			shouldError:  true,
 			errorMessage: "Invalid status",
@@ -219,6 +219,7 @@ func TestPackage_UpdateStatus(t *testing.T) {
 			// Create a fresh package for each test
 			testPkg, err := NewPackage("Test Product", "SP", 1.0, DestinationRegionSoutheast)
 			require.NoError(t, err)
+			testPkg.Status = tt.from
 
 			// Setup package if needed
 			if tt.setupPackage != nil {
@@ -228,16 +229,20 @@ func TestPackage_UpdateStatus(t *testing.T) {
 			originalUpdatedAt := testPkg.UpdatedAt
 			time.Sleep(1 * time.Millisecond) // Ensure time difference
 
-			err = testPkg.UpdateStatus(tt.status)
+			err = testPkg.UpdateStatus(tt.status, "operator", "")
 
 			if tt.shouldError {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tt.errorMessage)
-				assert.NotEqual(t, tt.status, testPkg.Status)
+				assert.Equal(t, tt.from, testPkg.Status)
+				assert.Len(t, testPkg.History, 1)
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.status, testPkg.Status)
 				assert.True(t, testPkg.UpdatedAt.After(originalUpdatedAt))
+				require.Len(t, testPkg.History, 2)
+				assert.Equal(t, tt.status, testPkg.History[1].Status)
+				assert.Equal(t, "operator", testPkg.History[1].Actor)
 			}
 		})
 	}
@@ -247,37 +252,418 @@ func TestPackage_AssignShipping(t *testing.T) {
 	pkg, err := NewPackage("Test Product", "SP", 1.0, DestinationRegionSoutheast)
 	require.NoError(t, err)
 
-	shipping := vo.NewShippingQuote("Test Carrier", "test-carrier", 25.50, 5)
+	shipping := vo.NewShippingQuote("Test Carrier", "test-carrier", vo.NewMoney(2550), 5)
 
 	t.Run("should assign shipping successfully", func(t *testing.T) {
 		originalUpdatedAt := pkg.UpdatedAt
 		time.Sleep(1 * time.Millisecond)
 
-		pkg.AssignShipping(shipping)
+		pkg.AssignShipping(shipping, "operator")
 
 		assert.Equal(t, &shipping, pkg.Shipping)
 		assert.Equal(t, StatusWaitingPickup, pkg.Status)
 		assert.True(t, pkg.UpdatedAt.After(originalUpdatedAt))
 	})
+
+	t.Run("should record the hire in the history", func(t *testing.T) {
+		require.Len(t, pkg.History, 2)
+		event := pkg.History[1]
+		assert.Equal(t, StatusWaitingPickup, event.Status)
+		assert.Equal(t, "operator", event.Actor)
+		assert.Equal(t, "Carrier hired: test-carrier", event.Note)
+		assert.Equal(t, pkg.UpdatedAt, event.Timestamp)
+	})
 }
 
-func TestPackage_SortShippingsByDeliveryTime(t *testing.T) {
-	pkg, err := NewPackage("Test Product", "SP", 1.0, DestinationRegionSoutheast)
+func TestPackage_IsOverdue(t *testing.T) {
+	promised := vo.NewShippingQuote("Test Carrier", "test-carrier", vo.NewMoney(2550), 5)
+	promised.EstimatedDeliveryDate = date("2025-01-17")
+	legacy := vo.NewShippingQuote("Test Carrier", "test-carrier", vo.NewMoney(2550), 5)
+	hired := []StatusEvent{
+		NewStatusEvent(StatusCreated, "", "", date("2025-01-10")),
+		NewStatusEvent(StatusWaitingPickup, "operator", "", date("2025-01-10").Add(14*time.Hour)),
+	}
+
+	tests := []struct {
+		name     string
+		shipping *vo.Shipping
+		history  []StatusEvent
+		status   PackageStatus
+		now      time.Time
+		expected bool
+	}{
+		{name: "on the promised date", shipping: &promised, status: StatusShipped, now: date("2025-01-17").Add(23 * time.Hour), expected: false},
+		{name: "after the promised date", shipping: &promised, status: StatusShipped, now: date("2025-01-18"), expected: true},
+		{name: "waiting pickup after the promised date", shipping: &promised, status: StatusWaitingPickup, now: date("2025-01-20"), expected: true},
+		{name: "delivered", shipping: &promised, status: StatusDelivered, now: date("2025-01-20"), expected: false},
+		{name: "lost", shipping: &promised, status: StatusLost, now: date("2025-01-20"), expected: false},
+		{name: "without carrier", status: StatusCreated, now: date("2025-01-20"), expected: false},
+		// fretes sem data prometida contam os dias corridos desde a contratação
+		{name: "legacy hire within the estimated days", shipping: &legacy, history: hired, status: StatusShipped, now: date("2025-01-15").Add(13 * time.Hour), expected: false},
+		{name: "legacy hire after the estimated days", shipping: &legacy, history: hired, status: StatusShipped, now: date("2025-01-15").Add(14 * time.Hour), expected: true},
+		{name: "legacy hire without history", shipping: &legacy, status: StatusShipped, now: date("2025-01-20"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg := Package{Shipping: tt.shipping, History: tt.history, Status: tt.status}
+
+			assert.Equal(t, tt.expected, pkg.IsOverdue(tt.now))
+		})
+	}
+}
+
+func TestPackage_MarkOverdue(t *testing.T) {
+	shipping := vo.NewShippingQuote("Test Carrier", "test-carrier", vo.NewMoney(2550), 5)
+	shipping.EstimatedDeliveryDate = date("2025-01-17")
+	pkg := &Package{Shipping: &shipping, Status: StatusShipped}
+
+	t.Run("should not mark before the deadline", func(t *testing.T) {
+		assert.False(t, pkg.MarkOverdue(date("2025-01-17")))
+		assert.Nil(t, pkg.OverdueAt)
+	})
+
+	t.Run("should mark once after the deadline", func(t *testing.T) {
+		first := date("2025-01-18").Add(5 * time.Minute)
+
+		assert.True(t, pkg.MarkOverdue(first))
+		assert.False(t, pkg.MarkOverdue(date("2025-01-19")))
+		assert.Equal(t, first, *pkg.OverdueAt)
+	})
+
+	t.Run("should keep the mark after delivery", func(t *testing.T) {
+		pkg.Status = StatusDelivered
+
+		assert.False(t, pkg.IsOverdue(date("2025-01-20")))
+		assert.NotNil(t, pkg.OverdueAt)
+	})
+}
+
+func TestPackage_LateDays(t *testing.T) {
+	shipping := vo.NewShippingQuote("Test Carrier", "test-carrier", vo.NewMoney(2550), 5)
+	shipping.EstimatedDeliveryDate = date("2025-01-17")
+	shipped := []StatusEvent{NewStatusEvent(StatusShipped, "", "", date("2025-01-15"))}
+
+	tests := []struct {
+		name     string
+		status   PackageStatus
+		history  []StatusEvent
+		now      time.Time
+		expected int
+	}{
+		{name: "on time", status: StatusShipped, history: shipped, now: date("2025-01-17").Add(20 * time.Hour), expected: 0},
+		{name: "first day late", status: StatusShipped, history: shipped, now: date("2025-01-18").Add(time.Hour), expected: 1},
+		{name: "still in transit", status: StatusShipped, history: shipped, now: date("2025-01-21").Add(time.Hour), expected: 4},
+		{
+			name:     "counts until the delivery",
+			status:   StatusDelivered,
+			history:  append(slices.Clone(shipped), NewStatusEvent(StatusDelivered, "", "", date("2025-01-19").Add(10*time.Hour))),
+			now:      date("2025-02-01"),
+			expected: 2,
+		},
+		{
+			name:     "delivered on time",
+			status:   StatusDelivered,
+			history:  append(slices.Clone(shipped), NewStatusEvent(StatusDelivered, "", "", date("2025-01-17").Add(10*time.Hour))),
+			now:      date("2025-02-01"),
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg := Package{Shipping: &shipping, Status: tt.status, History: tt.history}
+
+			assert.Equal(t, tt.expected, pkg.LateDays(tt.now))
+		})
+	}
+}
+
+func TestPackage_SetDimensions(t *testing.T) {
+	pkg, err := NewPackage("Poltrona", "SP", 15, DestinationRegionSoutheast)
 	require.NoError(t, err)
 
-	shippings := []vo.Shipping{
-		vo.NewShippingQuote("Slow Carrier", "slow", 30.0, 10),
-		vo.NewShippingQuote("Fast Carrier", "fast", 25.0, 3),
-		vo.NewShippingQuote("Medium Carrier", "medium", 27.0, 7),
+	t.Run("should keep valid dimensions", func(t *testing.T) {
+		require.NoError(t, pkg.SetDimensions(vo.NewDimensions(100, 80, 90)))
+		assert.Equal(t, vo.NewDimensions(100, 80, 90), pkg.Dimensions)
+	})
+
+	t.Run("should reject partial dimensions", func(t *testing.T) {
+		err := pkg.SetDimensions(vo.NewDimensions(100, 80, 0))
+
+		assert.ErrorContains(t, err, "Package dimensions must all be greater than zero")
+		assert.Equal(t, vo.NewDimensions(100, 80, 90), pkg.Dimensions)
+	})
+}
+
+func TestPackage_SetDestinationCEP(t *testing.T) {
+	tests := []struct {
+		name          string
+		cep           vo.CEP
+		expectedError string
+	}{
+		{name: "CEP in the destination state", cep: "80010000"},
+		{name: "CEP in another state", cep: "01310100", expectedError: "CEP 01310-100 belongs to SP, not PR"},
+		{name: "CEP outside every state", cep: "00000100", expectedError: "CEP not found in any state: 00000-100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg, err := NewPackage("Test Product", "PR", 1.0, DestinationRegionSouth)
+			require.NoError(t, err)
+
+			err = pkg.SetDestinationCEP(tt.cep)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				assert.True(t, pkg.DestinationCEP.IsZero())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.cep, pkg.DestinationCEP)
+		})
+	}
+}
+
+func TestPackage_SetItems(t *testing.T) {
+	items := []vo.Item{
+		{SKU: "CAM-G", Description: "Camisa azul", Quantity: 3, UnitWeightKg: 0.1, UnitValue: vo.NewMoney(8990), NCM: "62052000"},
+		{Description: "Calça jeans", Quantity: 1, UnitWeightKg: 0.65, UnitValue: vo.NewMoney(15950)},
+	}
+
+	t.Run("should derive the weight and the product from the items", func(t *testing.T) {
+		pkg, err := NewPackage("", "PR", 0, DestinationRegionSouth)
+		require.NoError(t, err)
+
+		require.NoError(t, pkg.SetItems(items))
+
+		assert.Equal(t, 0.95, pkg.WeightKg)
+		assert.Equal(t, "Camisa azul e mais 1 item", pkg.Product)
+		assert.Equal(t, vo.NewMoney(42920), pkg.DeclaredValue)
+	})
+
+	t.Run("should keep the informed weight and product", func(t *testing.T) {
+		pkg, err := NewPackage("Roupas", "PR", 1.2, DestinationRegionSouth)
+		require.NoError(t, err)
+
+		require.NoError(t, pkg.SetItems(items[:1]))
+
+		assert.Equal(t, 1.2, pkg.WeightKg)
+		assert.Equal(t, "Roupas", pkg.Product)
+		assert.Equal(t, vo.NewMoney(26970), pkg.DeclaredValue)
+	})
+
+	t.Run("should keep a declared value above the items", func(t *testing.T) {
+		pkg, err := NewPackage("Roupas", "PR", 0, DestinationRegionSouth)
+		require.NoError(t, err)
+		require.NoError(t, pkg.SetDeclaredValue(vo.NewMoney(50000)))
+
+		require.NoError(t, pkg.SetItems(items))
+
+		assert.Equal(t, vo.NewMoney(50000), pkg.DeclaredValue)
+	})
+
+	tests := []struct {
+		name          string
+		weightKg      float64
+		declaredValue vo.Money
+		items         []vo.Item
+		expectedError string
+	}{
+		{name: "no items", items: []vo.Item{}, expectedError: "Package items must not be empty"},
+		{name: "invalid item", items: []vo.Item{items[0], {Description: "Meia"}}, expectedError: "Invalid item 2: item quantity must be greater than zero"},
+		{name: "weight below the items", weightKg: 0.5, items: items, expectedError: "Package weight 0.5 kg is less than the items weight 0.95 kg"},
+		{name: "items over the maximum weight", items: []vo.Item{{Description: "Bobina de aço", Quantity: 3, UnitWeightKg: 400, UnitValue: vo.NewMoney(100000)}}, expectedError: "Items weight 1200 kg exceeds the maximum package weight of 1000 kg"},
+		{name: "declared value below the items", declaredValue: vo.NewMoney(30000), items: items, expectedError: "Declared value R$ 300,00 is less than the items value R$ 429,20"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg, err := NewPackage("Roupas", "PR", tt.weightKg, DestinationRegionSouth)
+			require.NoError(t, err)
+			pkg.DeclaredValue = tt.declaredValue
+
+			err = pkg.SetItems(tt.items)
+
+			assert.ErrorContains(t, err, tt.expectedError)
+			assert.Nil(t, pkg.Items)
+			assert.Equal(t, tt.weightKg, pkg.WeightKg)
+			assert.Equal(t, tt.declaredValue, pkg.DeclaredValue)
+		})
+	}
+}
+
+func TestPackage_SetDeclaredValue(t *testing.T) {
+	items := []vo.Item{{Description: "Camisa azul", Quantity: 2, UnitWeightKg: 0.3, UnitValue: vo.NewMoney(8990)}}
+
+	tests := []struct {
+		name          string
+		items         []vo.Item
+		value         vo.Money
+		expectedError string
+	}{
+		{name: "package without items", value: vo.NewMoney(179990)},
+		{name: "value equal to the items", items: items, value: vo.NewMoney(17980)},
+		{name: "value above the items", items: items, value: vo.NewMoney(20000)},
+		{name: "zero value", value: vo.NewMoney(0), expectedError: "Declared value must be greater than zero"},
+		{name: "negative value", value: vo.NewMoney(-100), expectedError: "Declared value must be greater than zero"},
+		{name: "value below the items", items: items, value: vo.NewMoney(10000), expectedError: "Declared value R$ 100,00 is less than the items value R$ 179,80"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg, err := NewPackage("Roupas", "PR", 1.0, DestinationRegionSouth)
+			require.NoError(t, err)
+			pkg.Items = tt.items
+
+			err = pkg.SetDeclaredValue(tt.value)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				assert.True(t, pkg.DeclaredValue.IsZero())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.value, pkg.DeclaredValue)
+		})
+	}
+}
+
+func TestPackage_SetRecipient(t *testing.T) {
+	contact := func(cep vo.CEP) vo.Contact {
+		return vo.Contact{
+			Name:     "Maria da Silva",
+			Document: "52998224725",
+			Address:  vo.Address{Street: "Rua XV de Novembro", Number: "1000", Neighborhood: "Centro", City: "Curitiba", CEP: cep},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		destinationCEP vo.CEP
+		recipient      vo.Contact
+		expectedError  string
+	}{
+		{name: "address in the destination state", recipient: contact("80010000")},
+		{name: "address in the destination CEP", destinationCEP: "80010000", recipient: contact("80010000")},
+		{name: "address in another CEP", destinationCEP: "80010000", recipient: contact("80020310"), expectedError: "Recipient CEP 80020-310 differs from the destination CEP 80010-000"},
+		{name: "address in another state", recipient: contact("01310100"), expectedError: "CEP 01310-100 belongs to SP, not PR"},
+		{name: "incomplete contact", recipient: vo.Contact{Name: "Maria da Silva"}, expectedError: "Invalid recipient: contact document (CPF or CNPJ) is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg, err := NewPackage("Test Product", "PR", 1.0, DestinationRegionSouth)
+			require.NoError(t, err)
+			pkg.DestinationCEP = tt.destinationCEP
+
+			err = pkg.SetRecipient(tt.recipient)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				assert.Nil(t, pkg.Recipient)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.recipient, *pkg.Recipient)
+		})
+	}
+}
+
+func TestPackage_SetSender(t *testing.T) {
+	pkg, err := NewPackage("Test Product", "PR", 1.0, DestinationRegionSouth)
+	require.NoError(t, err)
+	sender := vo.Contact{
+		Name:     "Loja Exemplo Ltda",
+		Document: "11222333000181",
+		Address:  vo.Address{Street: "Avenida Paulista", Number: "1578", Neighborhood: "Bela Vista", City: "São Paulo", CEP: "01310200"},
 	}
 
-	t.Run("should sort shippings by delivery time", func(t *testing.T) {
-		sorted := pkg.SortShippingsByDeliveryTime(shippings)
+	t.Run("should accept an address in any state", func(t *testing.T) {
+		require.NoError(t, pkg.SetSender(sender))
+		assert.Equal(t, sender, *pkg.Sender)
+	})
+
+	t.Run("should reject a CEP outside every state", func(t *testing.T) {
+		sender.Address.CEP = "00000100"
+		assert.ErrorContains(t, pkg.SetSender(sender), "CEP not found in any state: 00000-100")
+	})
+
+	t.Run("should reject an incomplete contact", func(t *testing.T) {
+		assert.ErrorContains(t, pkg.SetSender(vo.Contact{}), "Invalid sender: contact name is required")
+	})
+}
+
+func TestPackage_Clone(t *testing.T) {
+	pkg, err := NewPackage("Test Product", "SP", 1.0, DestinationRegionSoutheast)
+	require.NoError(t, err)
+	pkg.AssignShipping(vo.NewShippingQuote("Test Carrier", "test-carrier", vo.NewMoney(2550), 5), "operator")
+
+	clone := pkg.Clone()
+
+	t.Run("should copy all fields", func(t *testing.T) {
+		assert.Equal(t, pkg, clone)
+	})
+
+	t.Run("should not share shipping or history", func(t *testing.T) {
+		clone.Shipping.EstimatedPrice = vo.NewMoney(9900)
+		clone.History[0].Actor = "changed"
+		clone.History = append(clone.History, NewStatusEvent(StatusCollected, "", "", time.Now()))
+
+		assert.Equal(t, vo.NewMoney(2550), pkg.Shipping.EstimatedPrice)
+		assert.Equal(t, SystemActor, pkg.History[0].Actor)
+		assert.Len(t, pkg.History, 2)
+	})
+
+	t.Run("should not share the overdue mark", func(t *testing.T) {
+		overdueAt := time.Now()
+		pkg.OverdueAt = &overdueAt
+
+		clone := pkg.Clone()
+		*clone.OverdueAt = overdueAt.Add(time.Hour)
+
+		assert.Equal(t, overdueAt, *pkg.OverdueAt)
+	})
+
+	t.Run("should not share the origin", func(t *testing.T) {
+		pkg.SetOrigin(Warehouse{ID: "recife", Name: "CD Recife", State: "PE", CEP: "50050000"})
+
+		clone := pkg.Clone()
+		clone.Origin.Name = "changed"
+
+		assert.Equal(t, "CD Recife", pkg.Origin.Name)
+		assert.Equal(t, DestinationRegionNortheast, clone.OriginRegion())
+	})
+
+	t.Run("should not share the items", func(t *testing.T) {
+		pkg.Items = []vo.Item{{Description: "Camisa", Quantity: 1, UnitWeightKg: 0.3, UnitValue: vo.NewMoney(8990)}}
+
+		clone := pkg.Clone()
+		clone.Items[0].Quantity = 5
+
+		assert.Equal(t, 1, pkg.Items[0].Quantity)
+	})
+
+	t.Run("should not share the recipient or the sender", func(t *testing.T) {
+		pkg.Recipient = &vo.Contact{Name: "Maria da Silva"}
+		pkg.Sender = &vo.Contact{Name: "Loja Exemplo"}
+
+		clone := pkg.Clone()
+		clone.Recipient.Name = "changed"
+		clone.Sender.Address.City = "changed"
+
+		assert.Equal(t, "Maria da Silva", pkg.Recipient.Name)
+		assert.Empty(t, pkg.Sender.Address.City)
+	})
+
+	t.Run("should not share the surcharges of the shipping", func(t *testing.T) {
+		breakdown := vo.PriceBreakdown{Freight: vo.NewMoney(2060), Surcharges: []vo.Surcharge{{Name: "coleta", Amount: vo.NewMoney(490)}}}
+		pkg.Shipping = &vo.Shipping{CarrierID: "test-carrier", EstimatedPrice: breakdown.Total(), Breakdown: breakdown}
+
+		clone := pkg.Clone()
+		clone.Shipping.Breakdown.Surcharges[0].Amount = vo.NewMoney(990)
 
-		assert.Len(t, sorted, 3)
-		assert.Equal(t, 3, sorted[0].EstimatedDays)  // Fastest first
-		assert.Equal(t, 7, sorted[1].EstimatedDays)  // Medium
-		assert.Equal(t, 10, sorted[2].EstimatedDays) // Slowest last
+		assert.Equal(t, vo.NewMoney(490), pkg.Shipping.Breakdown.Surcharges[0].Amount)
 	})
 }
 
@@ -0,0 +1,234 @@
+package domain
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	apperr "github.com/foliveiracamara/delivery-manager-api/internal/shared/apperror"
+)
+
+type PackageSortField string
+
+const (
+	SortByCreatedAt PackageSortField = "criado_em"
+	SortByUpdatedAt PackageSortField = "atualizado_em"
+	SortByWeight    PackageSortField = "peso_kg"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// PackageFilter reúne os critérios de busca de pacotes; campos vazios não filtram
+type PackageFilter struct {
+	Statuses    []PackageStatus
+	Region      DestinationRegion
+	State       string
+	CarrierID   string
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	UpdatedFrom time.Time
+	UpdatedTo   time.Time
+	MinWeightKg float64
+	MaxWeightKg float64
+	// OverdueOnly restringe aos pacotes já marcados como atrasados
+	OverdueOnly bool
+}
+
+// Matches verifica se o pacote atende a todos os critérios do filtro
+func (f PackageFilter) Matches(p *Package) bool {
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, p.Status) {
+		return false
+	}
+	if f.Region != "" && p.DestinationRegion != f.Region {
+		return false
+	}
+	if f.State != "" && p.DestinationState != f.State {
+		return false
+	}
+	if f.CarrierID != "" && (p.Shipping == nil || p.Shipping.CarrierID != f.CarrierID) {
+		return false
+	}
+	if !inTimeRange(p.CreatedAt, f.CreatedFrom, f.CreatedTo) || !inTimeRange(p.UpdatedAt, f.UpdatedFrom, f.UpdatedTo) {
+		return false
+	}
+	if f.MinWeightKg > 0 && p.WeightKg < f.MinWeightKg {
+		return false
+	}
+	if f.MaxWeightKg > 0 && p.WeightKg > f.MaxWeightKg {
+		return false
+	}
+	if f.OverdueOnly && p.OverdueAt == nil {
+		return false
+	}
+	return true
+}
+
+func inTimeRange(t, from, to time.Time) bool {
+	if !from.IsZero() && t.Before(from) {
+		return false
+	}
+	if !to.IsZero() && t.After(to) {
+		return false
+	}
+	return true
+}
+
+// PackageQuery descreve uma página da listagem de pacotes
+type PackageQuery struct {
+	Filter     PackageFilter
+	SortBy     PackageSortField
+	Descending bool
+	Limit      int
+	After      *PackageCursor
+}
+
+// NewPackageQuery valida ordenação, limite e cursor. O cursor precisa ter sido gerado
+// com a mesma ordenação da consulta.
+func NewPackageQuery(filter PackageFilter, sortBy PackageSortField, descending bool, limit int, cursor string) (PackageQuery, error) {
+	if sortBy == "" {
+		sortBy = SortByCreatedAt
+	}
+	if !slices.Contains([]PackageSortField{SortByCreatedAt, SortByUpdatedAt, SortByWeight}, sortBy) {
+		return PackageQuery{}, apperr.NewBadRequestError("Invalid sort field: " + string(sortBy))
+	}
+
+	if limit == 0 {
+		limit = DefaultPageSize
+	}
+	if limit < 0 || limit > MaxPageSize {
+		return PackageQuery{}, apperr.NewBadRequestError("Limit must be between 1 and 100")
+	}
+
+	if filter.Region != "" && !isValidDestinationRegion(filter.Region) {
+		return PackageQuery{}, apperr.NewBadRequestError("Invalid destination region: " + string(filter.Region))
+	}
+
+	for _, status := range filter.Statuses {
+		if !IsValidStatus(status) {
+			return PackageQuery{}, apperr.NewBadRequestError("Invalid status: " + string(status))
+		}
+	}
+
+	query := PackageQuery{
+		Filter:     filter,
+		SortBy:     sortBy,
+		Descending: descending,
+		Limit:      limit,
+	}
+
+	if cursor != "" {
+		after, err := DecodePackageCursor(cursor)
+		if err != nil {
+			return PackageQuery{}, err
+		}
+		if after.SortBy != sortBy || after.Descending != descending {
+			return PackageQuery{}, apperr.NewBadRequestError("Cursor does not match the requested sort")
+		}
+		query.After = after
+	}
+
+	return query, nil
+}
+
+// Compare ordena pacotes pelo campo da consulta, desempatando pelo ID
+func (q PackageQuery) Compare(a, b *Package) int {
+	return q.compareCursors(q.CursorFor(a), q.CursorFor(b))
+}
+
+// IsAfterCursor verifica se o pacote vem depois do cursor na ordenação da consulta
+func (q PackageQuery) IsAfterCursor(p *Package) bool {
+	return q.After == nil || q.compareCursors(q.CursorFor(p), *q.After) > 0
+}
+
+func (q PackageQuery) compareCursors(a, b PackageCursor) int {
+	var result int
+	switch q.SortBy {
+	case SortByWeight:
+		result = cmp.Compare(a.Weight, b.Weight)
+	default:
+		result = a.Time.Compare(b.Time)
+	}
+	if result == 0 {
+		result = strings.Compare(a.ID, b.ID)
+	}
+	if q.Descending {
+		result = -result
+	}
+	return result
+}
+
+// CursorFor gera a posição do pacote na ordenação da consulta
+func (q PackageQuery) CursorFor(p *Package) PackageCursor {
+	cursor := PackageCursor{
+		SortBy:     q.SortBy,
+		Descending: q.Descending,
+		ID:         p.ID,
+	}
+
+	switch q.SortBy {
+	case SortByUpdatedAt:
+		cursor.Time = p.UpdatedAt
+	case SortByWeight:
+		cursor.Weight = p.WeightKg
+	default:
+		cursor.Time = p.CreatedAt
+	}
+
+	return cursor
+}
+
+// PackagePage é uma página de pacotes com o cursor para a próxima, se houver
+type PackagePage struct {
+	Packages   []*Package
+	NextCursor string
+}
+
+// NewPackagePage monta a página a partir de pacotes já ordenados e posicionados após o
+// cursor. Recebendo mais itens que o limite, o excedente indica que há próxima página.
+func NewPackagePage(q PackageQuery, packages []*Package) *PackagePage {
+	if len(packages) <= q.Limit {
+		return &PackagePage{Packages: packages}
+	}
+
+	packages = packages[:q.Limit]
+	return &PackagePage{
+		Packages:   packages,
+		NextCursor: q.CursorFor(packages[len(packages)-1]).Encode(),
+	}
+}
+
+// PackageCursor é a posição opaca de um pacote na listagem
+type PackageCursor struct {
+	SortBy     PackageSortField `json:"s"`
+	Descending bool             `json:"d"`
+	Time       time.Time        `json:"t"`
+	Weight     float64          `json:"w"`
+	ID         string           `json:"i"`
+}
+
+// Encode serializa o cursor em uma string opaca segura para URLs
+func (c PackageCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodePackageCursor interpreta um cursor gerado por Encode
+func DecodePackageCursor(value string) (*PackageCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, apperr.NewBadRequestError("Invalid cursor")
+	}
+
+	cursor := &PackageCursor{}
+	if err := json.Unmarshal(data, cursor); err != nil || cursor.ID == "" {
+		return nil, apperr.NewBadRequestError("Invalid cursor")
+	}
+
+	return cursor, nil
+}
@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageFilter_Matches(t *testing.T) {
+	pkg, err := NewPackage("Test Product", "PR", 5.0, DestinationRegionSouth)
+	require.NoError(t, err)
+	pkg.AssignShipping(vo.NewShippingQuote("Nebulix", "nebulix", vo.NewMoney(2950), 4), "operator")
+
+	tests := []struct {
+		name     string
+		filter   PackageFilter
+		expected bool
+	}{
+		{"empty filter", PackageFilter{}, true},
+		{"matching status", PackageFilter{Statuses: []PackageStatus{StatusCreated, StatusWaitingPickup}}, true},
+		{"other status", PackageFilter{Statuses: []PackageStatus{StatusDelivered}}, false},
+		{"matching region", PackageFilter{Region: DestinationRegionSouth}, true},
+		{"other region", PackageFilter{Region: DestinationRegionNorth}, false},
+		{"matching state", PackageFilter{State: "PR"}, true},
+		{"other state", PackageFilter{State: "SP"}, false},
+		{"matching carrier", PackageFilter{CarrierID: "nebulix"}, true},
+		{"other carrier", PackageFilter{CarrierID: "moventra"}, false},
+		{"created inside range", PackageFilter{CreatedFrom: pkg.CreatedAt.Add(-time.Hour), CreatedTo: pkg.CreatedAt.Add(time.Hour)}, true},
+		{"created before range", PackageFilter{CreatedFrom: pkg.CreatedAt.Add(time.Hour)}, false},
+		{"updated after range", PackageFilter{UpdatedTo: pkg.UpdatedAt.Add(-time.Hour)}, false},
+		{"weight inside range", PackageFilter{MinWeightKg: 5.0, MaxWeightKg: 5.0}, true},
+		{"too light", PackageFilter{MinWeightKg: 5.1}, false},
+		{"too heavy", PackageFilter{MaxWeightKg: 4.9}, false},
+		{"not marked overdue", PackageFilter{OverdueOnly: true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.filter.Matches(pkg))
+		})
+	}
+
+	t.Run("carrier filter excludes packages without carrier", func(t *testing.T) {
+		unassigned, err := NewPackage("Test Product", "PR", 5.0, DestinationRegionSouth)
+		require.NoError(t, err)
+
+		assert.False(t, PackageFilter{CarrierID: "nebulix"}.Matches(unassigned))
+	})
+
+	t.Run("overdue filter matches marked packages", func(t *testing.T) {
+		overdue := pkg.Clone()
+		overdueAt := time.Now()
+		overdue.OverdueAt = &overdueAt
+
+		assert.True(t, PackageFilter{OverdueOnly: true}.Matches(overdue))
+	})
+}
+
+func TestNewPackageQuery(t *testing.T) {
+	t.Run("should apply defaults", func(t *testing.T) {
+		query, err := NewPackageQuery(PackageFilter{}, "", false, 0, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, SortByCreatedAt, query.SortBy)
+		assert.Equal(t, DefaultPageSize, query.Limit)
+		assert.Nil(t, query.After)
+	})
+
+	t.Run("should reject invalid parameters", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			filter   PackageFilter
+			sortBy   PackageSortField
+			limit    int
+			cursor   string
+			expected string
+		}{
+			{"unknown sort", PackageFilter{}, "produto", 0, "", "Invalid sort field: produto"},
+			{"limit too large", PackageFilter{}, SortByCreatedAt, MaxPageSize + 1, "", "Limit must be between 1 and 100"},
+			{"negative limit", PackageFilter{}, SortByCreatedAt, -1, "", "Limit must be between 1 and 100"},
+			{"unknown status", PackageFilter{Statuses: []PackageStatus{"perdido"}}, SortByCreatedAt, 0, "", "Invalid status: perdido"},
+			{"unknown region", PackageFilter{Region: "oeste"}, SortByCreatedAt, 0, "", "Invalid destination region: oeste"},
+			{"garbage cursor", PackageFilter{}, SortByCreatedAt, 0, "%%%", "Invalid cursor"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := NewPackageQuery(tt.filter, tt.sortBy, false, tt.limit, tt.cursor)
+				assert.ErrorContains(t, err, tt.expected)
+			})
+		}
+	})
+
+	t.Run("should reject a cursor from another sort", func(t *testing.T) {
+		cursor := PackageCursor{SortBy: SortByWeight, Weight: 2.0, ID: "abc"}.Encode()
+
+		_, err := NewPackageQuery(PackageFilter{}, SortByCreatedAt, false, 0, cursor)
+
+		assert.ErrorContains(t, err, "Cursor does not match the requested sort")
+	})
+}
+
+func TestPackageQuery_Pagination(t *testing.T) {
+	base := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	packages := []*Package{
+		{ID: "a", CreatedAt: base, WeightKg: 3.0},
+		{ID: "b", CreatedAt: base.Add(time.Hour), WeightKg: 1.0},
+		{ID: "c", CreatedAt: base.Add(time.Hour), WeightKg: 2.0},
+	}
+
+	t.Run("should break ties by ID", func(t *testing.T) {
+		query, err := NewPackageQuery(PackageFilter{}, SortByCreatedAt, true, 0, "")
+		require.NoError(t, err)
+
+		assert.Negative(t, query.Compare(packages[2], packages[1]))
+		assert.Negative(t, query.Compare(packages[1], packages[0]))
+	})
+
+	t.Run("should build next cursor only when there are more items", func(t *testing.T) {
+		query, err := NewPackageQuery(PackageFilter{}, SortByWeight, false, 2, "")
+		require.NoError(t, err)
+
+		page := NewPackagePage(query, []*Package{packages[1], packages[2], packages[0]})
+		assert.Len(t, page.Packages, 2)
+		require.NotEmpty(t, page.NextCursor)
+
+		next, err := NewPackageQuery(PackageFilter{}, SortByWeight, false, 2, page.NextCursor)
+		require.NoError(t, err)
+		assert.False(t, next.IsAfterCursor(packages[2]))
+		assert.True(t, next.IsAfterCursor(packages[0]))
+
+		last := NewPackagePage(next, []*Package{packages[0]})
+		assert.Empty(t, last.NextCursor)
+	})
+}
+
+func TestPackageCursor_EncodeDecode(t *testing.T) {
+	cursor := PackageCursor{
+		SortBy:     SortByUpdatedAt,
+		Descending: true,
+		Time:       time.Date(2025, 1, 10, 12, 30, 15, 123456789, time.UTC),
+		ID:         "123e4567-e89b-12d3-a456-426614174000",
+	}
+
+	decoded, err := DecodePackageCursor(cursor.Encode())
+
+	require.NoError(t, err)
+	assert.Equal(t, cursor.SortBy, decoded.SortBy)
+	assert.Equal(t, cursor.Descending, decoded.Descending)
+	assert.True(t, cursor.Time.Equal(decoded.Time))
+	assert.Equal(t, cursor.ID, decoded.ID)
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
+	apperr "github.com/foliveiracamara/delivery-manager-api/internal/shared/apperror"
+	"github.com/google/uuid"
+)
+
+// DefaultQuoteValidity é a validade usada quando nenhuma é configurada
+const DefaultQuoteValidity = 30 * time.Minute
+
+// Quote é uma cotação de frete registrada. O preço e o prazo cotados são garantidos
+// na contratação até ExpiresAt.
+type Quote struct {
+	ID        string
+	PackageID string
+	Shipping  vo.Shipping
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// NewQuote registra a cotação de um pacote válida por validity a partir de now
+func NewQuote(packageID string, shipping vo.Shipping, validity time.Duration, now time.Time) *Quote {
+	return &Quote{
+		ID:        uuid.New().String(),
+		PackageID: packageID,
+		Shipping:  shipping,
+		CreatedAt: now,
+		ExpiresAt: now.Add(validity),
+	}
+}
+
+// Clone retorna uma cópia profunda da cotação, sem compartilhar as taxas adicionais
+func (q Quote) Clone() *Quote {
+	clone := q
+	clone.Shipping.Breakdown.Surcharges = slices.Clone(q.Shipping.Breakdown.Surcharges)
+	return &clone
+}
+
+// IsExpired verifica se a cotação já não pode ser usada na contratação
+func (q Quote) IsExpired(now time.Time) bool {
+	return !now.Before(q.ExpiresAt)
+}
+
+// CheckHire verifica se a cotação pode ser usada para contratar o frete do pacote
+func (q Quote) CheckHire(pkg *Package, now time.Time) error {
+	if q.PackageID != pkg.ID {
+		return apperr.NewBadRequestError("Quote " + q.ID + " does not belong to package " + pkg.ID)
+	}
+	if q.IsExpired(now) {
+		return apperr.NewGoneError("Quote " + q.ID + " expired at " + q.ExpiresAt.Format(time.RFC3339) + ", request a new quote")
+	}
+	return nil
+}
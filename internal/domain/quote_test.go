@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
+	apperr "github.com/foliveiracamara/delivery-manager-api/internal/shared/apperror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuote_CheckHire(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	pkg, err := NewPackage("Test Product", "SP", 2.0, DestinationRegionSoutheast)
+	require.NoError(t, err)
+
+	quote := NewQuote(pkg.ID, vo.NewShippingQuote("Test Carrier", "test-carrier", vo.NewMoney(2550), 5), 15*time.Minute, now)
+	assert.NotEmpty(t, quote.ID)
+	assert.Equal(t, now.Add(15*time.Minute), quote.ExpiresAt)
+
+	other, err := NewPackage("Other Product", "SP", 2.0, DestinationRegionSoutheast)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		pkg  *Package
+		now  time.Time
+		code int
+	}{
+		{name: "valid quote", pkg: pkg, now: now.Add(14 * time.Minute)},
+		{name: "expires exactly at the end of the window", pkg: pkg, now: now.Add(15 * time.Minute), code: http.StatusGone},
+		{name: "expired quote", pkg: pkg, now: now.Add(time.Hour), code: http.StatusGone},
+		{name: "quote of another package", pkg: other, now: now, code: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := quote.CheckHire(tt.pkg, tt.now)
+
+			if tt.code == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			var appErr *apperr.AppErr
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, tt.code, appErr.Code)
+		})
+	}
+}
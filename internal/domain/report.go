@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// LateDelivery resume um pacote atrasado com os dados do relatório, para que o relatório
+// não precise guardar os pacotes inteiros
+type LateDelivery struct {
+	PackageID             string
+	DestinationState      string
+	Status                PackageStatus
+	EstimatedDays         int
+	EstimatedDeliveryDate time.Time
+	OverdueAt             time.Time
+	LateDays              int
+}
+
+// LateDeliveryGroup reúne os pacotes atrasados de uma transportadora em uma região
+type LateDeliveryGroup struct {
+	CarrierID   string
+	CarrierName string
+	Region      DestinationRegion
+	Deliveries  []LateDelivery
+	// TotalLateDays soma os dias de atraso dos pacotes do grupo
+	TotalLateDays int
+}
+
+// LateDeliveryReport agrupa os pacotes atrasados por transportadora e região à medida que
+// são adicionados, para que a listagem seja percorrida página a página
+type LateDeliveryReport struct {
+	now    time.Time
+	index  map[lateDeliveryKey]int
+	groups []LateDeliveryGroup
+}
+
+type lateDeliveryKey struct {
+	carrierID string
+	region    DestinationRegion
+}
+
+// NewLateDeliveryReport cria o relatório contando os dias de atraso até now
+func NewLateDeliveryReport(now time.Time) *LateDeliveryReport {
+	return &LateDeliveryReport{
+		now:    now,
+		index:  map[lateDeliveryKey]int{},
+		groups: []LateDeliveryGroup{},
+	}
+}
+
+// Add inclui o pacote no grupo da transportadora e da região; pacotes sem frete são ignorados
+func (r *LateDeliveryReport) Add(pkg *Package) {
+	if pkg.Shipping == nil {
+		return
+	}
+
+	key := lateDeliveryKey{pkg.Shipping.CarrierID, pkg.DestinationRegion}
+	i, ok := r.index[key]
+	if !ok {
+		i = len(r.groups)
+		r.index[key] = i
+		r.groups = append(r.groups, LateDeliveryGroup{
+			CarrierID:   pkg.Shipping.CarrierID,
+			CarrierName: pkg.Shipping.CarrierName,
+			Region:      pkg.DestinationRegion,
+		})
+	}
+
+	delivery := LateDelivery{
+		PackageID:             pkg.ID,
+		DestinationState:      pkg.DestinationState,
+		Status:                pkg.Status,
+		EstimatedDays:         pkg.Shipping.EstimatedDays,
+		EstimatedDeliveryDate: pkg.Shipping.EstimatedDeliveryDate,
+		LateDays:              pkg.LateDays(r.now),
+	}
+	if pkg.OverdueAt != nil {
+		delivery.OverdueAt = *pkg.OverdueAt
+	}
+
+	r.groups[i].Deliveries = append(r.groups[i].Deliveries, delivery)
+	r.groups[i].TotalLateDays += delivery.LateDays
+}
+
+// Groups retorna os grupos ordenados pelo ID da transportadora e pela região; dentro do
+// grupo, os mais atrasados primeiro
+func (r *LateDeliveryReport) Groups() []LateDeliveryGroup {
+	slices.SortFunc(r.groups, func(a, b LateDeliveryGroup) int {
+		return cmp.Or(cmp.Compare(a.CarrierID, b.CarrierID), cmp.Compare(a.Region, b.Region))
+	})
+	for _, group := range r.groups {
+		slices.SortStableFunc(group.Deliveries, func(a, b LateDelivery) int {
+			return cmp.Or(cmp.Compare(b.LateDays, a.LateDays), cmp.Compare(a.PackageID, b.PackageID))
+		})
+	}
+
+	return r.groups
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLateDeliveryReport(t *testing.T) {
+	now := date("2025-01-24").Add(time.Hour)
+	late := func(id, carrierID string, region DestinationRegion, promised string) *Package {
+		shipping := vo.NewShippingQuote("Carrier "+carrierID, carrierID, vo.NewMoney(1000), 3)
+		shipping.EstimatedDeliveryDate = date(promised)
+		return &Package{ID: id, DestinationRegion: region, Status: StatusShipped, Shipping: &shipping}
+	}
+
+	report := NewLateDeliveryReport(now)
+	for _, pkg := range []*Package{
+		late("a", "nebulix", DestinationRegionSouth, "2025-01-22"),      // 2 dias
+		late("b", "moventra", DestinationRegionSoutheast, "2025-01-20"), // 4 dias
+		late("c", "nebulix", DestinationRegionSouth, "2025-01-17"),      // 7 dias
+		late("d", "nebulix", DestinationRegionSoutheast, "2025-01-23"),  // 1 dia
+		{ID: "e", DestinationRegion: DestinationRegionSouth, Status: StatusCreated},
+	} {
+		report.Add(pkg)
+	}
+
+	groups := report.Groups()
+	require.Len(t, groups, 3)
+
+	assert.Equal(t, "moventra", groups[0].CarrierID)
+	assert.Equal(t, "Carrier moventra", groups[0].CarrierName)
+	assert.Equal(t, 4, groups[0].TotalLateDays)
+
+	assert.Equal(t, "nebulix", groups[1].CarrierID)
+	assert.Equal(t, DestinationRegionSoutheast, groups[1].Region)
+	assert.Equal(t, 1, groups[1].TotalLateDays)
+
+	assert.Equal(t, "nebulix", groups[2].CarrierID)
+	assert.Equal(t, DestinationRegionSouth, groups[2].Region)
+	assert.Equal(t, 9, groups[2].TotalLateDays)
+	require.Len(t, groups[2].Deliveries, 2)
+	assert.Equal(t, "c", groups[2].Deliveries[0].PackageID)
+	assert.Equal(t, 7, groups[2].Deliveries[0].LateDays)
+	assert.Equal(t, "a", groups[2].Deliveries[1].PackageID)
+
+	t.Run("should return no groups without late packages", func(t *testing.T) {
+		assert.Empty(t, NewLateDeliveryReport(now).Groups())
+	})
+}
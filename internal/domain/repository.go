@@ -1,6 +1,41 @@
 package domain
 
+import (
+	"time"
+
+	apperr "github.com/foliveiracamara/delivery-manager-api/internal/shared/apperror"
+)
+
+// PackageRepository persiste o agregado de pacote com controle otimista de concorrência:
+// Save só aceita o pacote se sua versão for a mesma armazenada e, em caso de sucesso,
+// incrementa pkg.Version. Pacotes novos são salvos com versão 0.
+//
+// SaveOverdue grava só a marca de atraso, sem alterar a versão: a marca vem da
+// verificação periódica, e um cliente que leu o pacote antes dela não deve receber 412
+// no If-Match. Por isso Save nunca apaga uma marca já gravada.
 type PackageRepository interface {
 	Save(pkg *Package) error
+	SaveOverdue(id string, overdueAt time.Time) error
 	GetByID(id string) (*Package, error)
+	List(query PackageQuery) (*PackagePage, error)
+}
+
+// QuoteRepository guarda as cotações de frete para a contratação pelo ID da cotação.
+// DeleteExpired remove as cotações que expiraram antes de before e retorna quantas
+// foram removidas.
+type QuoteRepository interface {
+	Save(quote *Quote) error
+	GetByID(id string) (*Quote, error)
+	DeleteExpired(before time.Time) (int, error)
+}
+
+// WarehouseRepository consulta os armazéns de onde os pacotes podem ser despachados
+type WarehouseRepository interface {
+	GetAll() []*Warehouse
+	GetByID(id string) (*Warehouse, error)
+}
+
+// NewVersionConflictError indica que o pacote foi alterado por outra requisição desde a leitura
+func NewVersionConflictError(id string) *apperr.AppErr {
+	return apperr.NewConflictError("Package " + id + " was modified by another request, reload it and try again")
 }
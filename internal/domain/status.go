@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+
+	apperr "github.com/foliveiracamara/delivery-manager-api/internal/shared/apperror"
+)
+
+// StatusTransitions define o grafo de transições permitidas entre status.
+// Status sem transições de saída são terminais.
+var StatusTransitions = map[PackageStatus][]PackageStatus{
+	StatusCreated:       {StatusWaitingPickup},
+	StatusWaitingPickup: {StatusCollected},
+	StatusCollected:     {StatusShipped},
+	StatusShipped:       {StatusDelivered, StatusLost},
+	StatusDelivered:     {},
+	StatusLost:          {},
+}
+
+// InvalidStatusTransitionError indica uma transição de status fora do grafo permitido
+type InvalidStatusTransitionError struct {
+	*apperr.AppErr
+	From    PackageStatus   `json:"status_atual"`
+	To      PackageStatus   `json:"status_solicitado"`
+	Allowed []PackageStatus `json:"proximos_status"`
+}
+
+// NewInvalidStatusTransitionError cria o erro listando os próximos status permitidos
+func NewInvalidStatusTransitionError(from, to PackageStatus) *InvalidStatusTransitionError {
+	allowed := AllowedTransitions(from)
+
+	message := "Cannot change status from '" + string(from) + "' to '" + string(to) + "'"
+	if len(allowed) == 0 {
+		message += ": '" + string(from) + "' is a terminal status"
+	} else {
+		names := make([]string, len(allowed))
+		for i, s := range allowed {
+			names[i] = string(s)
+		}
+		message += ". Allowed: " + strings.Join(names, ", ")
+	}
+
+	return &InvalidStatusTransitionError{
+		AppErr:  apperr.NewConflictError(message),
+		From:    from,
+		To:      to,
+		Allowed: allowed,
+	}
+}
+
+// Unwrap expõe o AppErr para o tratamento de erros HTTP
+func (e *InvalidStatusTransitionError) Unwrap() error {
+	return e.AppErr
+}
+
+// AllowedTransitions retorna os próximos status permitidos a partir de um status
+func AllowedTransitions(from PackageStatus) []PackageStatus {
+	return slices.Clone(StatusTransitions[from])
+}
+
+// CanTransition verifica se a transição entre dois status é permitida
+func CanTransition(from, to PackageStatus) bool {
+	return slices.Contains(StatusTransitions[from], to)
+}
+
+// IsTerminalStatus verifica se o status não permite novas transições
+func IsTerminalStatus(status PackageStatus) bool {
+	return IsValidStatus(status) && len(StatusTransitions[status]) == 0
+}
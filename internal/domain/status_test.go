@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	apperr "github.com/foliveiracamara/delivery-manager-api/internal/shared/apperror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllowedTransitions(t *testing.T) {
+	tests := []struct {
+		from     PackageStatus
+		expected []PackageStatus
+	}{
+		{StatusCreated, []PackageStatus{StatusWaitingPickup}},
+		{StatusWaitingPickup, []PackageStatus{StatusCollected}},
+		{StatusCollected, []PackageStatus{StatusShipped}},
+		{StatusShipped, []PackageStatus{StatusDelivered, StatusLost}},
+		{StatusDelivered, []PackageStatus{}},
+		{StatusLost, []PackageStatus{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from), func(t *testing.T) {
+			assert.Equal(t, tt.expected, AllowedTransitions(tt.from))
+		})
+	}
+
+	t.Run("should not expose the transition graph for mutation", func(t *testing.T) {
+		allowed := AllowedTransitions(StatusShipped)
+		allowed[0] = StatusCreated
+
+		assert.Equal(t, StatusDelivered, StatusTransitions[StatusShipped][0])
+	})
+}
+
+func TestCanTransition(t *testing.T) {
+	assert.True(t, CanTransition(StatusCreated, StatusWaitingPickup))
+	assert.True(t, CanTransition(StatusShipped, StatusLost))
+	assert.False(t, CanTransition(StatusCreated, StatusDelivered))
+	assert.False(t, CanTransition(StatusDelivered, StatusCreated))
+	assert.False(t, CanTransition("invalid", StatusCreated))
+}
+
+func TestIsTerminalStatus(t *testing.T) {
+	assert.True(t, IsTerminalStatus(StatusDelivered))
+	assert.True(t, IsTerminalStatus(StatusLost))
+	assert.False(t, IsTerminalStatus(StatusShipped))
+	assert.False(t, IsTerminalStatus("invalid"))
+}
+
+func TestInvalidStatusTransitionError(t *testing.T) {
+	err := NewInvalidStatusTransitionError(StatusCollected, StatusDelivered)
+
+	assert.Equal(t, StatusCollected, err.From)
+	assert.Equal(t, StatusDelivered, err.To)
+	assert.Equal(t, []PackageStatus{StatusShipped}, err.Allowed)
+	assert.Equal(t, "Cannot change status from 'coletado' to 'entregue'. Allowed: enviado", err.Error())
+
+	var appErr *apperr.AppErr
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, http.StatusConflict, appErr.Code)
+}
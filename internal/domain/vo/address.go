@@ -0,0 +1,50 @@
+package vo
+
+import (
+	"errors"
+	"strings"
+)
+
+// Address representa o endereço de entrega ou de coleta. O complemento é opcional; o
+// número aceita "S/N" para endereços sem número.
+type Address struct {
+	Street       string `json:"logradouro"`
+	Number       string `json:"numero"`
+	Complement   string `json:"complemento,omitempty"`
+	Neighborhood string `json:"bairro"`
+	City         string `json:"cidade"`
+	CEP          CEP    `json:"cep"`
+}
+
+// NewAddress cria o endereço, removendo os espaços nas pontas de cada campo
+func NewAddress(street, number, complement, neighborhood, city string, cep CEP) (Address, error) {
+	address := Address{
+		Street:       strings.TrimSpace(street),
+		Number:       strings.TrimSpace(number),
+		Complement:   strings.TrimSpace(complement),
+		Neighborhood: strings.TrimSpace(neighborhood),
+		City:         strings.TrimSpace(city),
+		CEP:          cep,
+	}
+	if err := address.Validate(); err != nil {
+		return Address{}, err
+	}
+	return address, nil
+}
+
+// Validate verifica se os campos obrigatórios do endereço foram informados
+func (a Address) Validate() error {
+	switch {
+	case a.Street == "":
+		return errors.New("address street is required")
+	case a.Number == "":
+		return errors.New("address number is required (use S/N when there is none)")
+	case a.Neighborhood == "":
+		return errors.New("address neighborhood is required")
+	case a.City == "":
+		return errors.New("address city is required")
+	case a.CEP.IsZero():
+		return errors.New("address CEP is required")
+	}
+	return nil
+}
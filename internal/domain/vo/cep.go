@@ -0,0 +1,71 @@
+package vo
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var cepPattern = regexp.MustCompile(`^[0-9]{5}-?[0-9]{3}$`)
+
+// CEP representa um código postal com os oito dígitos, sem hífen. O valor vazio indica
+// que o CEP não foi informado. Como todos têm o mesmo tamanho, CEPs podem ser comparados
+// como texto para verificar faixas.
+type CEP string
+
+// ParseCEP aceita o CEP com ou sem hífen ("01310-100" ou "01310100")
+func ParseCEP(value string) (CEP, error) {
+	value = strings.TrimSpace(value)
+	if !cepPattern.MatchString(value) {
+		return "", fmt.Errorf("invalid CEP: %q (expected 00000-000)", value)
+	}
+	return CEP(strings.Replace(value, "-", "", 1)), nil
+}
+
+// IsZero verifica se o CEP não foi informado
+func (c CEP) IsZero() bool {
+	return c == ""
+}
+
+// String formata o CEP com hífen
+func (c CEP) String() string {
+	if len(c) != 8 {
+		return string(c)
+	}
+	return string(c[:5]) + "-" + string(c[5:])
+}
+
+// Masked mantém os cinco primeiros dígitos do CEP, que indicam a região, e oculta o
+// sufixo que localiza a rua (80010-***)
+func (c CEP) Masked() string {
+	if len(c) != 8 {
+		return string(c)
+	}
+	return string(c[:5]) + "-***"
+}
+
+// Between verifica se o CEP está na faixa, incluindo as pontas
+func (c CEP) Between(from, to CEP) bool {
+	return !c.IsZero() && c >= from && c <= to
+}
+
+// MarshalJSON serializa o CEP com hífen
+func (c CEP) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + c.String() + `"`), nil
+}
+
+// UnmarshalJSON aceita o CEP com ou sem hífen
+func (c *CEP) UnmarshalJSON(data []byte) error {
+	value := strings.Trim(string(data), `"`)
+	if value == "null" || value == "" {
+		*c = ""
+		return nil
+	}
+
+	parsed, err := ParseCEP(value)
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
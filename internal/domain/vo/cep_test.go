@@ -0,0 +1,68 @@
+package vo
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCEP(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected CEP
+		valid    bool
+	}{
+		{value: "01310-100", expected: "01310100", valid: true},
+		{value: "01310100", expected: "01310100", valid: true},
+		{value: " 80010-000 ", expected: "80010000", valid: true},
+		{value: "0131-0100", valid: false},
+		{value: "1310-100", valid: false},
+		{value: "01310-10a", valid: false},
+		{value: "", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			cep, err := ParseCEP(tt.value)
+			if !tt.valid {
+				assert.ErrorContains(t, err, "invalid CEP")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, cep)
+		})
+	}
+}
+
+func TestCEP_Between(t *testing.T) {
+	assert.True(t, CEP("01310100").Between("01000000", "05999999"))
+	assert.True(t, CEP("01000000").Between("01000000", "05999999"))
+	assert.False(t, CEP("06000000").Between("01000000", "05999999"))
+	assert.False(t, CEP("").Between("", "99999999"))
+}
+
+func TestCEP_Masked(t *testing.T) {
+	assert.Equal(t, "80010-***", CEP("80010000").Masked())
+}
+
+func TestCEP_JSON(t *testing.T) {
+	t.Run("should accept the CEP with or without hyphen", func(t *testing.T) {
+		var req struct {
+			CEP CEP `json:"cep"`
+		}
+
+		require.NoError(t, json.Unmarshal([]byte(`{"cep": "69005010"}`), &req))
+		assert.Equal(t, CEP("69005010"), req.CEP)
+
+		assert.ErrorContains(t, json.Unmarshal([]byte(`{"cep": "69.005-010"}`), &req), `invalid CEP: "69.005-010"`)
+	})
+
+	t.Run("should write the CEP with hyphen", func(t *testing.T) {
+		data, err := json.Marshal(CEP("69005010"))
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `"69005-010"`, string(data))
+	})
+}
@@ -0,0 +1,70 @@
+package vo
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// Contact representa o destinatário ou o remetente de um pacote: quem recebe ou envia,
+// com o documento exigido pelas transportadoras na declaração de conteúdo e o endereço.
+// O telefone é opcional.
+type Contact struct {
+	Name     string   `json:"nome"`
+	Document Document `json:"documento"`
+	Phone    Phone    `json:"telefone,omitempty"`
+	Address  Address  `json:"endereco"`
+}
+
+// NewContact cria o contato com o endereço já validado por NewAddress
+func NewContact(name string, document Document, phone Phone, address Address) (Contact, error) {
+	contact := Contact{
+		Name:     strings.TrimSpace(name),
+		Document: document,
+		Phone:    phone,
+		Address:  address,
+	}
+	if err := contact.Validate(); err != nil {
+		return Contact{}, err
+	}
+	return contact, nil
+}
+
+// Validate verifica se o nome, o documento e o endereço foram informados
+func (c Contact) Validate() error {
+	if c.Name == "" {
+		return errors.New("contact name is required")
+	}
+	if c.Document.IsZero() {
+		return errors.New("contact document (CPF or CNPJ) is required")
+	}
+	return c.Address.Validate()
+}
+
+// IsPerson indica se o contato é uma pessoa física, cujos dados pessoais são protegidos
+// pela LGPD. Os dados de pessoas jurídicas são públicos.
+func (c Contact) IsPerson() bool {
+	return c.Document.Kind() == DocumentCPF
+}
+
+// nameParticles são as partículas dos sobrenomes, omitidas no nome mascarado
+var nameParticles = []string{"da", "das", "de", "do", "dos", "e"}
+
+// MaskedName mantém o primeiro nome e as iniciais dos sobrenomes (Maria da Silva Souza
+// fica Maria S. S.)
+func (c Contact) MaskedName() string {
+	names := strings.Fields(c.Name)
+	if len(names) == 0 {
+		return ""
+	}
+
+	masked := names[0]
+	for _, name := range names[1:] {
+		if slices.Contains(nameParticles, strings.ToLower(name)) {
+			continue
+		}
+		initial := []rune(name)[0]
+		masked += " " + string(initial) + "."
+	}
+	return masked
+}
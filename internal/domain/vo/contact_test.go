@@ -0,0 +1,85 @@
+package vo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAddress(t *testing.T) {
+	t.Run("should trim the fields", func(t *testing.T) {
+		address, err := NewAddress(" Rua XV de Novembro ", "1000", " ", "Centro", "Curitiba", "80010000")
+		require.NoError(t, err)
+		assert.Equal(t, "Rua XV de Novembro", address.Street)
+		assert.Empty(t, address.Complement)
+	})
+
+	tests := []struct {
+		name    string
+		address Address
+		err     string
+	}{
+		{name: "no street", address: Address{Number: "1", Neighborhood: "Centro", City: "Curitiba", CEP: "80010000"}, err: "street is required"},
+		{name: "no number", address: Address{Street: "Rua A", Neighborhood: "Centro", City: "Curitiba", CEP: "80010000"}, err: "number is required"},
+		{name: "no neighborhood", address: Address{Street: "Rua A", Number: "S/N", City: "Curitiba", CEP: "80010000"}, err: "neighborhood is required"},
+		{name: "no city", address: Address{Street: "Rua A", Number: "S/N", Neighborhood: "Centro", CEP: "80010000"}, err: "city is required"},
+		{name: "no CEP", address: Address{Street: "Rua A", Number: "S/N", Neighborhood: "Centro", City: "Curitiba"}, err: "CEP is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.address
+			_, err := NewAddress(a.Street, a.Number, a.Complement, a.Neighborhood, a.City, a.CEP)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestNewContact(t *testing.T) {
+	address := Address{Street: "Rua XV de Novembro", Number: "1000", Neighborhood: "Centro", City: "Curitiba", CEP: "80010000"}
+
+	t.Run("should create the contact without phone", func(t *testing.T) {
+		contact, err := NewContact(" Maria da Silva ", "52998224725", "", address)
+		require.NoError(t, err)
+		assert.Equal(t, "Maria da Silva", contact.Name)
+		assert.True(t, contact.IsPerson())
+	})
+
+	t.Run("should require the name and the document", func(t *testing.T) {
+		_, err := NewContact(" ", "52998224725", "", address)
+		assert.ErrorContains(t, err, "name is required")
+
+		_, err = NewContact("Maria da Silva", "", "", address)
+		assert.ErrorContains(t, err, "document (CPF or CNPJ) is required")
+	})
+
+	t.Run("should validate the address", func(t *testing.T) {
+		_, err := NewContact("Maria da Silva", "52998224725", "", Address{})
+		assert.ErrorContains(t, err, "street is required")
+	})
+
+	t.Run("should not treat companies as persons", func(t *testing.T) {
+		contact, err := NewContact("Loja Exemplo Ltda", "11222333000181", "", address)
+		require.NoError(t, err)
+		assert.False(t, contact.IsPerson())
+	})
+}
+
+func TestContact_MaskedName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{name: "Maria da Silva Souza", expected: "Maria S. S."},
+		{name: "João", expected: "João"},
+		{name: "Ana  Élis dos Santos", expected: "Ana É. S."},
+		{name: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Contact{Name: tt.name}.MaskedName())
+		})
+	}
+}
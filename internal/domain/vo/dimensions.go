@@ -0,0 +1,49 @@
+package vo
+
+import "math"
+
+// Dimensions representa as medidas da embalagem em centímetros. O valor zero indica
+// que as medidas não foram informadas.
+type Dimensions struct {
+	LengthCm float64
+	WidthCm  float64
+	HeightCm float64
+}
+
+// NewDimensions cria as medidas da embalagem
+func NewDimensions(lengthCm, widthCm, heightCm float64) Dimensions {
+	return Dimensions{
+		LengthCm: lengthCm,
+		WidthCm:  widthCm,
+		HeightCm: heightCm,
+	}
+}
+
+// IsZero verifica se as medidas não foram informadas
+func (d Dimensions) IsZero() bool {
+	return d == Dimensions{}
+}
+
+// IsValid aceita medidas não informadas ou com as três medidas positivas
+func (d Dimensions) IsValid() bool {
+	return d.IsZero() || (d.LengthCm > 0 && d.WidthCm > 0 && d.HeightCm > 0)
+}
+
+// VolumeM3 retorna o volume da embalagem em metros cúbicos
+func (d Dimensions) VolumeM3() float64 {
+	return d.LengthCm * d.WidthCm * d.HeightCm / 1_000_000
+}
+
+// CubicWeightKg calcula o peso cúbico com o fator de cubagem em kg/m³
+func (d Dimensions) CubicWeightKg(cubingFactor float64) float64 {
+	return d.VolumeM3() * cubingFactor
+}
+
+// BillableWeightKg retorna o peso cobrado: o maior entre o peso real e o peso cúbico.
+// Sem fator de cubagem ou sem medidas, vale o peso real.
+func BillableWeightKg(weightKg float64, dimensions Dimensions, cubingFactor float64) float64 {
+	if cubingFactor <= 0 {
+		return weightKg
+	}
+	return math.Max(weightKg, dimensions.CubicWeightKg(cubingFactor))
+}
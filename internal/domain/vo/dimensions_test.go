@@ -0,0 +1,50 @@
+package vo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDimensions_IsValid(t *testing.T) {
+	tests := []struct {
+		name       string
+		dimensions Dimensions
+		expected   bool
+	}{
+		{name: "not informed", dimensions: Dimensions{}, expected: true},
+		{name: "all positive", dimensions: NewDimensions(40, 30, 20), expected: true},
+		{name: "missing height", dimensions: NewDimensions(40, 30, 0), expected: false},
+		{name: "negative length", dimensions: NewDimensions(-40, 30, 20), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.dimensions.IsValid())
+		})
+	}
+}
+
+func TestBillableWeightKg(t *testing.T) {
+	// Poltrona: 100 x 80 x 90 cm = 0,72 m³
+	armchair := NewDimensions(100, 80, 90)
+
+	tests := []struct {
+		name         string
+		weightKg     float64
+		dimensions   Dimensions
+		cubingFactor float64
+		expected     float64
+	}{
+		{name: "cubic weight above actual weight", weightKg: 15, dimensions: armchair, cubingFactor: 300, expected: 216},
+		{name: "actual weight above cubic weight", weightKg: 250, dimensions: armchair, cubingFactor: 300, expected: 250},
+		{name: "carrier without cubing factor", weightKg: 15, dimensions: armchair, cubingFactor: 0, expected: 15},
+		{name: "dimensions not informed", weightKg: 15, dimensions: Dimensions{}, cubingFactor: 300, expected: 15},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, BillableWeightKg(tt.weightKg, tt.dimensions, tt.cubingFactor), 1e-9)
+		})
+	}
+}
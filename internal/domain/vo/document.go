@@ -0,0 +1,139 @@
+package vo
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DocumentKind identifica o cadastro do documento: CPF para pessoas físicas e CNPJ para
+// pessoas jurídicas
+type DocumentKind string
+
+const (
+	DocumentCPF  DocumentKind = "cpf"
+	DocumentCNPJ DocumentKind = "cnpj"
+)
+
+var (
+	cpfPattern  = regexp.MustCompile(`^[0-9]{11}$`)
+	cnpjPattern = regexp.MustCompile(`^[0-9A-Z]{12}[0-9]{2}$`)
+)
+
+// Document representa um CPF (11 dígitos) ou um CNPJ (14 caracteres), sem pontuação. O
+// CNPJ aceita letras nas 12 primeiras posições, no formato alfanumérico da Receita. O
+// valor vazio indica que o documento não foi informado.
+type Document string
+
+// ParseDocument aceita o CPF ou o CNPJ com ou sem pontuação ("529.982.247-25" ou
+// "52998224725") e confere os dígitos verificadores
+func ParseDocument(value string) (Document, error) {
+	digits := strings.ToUpper(strings.NewReplacer(".", "", "-", "", "/", "", " ", "").Replace(value))
+
+	switch {
+	case cpfPattern.MatchString(digits):
+		if !validCPF(digits) {
+			return "", fmt.Errorf("invalid CPF: %q (check digits do not match)", value)
+		}
+	case cnpjPattern.MatchString(digits):
+		if !validCNPJ(digits) {
+			return "", fmt.Errorf("invalid CNPJ: %q (check digits do not match)", value)
+		}
+	default:
+		return "", fmt.Errorf("invalid document: %q (expected a CPF or a CNPJ)", value)
+	}
+
+	return Document(digits), nil
+}
+
+// IsZero verifica se o documento não foi informado
+func (d Document) IsZero() bool {
+	return d == ""
+}
+
+// Kind retorna se o documento é um CPF ou um CNPJ
+func (d Document) Kind() DocumentKind {
+	if len(d) == 11 {
+		return DocumentCPF
+	}
+	return DocumentCNPJ
+}
+
+// String formata o documento com a pontuação do CPF (000.000.000-00) ou do CNPJ
+// (00.000.000/0000-00)
+func (d Document) String() string {
+	switch len(d) {
+	case 11:
+		return string(d[:3]) + "." + string(d[3:6]) + "." + string(d[6:9]) + "-" + string(d[9:])
+	case 14:
+		return string(d[:2]) + "." + string(d[2:5]) + "." + string(d[5:8]) + "/" + string(d[8:12]) + "-" + string(d[12:])
+	default:
+		return string(d)
+	}
+}
+
+// Masked oculta os três primeiros dígitos e os verificadores do CPF (***.982.247-**). O
+// CNPJ é um dado público e não é mascarado.
+func (d Document) Masked() string {
+	if d.Kind() != DocumentCPF {
+		return d.String()
+	}
+	return "***." + string(d[3:6]) + "." + string(d[6:9]) + "-**"
+}
+
+// MarshalJSON serializa o documento com pontuação
+func (d Document) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalJSON aceita o documento com ou sem pontuação
+func (d *Document) UnmarshalJSON(data []byte) error {
+	value := strings.Trim(string(data), `"`)
+	if value == "null" || value == "" {
+		*d = ""
+		return nil
+	}
+
+	parsed, err := ParseDocument(value)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// validCPF confere os dois dígitos verificadores do CPF. Sequências de um só dígito,
+// como 111.111.111-11, passam no cálculo mas não são CPFs válidos.
+func validCPF(digits string) bool {
+	if strings.Count(digits, digits[:1]) == len(digits) {
+		return false
+	}
+
+	return checkDigit(digits[:9], []int{10, 9, 8, 7, 6, 5, 4, 3, 2}) == digits[9] &&
+		checkDigit(digits[:10], []int{11, 10, 9, 8, 7, 6, 5, 4, 3, 2}) == digits[10]
+}
+
+// validCNPJ confere os dois dígitos verificadores do CNPJ. As letras do CNPJ alfanumérico
+// valem o código ASCII menos 48, como os dígitos.
+func validCNPJ(digits string) bool {
+	if strings.Count(digits, digits[:1]) == len(digits) {
+		return false
+	}
+
+	return checkDigit(digits[:12], []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}) == digits[12] &&
+		checkDigit(digits[:13], []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}) == digits[13]
+}
+
+// checkDigit calcula o dígito verificador pelo módulo 11 com os pesos de cada posição
+func checkDigit(digits string, weights []int) byte {
+	sum := 0
+	for i, weight := range weights {
+		sum += int(digits[i]-'0') * weight
+	}
+
+	rest := sum % 11
+	if rest < 2 {
+		return '0'
+	}
+	return byte('0' + 11 - rest)
+}
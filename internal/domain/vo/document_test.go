@@ -0,0 +1,75 @@
+package vo
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDocument(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected Document
+		kind     DocumentKind
+		err      string
+	}{
+		{value: "529.982.247-25", expected: "52998224725", kind: DocumentCPF},
+		{value: "52998224725", expected: "52998224725", kind: DocumentCPF},
+		{value: " 111.444.777-35 ", expected: "11144477735", kind: DocumentCPF},
+		{value: "11.222.333/0001-81", expected: "11222333000181", kind: DocumentCNPJ},
+		{value: "11222333000181", expected: "11222333000181", kind: DocumentCNPJ},
+		{value: "12.abc.345/01de-35", expected: "12ABC34501DE35", kind: DocumentCNPJ},
+		{value: "529.982.247-24", err: "invalid CPF"},
+		{value: "111.111.111-11", err: "invalid CPF"},
+		{value: "11.222.333/0001-80", err: "invalid CNPJ"},
+		{value: "00.000.000/0000-00", err: "invalid CNPJ"},
+		{value: "12.ABC.345/01DE-3A", err: "invalid document"},
+		{value: "5299822472", err: "invalid document"},
+		{value: "", err: "invalid document"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			document, err := ParseDocument(tt.value)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, document)
+			assert.Equal(t, tt.kind, document.Kind())
+		})
+	}
+}
+
+func TestDocument_Format(t *testing.T) {
+	cpf := Document("52998224725")
+	assert.Equal(t, "529.982.247-25", cpf.String())
+	assert.Equal(t, "***.982.247-**", cpf.Masked())
+
+	cnpj := Document("11222333000181")
+	assert.Equal(t, "11.222.333/0001-81", cnpj.String())
+	assert.Equal(t, "11.222.333/0001-81", cnpj.Masked(), "CNPJ is public and should not be masked")
+}
+
+func TestDocument_JSON(t *testing.T) {
+	t.Run("should accept the document with or without punctuation", func(t *testing.T) {
+		var req struct {
+			Document Document `json:"documento"`
+		}
+
+		require.NoError(t, json.Unmarshal([]byte(`{"documento":"52998224725"}`), &req))
+		assert.Equal(t, Document("52998224725"), req.Document)
+
+		data, err := json.Marshal(req)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"documento":"529.982.247-25"}`, string(data))
+	})
+
+	t.Run("should reject a document with wrong check digits", func(t *testing.T) {
+		var document Document
+		assert.ErrorContains(t, json.Unmarshal([]byte(`"529.982.247-24"`), &document), "invalid CPF")
+	})
+}
@@ -0,0 +1,83 @@
+package vo
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+// Item representa uma mercadoria do pacote, com os dados da declaração de conteúdo e da
+// nota fiscal exigidos pelas transportadoras. O SKU e o NCM são opcionais.
+type Item struct {
+	SKU          string  `json:"sku,omitempty"`
+	Description  string  `json:"descricao"`
+	Quantity     int     `json:"quantidade"`
+	UnitWeightKg float64 `json:"peso_unitario_kg"`
+	UnitValue    Money   `json:"valor_unitario"`
+	NCM          NCM     `json:"ncm,omitempty"`
+}
+
+// NewItem cria o item, removendo os espaços nas pontas do SKU e da descrição
+func NewItem(sku, description string, quantity int, unitWeightKg float64, unitValue Money, ncm NCM) (Item, error) {
+	item := Item{
+		SKU:          strings.TrimSpace(sku),
+		Description:  strings.TrimSpace(description),
+		Quantity:     quantity,
+		UnitWeightKg: unitWeightKg,
+		UnitValue:    unitValue,
+		NCM:          ncm,
+	}
+	if err := item.Validate(); err != nil {
+		return Item{}, err
+	}
+	return item, nil
+}
+
+// Validate verifica a descrição e se quantidade, peso e valor unitários são positivos
+func (i Item) Validate() error {
+	switch {
+	case i.Description == "":
+		return errors.New("item description is required")
+	case i.Quantity <= 0:
+		return errors.New("item quantity must be greater than zero")
+	case i.UnitWeightKg <= 0:
+		return errors.New("item unit weight must be greater than zero")
+	case i.UnitValue.Cents() <= 0:
+		return errors.New("item unit declared value must be greater than zero")
+	}
+	return nil
+}
+
+// WeightKg retorna o peso de todas as unidades do item, arredondado ao grama
+func (i Item) WeightKg() float64 {
+	return roundGrams(i.UnitWeightKg * float64(i.Quantity))
+}
+
+// Value retorna o valor declarado de todas as unidades do item
+func (i Item) Value() Money {
+	return i.UnitValue.MultiplyBy(float64(i.Quantity))
+}
+
+// ItemsWeightKg soma o peso dos itens, arredondado ao grama
+func ItemsWeightKg(items []Item) float64 {
+	total := 0.0
+	for _, item := range items {
+		total += item.WeightKg()
+	}
+	return roundGrams(total)
+}
+
+// ItemsValue soma o valor declarado dos itens
+func ItemsValue(items []Item) Money {
+	total := NewMoney(0)
+	for _, item := range items {
+		total = total.Add(item.Value())
+	}
+	return total
+}
+
+// roundGrams arredonda o peso em kg ao grama, descartando os erros de ponto flutuante
+// das somas (0,1 × 3 = 0,30000000000000004)
+func roundGrams(weightKg float64) float64 {
+	return math.Round(weightKg*1000) / 1000
+}
@@ -0,0 +1,81 @@
+package vo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNCM(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected NCM
+		valid    bool
+	}{
+		{value: "8517.13.00", expected: "85171300", valid: true},
+		{value: "85171300", expected: "85171300", valid: true},
+		{value: "8517.1300", expected: "85171300", valid: true},
+		{value: "8517.13.0", valid: false},
+		{value: "8517-13-00", valid: false},
+		{value: "", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			ncm, err := ParseNCM(tt.value)
+			if !tt.valid {
+				assert.ErrorContains(t, err, "invalid NCM")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ncm)
+			assert.Equal(t, "8517.13.00", ncm.String())
+		})
+	}
+}
+
+func TestNewItem(t *testing.T) {
+	t.Run("should create the item", func(t *testing.T) {
+		item, err := NewItem(" CAM-G ", " Camisa azul ", 3, 0.1, NewMoney(8990), "62052000")
+		require.NoError(t, err)
+
+		assert.Equal(t, "CAM-G", item.SKU)
+		assert.Equal(t, "Camisa azul", item.Description)
+		assert.Equal(t, 0.3, item.WeightKg())
+		assert.Equal(t, NewMoney(26970), item.Value())
+	})
+
+	tests := []struct {
+		name        string
+		description string
+		quantity    int
+		weightKg    float64
+		value       Money
+		err         string
+	}{
+		{name: "no description", description: " ", quantity: 1, weightKg: 1, value: NewMoney(100), err: "description is required"},
+		{name: "no quantity", description: "Camisa", weightKg: 1, value: NewMoney(100), err: "quantity must be greater than zero"},
+		{name: "no weight", description: "Camisa", quantity: 1, value: NewMoney(100), err: "unit weight must be greater than zero"},
+		{name: "no value", description: "Camisa", quantity: 1, weightKg: 1, err: "unit declared value must be greater than zero"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewItem("", tt.description, tt.quantity, tt.weightKg, tt.value, "")
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestItemsTotals(t *testing.T) {
+	items := []Item{
+		{Description: "Camisa", Quantity: 3, UnitWeightKg: 0.1, UnitValue: NewMoney(8990)},
+		{Description: "Calça", Quantity: 1, UnitWeightKg: 0.65, UnitValue: NewMoney(15950)},
+	}
+
+	assert.Equal(t, 0.95, ItemsWeightKg(items))
+	assert.Equal(t, NewMoney(42920), ItemsValue(items))
+	assert.Zero(t, ItemsWeightKg(nil))
+	assert.True(t, ItemsValue(nil).IsZero())
+}
@@ -0,0 +1,222 @@
+package vo
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var decimalPattern = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// Currency é o código ISO 4217 da moeda
+type Currency string
+
+const BRL Currency = "BRL"
+
+// DefaultCurrency é a moeda dos valores sem moeda informada
+const DefaultCurrency = BRL
+
+// Money representa um valor monetário em centavos. Frações de centavo são arredondadas
+// para o centavo mais próximo, com a metade arredondada para longe do zero
+// (1,005 vira 1,01), a mesma regra usada na emissão das notas fiscais.
+type Money struct {
+	cents    int64
+	currency Currency
+}
+
+// NewMoney cria um valor em reais a partir dos centavos
+func NewMoney(cents int64) Money {
+	return NewMoneyIn(cents, DefaultCurrency)
+}
+
+// NewMoneyIn cria um valor na moeda informada a partir dos centavos
+func NewMoneyIn(cents int64, currency Currency) Money {
+	// a moeda padrão fica vazia para que o valor zero de Money seja zero real e
+	// valores iguais sejam comparáveis com ==
+	if currency == DefaultCurrency {
+		currency = ""
+	}
+	return Money{cents: cents, currency: currency}
+}
+
+// ParseMoney interpreta um valor decimal com ponto como separador ("12.90"), sem passar
+// por ponto flutuante. Valores cujos centavos não cabem em int64 são rejeitados.
+func ParseMoney(value string) (Money, error) {
+	value = strings.TrimSpace(value)
+	if !decimalPattern.MatchString(value) {
+		return Money{}, fmt.Errorf("invalid money amount: %q", value)
+	}
+
+	amount, _ := new(big.Rat).SetString(value)
+	cents, ok := roundCents(amount.Mul(amount, big.NewRat(100, 1)))
+	if !ok {
+		return Money{}, fmt.Errorf("money amount out of range: %q", value)
+	}
+	return NewMoney(cents), nil
+}
+
+// MoneyFromFloat converte um valor em ponto flutuante, como os recebidos em JSON, pela
+// sua menor representação decimal: 5.9 vira 5,90 e não 5,8999999
+func MoneyFromFloat(value float64) (Money, error) {
+	return ParseMoney(strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+// Cents retorna o valor em centavos
+func (m Money) Cents() int64 {
+	return m.cents
+}
+
+// Currency retorna a moeda do valor
+func (m Money) Currency() Currency {
+	if m.currency == "" {
+		return DefaultCurrency
+	}
+	return m.currency
+}
+
+// IsZero verifica se o valor é zero
+func (m Money) IsZero() bool {
+	return m.cents == 0
+}
+
+// Add soma dois valores na mesma moeda
+func (m Money) Add(other Money) Money {
+	m.mustMatch(other)
+	return NewMoneyIn(m.cents+other.cents, m.Currency())
+}
+
+// MultiplyBy multiplica o valor por uma quantidade, como o preço por kg pelo peso,
+// arredondando o resultado para o centavo
+func (m Money) MultiplyBy(factor float64) Money {
+	quantity, _ := new(big.Rat).SetString(strconv.FormatFloat(factor, 'f', -1, 64))
+	return NewMoneyIn(mustRoundCents(quantity.Mul(quantity, big.NewRat(m.cents, 1))), m.Currency())
+}
+
+// Percent calcula a porcentagem do valor, como o seguro ad valorem sobre o valor
+// declarado, arredondando o resultado para o centavo
+func (m Money) Percent(percent float64) Money {
+	rate, _ := new(big.Rat).SetString(strconv.FormatFloat(percent, 'f', -1, 64))
+	rate.Mul(rate, big.NewRat(m.cents, 100))
+	return NewMoneyIn(mustRoundCents(rate), m.Currency())
+}
+
+// Compare retorna -1, 0 ou +1 conforme o valor seja menor, igual ou maior que o outro
+func (m Money) Compare(other Money) int {
+	m.mustMatch(other)
+	switch {
+	case m.cents < other.cents:
+		return -1
+	case m.cents > other.cents:
+		return 1
+	}
+	return 0
+}
+
+// Max retorna o maior entre os dois valores
+func (m Money) Max(other Money) Money {
+	if m.Compare(other) >= 0 {
+		return m
+	}
+	return other
+}
+
+// Decimal formata o valor com duas casas e ponto decimal, como em "1234.56"
+func (m Money) Decimal() string {
+	cents := m.cents
+	sign := ""
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// Float64 retorna o valor em ponto flutuante, apenas para formatos que não têm tipo
+// decimal; cálculos devem usar os métodos de Money
+func (m Money) Float64() float64 {
+	return float64(m.cents) / 100
+}
+
+// String formata o valor para exibição, como em "R$ 1.234,56"
+func (m Money) String() string {
+	integer, fraction, _ := strings.Cut(m.Decimal(), ".")
+	sign := ""
+	if strings.HasPrefix(integer, "-") {
+		sign, integer = "-", integer[1:]
+	}
+
+	for i := len(integer) - 3; i > 0; i -= 3 {
+		integer = integer[:i] + "." + integer[i:]
+	}
+
+	symbol := string(m.Currency())
+	if m.Currency() == BRL {
+		symbol = "R$"
+	}
+	return sign + symbol + " " + integer + "," + fraction
+}
+
+// MarshalJSON serializa o valor como número com duas casas decimais
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.Decimal()), nil
+}
+
+// UnmarshalJSON aceita número ou texto decimal, arredondando para o centavo
+func (m *Money) UnmarshalJSON(data []byte) error {
+	value := strings.Trim(string(data), `"`)
+	if value == "null" {
+		return nil
+	}
+
+	parsed, err := ParseMoney(value)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// MoneyCents expõe os centavos de um campo Money às regras de validação, para que tags
+// como gte=0 e required_without funcionem. Registre com
+// validate.RegisterCustomTypeFunc(vo.MoneyCents, vo.Money{}).
+func MoneyCents(field reflect.Value) any {
+	if m, ok := field.Interface().(Money); ok {
+		return m.cents
+	}
+	return nil
+}
+
+func (m Money) mustMatch(other Money) {
+	if m.Currency() != other.Currency() {
+		panic(fmt.Sprintf("money: mixing %s and %s", m.Currency(), other.Currency()))
+	}
+}
+
+// roundCents arredonda para o inteiro mais próximo, com a metade para longe do zero,
+// informando false quando o resultado não cabe em int64
+func roundCents(amount *big.Rat) (int64, bool) {
+	num := new(big.Int).Abs(amount.Num())
+	quotient, remainder := new(big.Int).QuoRem(num, amount.Denom(), new(big.Int))
+	if remainder.Mul(remainder, big.NewInt(2)).Cmp(amount.Denom()) >= 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	if amount.Sign() < 0 {
+		quotient.Neg(quotient)
+	}
+	if !quotient.IsInt64() {
+		return 0, false
+	}
+	return quotient.Int64(), true
+}
+
+// mustRoundCents arredonda o resultado de uma operação entre valores válidos, que só sai
+// do intervalo de int64 por erro de programação
+func mustRoundCents(amount *big.Rat) int64 {
+	cents, ok := roundCents(amount)
+	if !ok {
+		panic("money: amount out of range")
+	}
+	return cents
+}
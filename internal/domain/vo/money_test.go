@@ -0,0 +1,161 @@
+package vo
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected Money
+	}{
+		{name: "two decimals", value: "12.90", expected: NewMoney(1290)},
+		{name: "integer", value: "30", expected: NewMoney(3000)},
+		{name: "one decimal", value: "5.9", expected: NewMoney(590)},
+		{name: "half centavo rounds up", value: "1.005", expected: NewMoney(101)},
+		{name: "below half centavo rounds down", value: "1.0049", expected: NewMoney(100)},
+		{name: "negative half centavo rounds away from zero", value: "-1.005", expected: NewMoney(-101)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := ParseMoney(tt.value)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, m)
+		})
+	}
+
+	for _, value := range []string{"", "12,90", "1e3", "1/3", "0x10", "R$ 5", "184467440737095516.16", "-92233720368547758.09"} {
+		t.Run("reject "+value, func(t *testing.T) {
+			_, err := ParseMoney(value)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestMoneyFromFloat(t *testing.T) {
+	tests := []struct {
+		value    float64
+		expected Money
+	}{
+		{value: 5.90 * 3, expected: NewMoney(1770)}, // 17.700000000000003
+		{value: 5.9, expected: NewMoney(590)},
+		{value: 1.005, expected: NewMoney(101)},
+	}
+
+	for _, tt := range tests {
+		m, err := MoneyFromFloat(tt.value)
+
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, m)
+	}
+
+	_, err := MoneyFromFloat(1e300)
+	assert.ErrorContains(t, err, "money amount out of range")
+}
+
+func TestMoney_MultiplyBy(t *testing.T) {
+	tests := []struct {
+		name     string
+		price    Money
+		factor   float64
+		expected Money
+	}{
+		{name: "exact", price: NewMoney(590), factor: 2, expected: NewMoney(1180)},
+		{name: "no float noise", price: NewMoney(590), factor: 0.6, expected: NewMoney(354)},
+		{name: "half centavo rounds up", price: NewMoney(435), factor: 0.3, expected: NewMoney(131)},            // 1,305
+		{name: "below half centavo rounds down", price: NewMoney(590), factor: 0.2499, expected: NewMoney(147)}, // 1,47441
+		{name: "zero", price: NewMoney(590), factor: 0, expected: NewMoney(0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.price.MultiplyBy(tt.factor))
+		})
+	}
+}
+
+func TestMoney_Percent(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    Money
+		percent  float64
+		expected Money
+	}{
+		{name: "exact", value: NewMoney(179990), percent: 0.5, expected: NewMoney(900)}, // 8,9995
+		{name: "no float noise", value: NewMoney(100000), percent: 0.7, expected: NewMoney(700)},
+		{name: "half centavo rounds up", value: NewMoney(17990), percent: 0.25, expected: NewMoney(45)}, // 0,44975
+		{name: "whole value", value: NewMoney(590), percent: 100, expected: NewMoney(590)},
+		{name: "zero", value: NewMoney(590), percent: 0, expected: NewMoney(0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.value.Percent(tt.percent))
+		})
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	assert.Equal(t, NewMoney(2160), NewMoney(1290).Add(NewMoney(870)))
+	assert.Equal(t, NewMoney(1290), NewMoney(1290).Max(NewMoney(990)))
+	assert.Equal(t, NewMoney(1290), NewMoney(990).Max(NewMoney(1290)))
+	assert.Equal(t, -1, NewMoney(990).Compare(NewMoney(1290)))
+	assert.Equal(t, 0, NewMoney(990).Compare(NewMoney(990)))
+	assert.True(t, Money{}.IsZero())
+	assert.Equal(t, Money{}, NewMoney(0))
+	assert.Equal(t, BRL, Money{}.Currency())
+
+	assert.Panics(t, func() { NewMoney(100).Add(NewMoneyIn(100, "USD")) })
+}
+
+func TestMoney_Format(t *testing.T) {
+	tests := []struct {
+		money   Money
+		decimal string
+		display string
+	}{
+		{money: NewMoney(1770), decimal: "17.70", display: "R$ 17,70"},
+		{money: NewMoney(5), decimal: "0.05", display: "R$ 0,05"},
+		{money: NewMoney(123456789), decimal: "1234567.89", display: "R$ 1.234.567,89"},
+		{money: NewMoney(-123456), decimal: "-1234.56", display: "-R$ 1.234,56"},
+		{money: NewMoneyIn(1999, "USD"), decimal: "19.99", display: "USD 19,99"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.decimal, func(t *testing.T) {
+			assert.Equal(t, tt.decimal, tt.money.Decimal())
+			assert.Equal(t, tt.display, tt.money.String())
+		})
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	data, err := json.Marshal(map[string]Money{"preco": NewMoney(1770)})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"preco": 17.70}`, string(data))
+	assert.Contains(t, string(data), "17.70")
+
+	var decoded struct {
+		Number Money `json:"number"`
+		Text   Money `json:"text"`
+		Noise  Money `json:"noise"`
+		Null   Money `json:"null"`
+	}
+	err = json.Unmarshal([]byte(`{"number": 5.9, "text": "12.90", "noise": 17.700000000000003, "null": null}`), &decoded)
+	require.NoError(t, err)
+	assert.Equal(t, NewMoney(590), decoded.Number)
+	assert.Equal(t, NewMoney(1290), decoded.Text)
+	assert.Equal(t, NewMoney(1770), decoded.Noise)
+	assert.True(t, decoded.Null.IsZero())
+
+	assert.Error(t, json.Unmarshal([]byte(`{"number": true}`), &decoded))
+	// 2^64 centavos wrapped to zero and passed gte=0
+	assert.ErrorContains(t, json.Unmarshal([]byte(`{"number": 184467440737095516.16}`), &decoded), "money amount out of range")
+}
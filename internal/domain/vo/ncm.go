@@ -0,0 +1,57 @@
+package vo
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var ncmPattern = regexp.MustCompile(`^[0-9]{4}\.?[0-9]{2}\.?[0-9]{2}$`)
+
+// NCM representa o código da Nomenclatura Comum do Mercosul que classifica a mercadoria
+// na nota fiscal, com os oito dígitos e sem pontos. O valor vazio indica que o código não
+// foi informado.
+type NCM string
+
+// ParseNCM aceita o código com ou sem pontos ("8517.13.00" ou "85171300")
+func ParseNCM(value string) (NCM, error) {
+	value = strings.TrimSpace(value)
+	if !ncmPattern.MatchString(value) {
+		return "", fmt.Errorf("invalid NCM: %q (expected 0000.00.00)", value)
+	}
+	return NCM(strings.ReplaceAll(value, ".", "")), nil
+}
+
+// IsZero verifica se o código não foi informado
+func (n NCM) IsZero() bool {
+	return n == ""
+}
+
+// String formata o código com os pontos da tabela (8517.13.00)
+func (n NCM) String() string {
+	if len(n) != 8 {
+		return string(n)
+	}
+	return string(n[:4]) + "." + string(n[4:6]) + "." + string(n[6:])
+}
+
+// MarshalJSON serializa o código com pontos
+func (n NCM) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + n.String() + `"`), nil
+}
+
+// UnmarshalJSON aceita o código com ou sem pontos
+func (n *NCM) UnmarshalJSON(data []byte) error {
+	value := strings.Trim(string(data), `"`)
+	if value == "null" || value == "" {
+		*n = ""
+		return nil
+	}
+
+	parsed, err := ParseNCM(value)
+	if err != nil {
+		return err
+	}
+	*n = parsed
+	return nil
+}
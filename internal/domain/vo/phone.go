@@ -0,0 +1,70 @@
+package vo
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var phonePattern = regexp.MustCompile(`^[1-9][0-9](9[0-9]{8}|[2-8][0-9]{7})$`)
+
+// Phone representa um telefone brasileiro com DDD, só com os dígitos: 11 para celulares,
+// que começam com 9, e 10 para fixos. O valor vazio indica que o telefone não foi
+// informado.
+type Phone string
+
+// ParsePhone aceita o telefone com ou sem pontuação e com ou sem o código do país
+// ("(41) 99876-5432", "41998765432" ou "+55 41 99876-5432")
+func ParsePhone(value string) (Phone, error) {
+	digits := strings.NewReplacer("(", "", ")", "", "-", "", " ", "", "+", "").Replace(value)
+	if len(digits) > 11 && strings.HasPrefix(digits, "55") {
+		digits = digits[2:]
+	}
+
+	if !phonePattern.MatchString(digits) {
+		return "", fmt.Errorf("invalid phone: %q (expected DDD and number, as (41) 99876-5432)", value)
+	}
+	return Phone(digits), nil
+}
+
+// IsZero verifica se o telefone não foi informado
+func (p Phone) IsZero() bool {
+	return p == ""
+}
+
+// String formata o telefone com DDD entre parênteses e hífen
+func (p Phone) String() string {
+	if len(p) < 10 {
+		return string(p)
+	}
+	return "(" + string(p[:2]) + ") " + string(p[2:len(p)-4]) + "-" + string(p[len(p)-4:])
+}
+
+// Masked mantém o DDD e os quatro últimos dígitos do telefone ((41) *****-5432)
+func (p Phone) Masked() string {
+	if len(p) < 10 {
+		return string(p)
+	}
+	return "(" + string(p[:2]) + ") " + strings.Repeat("*", len(p)-6) + "-" + string(p[len(p)-4:])
+}
+
+// MarshalJSON serializa o telefone formatado
+func (p Phone) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + p.String() + `"`), nil
+}
+
+// UnmarshalJSON aceita o telefone com ou sem pontuação
+func (p *Phone) UnmarshalJSON(data []byte) error {
+	value := strings.Trim(string(data), `"`)
+	if value == "null" || value == "" {
+		*p = ""
+		return nil
+	}
+
+	parsed, err := ParsePhone(value)
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
@@ -0,0 +1,45 @@
+package vo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePhone(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected Phone
+		valid    bool
+	}{
+		{value: "(41) 99876-5432", expected: "41998765432", valid: true},
+		{value: "41998765432", expected: "41998765432", valid: true},
+		{value: "+55 41 99876-5432", expected: "41998765432", valid: true},
+		{value: "(11) 3333-4444", expected: "1133334444", valid: true},
+		{value: "(41) 89876-5432", valid: false},
+		{value: "(01) 99876-5432", valid: false},
+		{value: "(41) 1333-4444", valid: false},
+		{value: "99876-5432", valid: false},
+		{value: "(41) 9987a-5432", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			phone, err := ParsePhone(tt.value)
+			if !tt.valid {
+				assert.ErrorContains(t, err, "invalid phone")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, phone)
+		})
+	}
+}
+
+func TestPhone_Format(t *testing.T) {
+	assert.Equal(t, "(41) 99876-5432", Phone("41998765432").String())
+	assert.Equal(t, "(41) *****-5432", Phone("41998765432").Masked())
+	assert.Equal(t, "(11) 3333-4444", Phone("1133334444").String())
+	assert.Equal(t, "(11) ****-4444", Phone("1133334444").Masked())
+}
@@ -0,0 +1,25 @@
+package vo
+
+// Surcharge é uma taxa adicional cobrada pela transportadora além do frete e do seguro,
+// como a taxa de coleta ou o pedágio
+type Surcharge struct {
+	Name   string
+	Amount Money
+}
+
+// PriceBreakdown detalha o preço de uma cotação: o frete pelo peso cobrado, o seguro ad
+// valorem sobre o valor declarado e as taxas adicionais. O preço da cotação é a soma.
+type PriceBreakdown struct {
+	Freight    Money
+	Insurance  Money
+	Surcharges []Surcharge
+}
+
+// Total soma o frete, o seguro e as taxas adicionais
+func (b PriceBreakdown) Total() Money {
+	total := b.Freight.Add(b.Insurance)
+	for _, surcharge := range b.Surcharges {
+		total = total.Add(surcharge.Amount)
+	}
+	return total
+}
@@ -1,34 +1,73 @@
 package vo
 
-// Shipping representa uma cotação de frete
+import "time"
+
+// Shipping representa uma cotação de frete. EstimatedPrice é o total de Breakdown.
+// EstimatedDeliveryDate só é preenchida na contratação, contando EstimatedDays em dias
+// úteis a partir dela.
 type Shipping struct {
-	CarrierName    string
-	EstimatedPrice float64
-	EstimatedDays  int
-	CarrierID      string
+	CarrierName           string
+	EstimatedPrice        Money
+	EstimatedDays         int
+	CarrierID             string
+	EstimatedDeliveryDate time.Time
+	Breakdown             PriceBreakdown
+}
+
+// QuoteFailure representa uma transportadora que não conseguiu cotar o frete
+type QuoteFailure struct {
+	CarrierID   string
+	CarrierName string
+	Reason      string
 }
 
-// ShippingRequest representa uma requisição de cotação
+// ShippingRequest representa uma requisição de cotação. DestinationCEP fica vazio em
+// pacotes cadastrados só com o estado de destino, os campos de origem em pacotes
+// sem armazém de origem e DeclaredValue em pacotes sem valor declarado.
 type ShippingRequest struct {
-	WeightKg         float64
-	DestinationState string
+	WeightKg          float64
+	Dimensions        Dimensions
+	DestinationState  string
+	DestinationRegion string
+	DestinationCEP    CEP
+	OriginState       string
+	OriginRegion      string
+	OriginCEP         CEP
+	DeclaredValue     Money
+}
+
+// NewShippingQuote cria uma nova cotação de frete, com o preço todo como frete
+func NewShippingQuote(carrierName, carrierID string, estimatedPrice Money, estimatedDays int) Shipping {
+	return NewItemizedShippingQuote(carrierName, carrierID, PriceBreakdown{Freight: estimatedPrice}, estimatedDays)
 }
 
-// NewShippingQuote cria uma nova cotação de frete
-func NewShippingQuote(carrierName, carrierID string, estimatedPrice float64, estimatedDays int) Shipping {
+// NewItemizedShippingQuote cria uma cotação de frete com o preço detalhado
+func NewItemizedShippingQuote(carrierName, carrierID string, breakdown PriceBreakdown, estimatedDays int) Shipping {
 	return Shipping{
 		CarrierName:    carrierName,
-		EstimatedPrice: estimatedPrice,
+		EstimatedPrice: breakdown.Total(),
 		EstimatedDays:  estimatedDays,
 		CarrierID:      carrierID,
+		Breakdown:      breakdown,
+	}
+}
+
+// PriceBreakdown retorna o detalhamento do preço. Cotações anteriores ao detalhamento
+// têm o preço todo como frete.
+func (s Shipping) PriceBreakdown() PriceBreakdown {
+	if s.Breakdown.Total() != s.EstimatedPrice {
+		return PriceBreakdown{Freight: s.EstimatedPrice}
 	}
+	return s.Breakdown
 }
 
 // NewShippingRequest cria uma nova requisição de cotação
-func NewShippingRequest(weightKg float64, destinationState string) ShippingRequest {
+func NewShippingRequest(weightKg float64, dimensions Dimensions, destinationState, destinationRegion string) ShippingRequest {
 	return ShippingRequest{
-		WeightKg:         weightKg,
-		DestinationState: destinationState,
+		WeightKg:          weightKg,
+		Dimensions:        dimensions,
+		DestinationState:  destinationState,
+		DestinationRegion: destinationRegion,
 	}
 }
 
@@ -8,21 +8,43 @@ import (
 
 func TestNewShippingQuote(t *testing.T) {
 	t.Run("should create shipping quote successfully", func(t *testing.T) {
-		shipping := NewShippingQuote("Test Carrier", "test-carrier", 25.50, 5)
+		shipping := NewShippingQuote("Test Carrier", "test-carrier", NewMoney(2550), 5)
 
 		assert.Equal(t, "Test Carrier", shipping.CarrierName)
 		assert.Equal(t, "test-carrier", shipping.CarrierID)
-		assert.Equal(t, 25.50, shipping.EstimatedPrice)
+		assert.Equal(t, NewMoney(2550), shipping.EstimatedPrice)
 		assert.Equal(t, 5, shipping.EstimatedDays)
+		assert.Equal(t, PriceBreakdown{Freight: NewMoney(2550)}, shipping.PriceBreakdown())
+	})
+
+	t.Run("should price an itemized quote by the total", func(t *testing.T) {
+		breakdown := PriceBreakdown{
+			Freight:    NewMoney(1180),
+			Insurance:  NewMoney(900),
+			Surcharges: []Surcharge{{Name: "coleta", Amount: NewMoney(490)}},
+		}
+
+		shipping := NewItemizedShippingQuote("Test Carrier", "test-carrier", breakdown, 4)
+
+		assert.Equal(t, NewMoney(2570), shipping.EstimatedPrice)
+		assert.Equal(t, breakdown, shipping.PriceBreakdown())
+	})
+
+	t.Run("should charge quotes without breakdown as freight", func(t *testing.T) {
+		shipping := Shipping{CarrierID: "test-carrier", EstimatedPrice: NewMoney(2550), EstimatedDays: 5}
+
+		assert.Equal(t, PriceBreakdown{Freight: NewMoney(2550)}, shipping.PriceBreakdown())
 	})
 }
 
 func TestNewShippingRequest(t *testing.T) {
 	t.Run("should create shipping request successfully", func(t *testing.T) {
-		request := NewShippingRequest(2.5, "SP")
+		request := NewShippingRequest(2.5, NewDimensions(40, 30, 20), "SP", "sudeste")
 
 		assert.Equal(t, 2.5, request.WeightKg)
+		assert.Equal(t, NewDimensions(40, 30, 20), request.Dimensions)
 		assert.Equal(t, "SP", request.DestinationState)
+		assert.Equal(t, "sudeste", request.DestinationRegion)
 	})
 }
 
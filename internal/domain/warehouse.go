@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
+	apperr "github.com/foliveiracamara/delivery-manager-api/internal/shared/apperror"
+)
+
+// Warehouse é um centro de distribuição de onde os pacotes são despachados. O pacote
+// guarda uma cópia do armazém de origem, então mudanças no cadastro não alteram os
+// pacotes já criados.
+type Warehouse struct {
+	ID    string `json:"id"`
+	Name  string `json:"nome"`
+	State string `json:"estado"`
+	CEP   vo.CEP `json:"cep"`
+}
+
+// NewWarehouse cria um armazém. O CEP precisa pertencer ao estado pela tabela de faixas
+// dos Correios.
+func NewWarehouse(id, name, state string, cep vo.CEP) (*Warehouse, error) {
+	if id == "" || name == "" {
+		return nil, apperr.NewBadRequestError("Warehouse ID and name are required")
+	}
+	if _, exists := GetRegionFromState(state); !exists {
+		return nil, apperr.NewBadRequestError("Invalid state: " + state)
+	}
+	if err := checkCEPState(cep, state); err != nil {
+		return nil, err
+	}
+
+	return &Warehouse{
+		ID:    id,
+		Name:  name,
+		State: state,
+		CEP:   cep,
+	}, nil
+}
+
+// Region retorna a região do estado do armazém, usada como origem nas tabelas de frete
+func (w Warehouse) Region() DestinationRegion {
+	region, _ := GetRegionFromState(w.State)
+	return region
+}
+
+// checkCEPState verifica se o CEP pertence ao estado
+func checkCEPState(cep vo.CEP, state string) error {
+	cepState, found := GetStateFromCEP(cep)
+	if !found {
+		return apperr.NewBadRequestError("CEP not found in any state: " + cep.String())
+	}
+	if cepState != state {
+		return apperr.NewBadRequestError("CEP " + cep.String() + " belongs to " + cepState + ", not " + state)
+	}
+	return nil
+}
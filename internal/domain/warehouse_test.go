@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWarehouse(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            string
+		state         string
+		cep           vo.CEP
+		expectedError string
+	}{
+		{name: "valid warehouse", id: "curitiba", state: "PR", cep: "81460000"},
+		{name: "missing ID", id: "", state: "PR", cep: "81460000", expectedError: "Warehouse ID and name are required"},
+		{name: "invalid state", id: "curitiba", state: "XX", cep: "81460000", expectedError: "Invalid state: XX"},
+		{name: "CEP in another state", id: "curitiba", state: "PR", cep: "50050000", expectedError: "CEP 50050-000 belongs to PE, not PR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warehouse, err := NewWarehouse(tt.id, "CD Curitiba", tt.state, tt.cep)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				assert.Nil(t, warehouse)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, &Warehouse{ID: tt.id, Name: "CD Curitiba", State: tt.state, CEP: tt.cep}, warehouse)
+			assert.Equal(t, DestinationRegionSouth, warehouse.Region())
+		})
+	}
+}
@@ -6,4 +6,35 @@ func setDefaults() {
 	viper.SetDefault("app.name", "delivery-manager-api")
 	viper.SetDefault("app.environment", "local")
 	viper.SetDefault("server.port", 5000)
+
+	viper.SetDefault("storage.driver", StorageDriverMemory)
+	viper.SetDefault("storage.postgres.dsn", "")
+	viper.SetDefault("storage.postgres.max_open_conns", 10)
+	viper.SetDefault("storage.postgres.max_idle_conns", 5)
+	viper.SetDefault("storage.postgres.conn_max_lifetime", "30m")
+	viper.SetDefault("storage.sqlite.path", "data/delivery-manager.db")
+	viper.SetDefault("storage.sqlite.busy_timeout", "5s")
+
+	viper.SetDefault("carriers.catalog_path", "config/carriers.yaml")
+	viper.SetDefault("carriers.hot_reload", true)
+	viper.SetDefault("carriers.quote_timeout", "5s")
+	viper.SetDefault("carriers.quote_validity", "30m")
+	viper.SetDefault("carriers.quote_retention", "24h")
+	viper.SetDefault("carriers.quote_purge_interval", "1h")
+	viper.SetDefault("carriers.reliability_refresh", "10m")
+	viper.SetDefault("carriers.reliability_ttl", "1h")
+	viper.SetDefault("carriers.http.timeout", "2s")
+	viper.SetDefault("carriers.http.max_retries", 2)
+	viper.SetDefault("carriers.http.retry_backoff", "100ms")
+	viper.SetDefault("carriers.http.breaker_threshold", 5)
+	viper.SetDefault("carriers.http.breaker_cooldown", "30s")
+
+	viper.SetDefault("sla.scan_interval", "5m")
+
+	viper.SetDefault("warehouses", []map[string]any{
+		{"id": "curitiba", "name": "CD Curitiba", "state": "PR", "cep": "81460-000"},
+		{"id": "recife", "name": "CD Recife", "state": "PE", "cep": "50050-000"},
+	})
+
+	viper.SetDefault("auth.privileged_api_keys", []string{})
 }
@@ -1,8 +1,22 @@
 package config
 
+import "time"
+
+const (
+	StorageDriverMemory   = "memory"
+	StorageDriverPostgres = "postgres"
+	StorageDriverSQLite   = "sqlite"
+)
+
 type Config struct {
-	App    App    `mapstructure:"app"`
-	Server Server `mapstructure:"server"`
+	App        App         `mapstructure:"app"`
+	Server     Server      `mapstructure:"server"`
+	Storage    Storage     `mapstructure:"storage"`
+	Carriers   Carriers    `mapstructure:"carriers"`
+	Holidays   Holidays    `mapstructure:"holidays"`
+	SLA        SLA         `mapstructure:"sla"`
+	Warehouses []Warehouse `mapstructure:"warehouses"`
+	Auth       Auth        `mapstructure:"auth"`
 }
 
 type App struct {
@@ -13,3 +27,75 @@ type App struct {
 type Server struct {
 	Port int `mapstructure:"port"`
 }
+
+type Storage struct {
+	Driver   string   `mapstructure:"driver"`
+	Postgres Postgres `mapstructure:"postgres"`
+	SQLite   SQLite   `mapstructure:"sqlite"`
+}
+
+type Postgres struct {
+	DSN             string        `mapstructure:"dsn"`
+	MaxOpenConns    int           `mapstructure:"max_open_conns"`
+	MaxIdleConns    int           `mapstructure:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
+}
+
+type SQLite struct {
+	Path        string        `mapstructure:"path"`
+	BusyTimeout time.Duration `mapstructure:"busy_timeout"`
+}
+
+// Carriers configures the carrier catalog and quotes. Quotes expired for longer than
+// QuoteRetention are purged every QuotePurgeInterval; a zero interval disables the purge.
+// Reliability scores are recomputed every ReliabilityRefresh and ignored once older than
+// ReliabilityTTL.
+type Carriers struct {
+	CatalogPath        string        `mapstructure:"catalog_path"`
+	HotReload          bool          `mapstructure:"hot_reload"`
+	QuoteTimeout       time.Duration `mapstructure:"quote_timeout"`
+	QuoteValidity      time.Duration `mapstructure:"quote_validity"`
+	QuoteRetention     time.Duration `mapstructure:"quote_retention"`
+	QuotePurgeInterval time.Duration `mapstructure:"quote_purge_interval"`
+	ReliabilityRefresh time.Duration `mapstructure:"reliability_refresh"`
+	ReliabilityTTL     time.Duration `mapstructure:"reliability_ttl"`
+	HTTP               CarrierHTTP   `mapstructure:"http"`
+}
+
+type CarrierHTTP struct {
+	Timeout          time.Duration `mapstructure:"timeout"`
+	MaxRetries       int           `mapstructure:"max_retries"`
+	RetryBackoff     time.Duration `mapstructure:"retry_backoff"`
+	BreakerThreshold int           `mapstructure:"breaker_threshold"`
+	BreakerCooldown  time.Duration `mapstructure:"breaker_cooldown"`
+}
+
+// Holidays adds non-working days to the built-in national and state holidays, as MM-DD
+// (every year) or YYYY-MM-DD (a single day)
+type Holidays struct {
+	National []string            `mapstructure:"national"`
+	States   map[string][]string `mapstructure:"states"`
+}
+
+// Warehouse is a distribution center packages are shipped from. New packages refer to it
+// by ID in the origem field, and the region of its state selects the origin rates of
+// the carrier catalog.
+type Warehouse struct {
+	ID    string `mapstructure:"id"`
+	Name  string `mapstructure:"name"`
+	State string `mapstructure:"state"`
+	CEP   string `mapstructure:"cep"`
+}
+
+// SLA configures the job that marks packages past their promised delivery date.
+// A zero ScanInterval disables the job.
+type SLA struct {
+	ScanInterval time.Duration `mapstructure:"scan_interval"`
+}
+
+// Auth lists the API keys of privileged callers. Requests sending one of them in the
+// X-API-Key header may change the carrier catalog and see recipient and sender personal
+// data unmasked.
+type Auth struct {
+	PrivilegedAPIKeys []string `mapstructure:"privileged_api_keys"`
+}
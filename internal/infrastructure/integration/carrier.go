@@ -1,21 +1,262 @@
 package integration
 
 import (
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
 	apperr "github.com/foliveiracamara/delivery-manager-api/internal/shared/apperror"
+	"github.com/go-playground/validator/v10"
 )
 
-// CarrierRegion represents the coverage of a carrier in a region
+// CarrierRegion represents the coverage of a carrier in a region. The freight comes from
+// the weight bands when set, or from PricePerKg, charged on the greater of the actual and
+// the cubic weight (CubingFactor, in kg/m³; 0 disables it) and never below MinimumCharge,
+// which defaults to one kilo on per kg tables.
+// Origins and CEPRanges override the terms for packages shipped from a given region or
+// bound to part of the destination region. On top of the freight come the Surcharges and,
+// for packages with a declared value, the ad valorem insurance.
 type CarrierRegion struct {
-	Region        string  `json:"regiao"`
-	EstimatedDays int     `json:"prazo_estimado_dias"`
-	PricePerKg    float64 `json:"preco_por_kg"`
+	Region           string       `json:"regiao" mapstructure:"regiao" validate:"oneof=norte nordeste centro-oeste sudeste sul"`
+	EstimatedDays    int          `json:"prazo_estimado_dias" mapstructure:"prazo_estimado_dias" validate:"gt=0"`
+	PricePerKg       vo.Money     `json:"preco_por_kg,omitempty" mapstructure:"preco_por_kg" validate:"gte=0"`
+	WeightBands      []WeightBand `json:"faixas_peso,omitempty" mapstructure:"faixas_peso" validate:"dive"`
+	MinimumCharge    vo.Money     `json:"valor_minimo,omitempty" mapstructure:"valor_minimo" validate:"gte=0"`
+	CubingFactor     float64      `json:"fator_cubagem,omitempty" mapstructure:"fator_cubagem" validate:"gte=0"`
+	Origins          []OriginRate `json:"origens,omitempty" mapstructure:"origens" validate:"unique=Origin,dive"`
+	CEPRanges        []CEPRange   `json:"faixas_cep,omitempty" mapstructure:"faixas_cep" validate:"dive"`
+	AdValoremPercent float64      `json:"ad_valorem_percentual,omitempty" mapstructure:"ad_valorem_percentual" validate:"gte=0,lte=100"`
+	MinimumInsurance vo.Money     `json:"seguro_minimo,omitempty" mapstructure:"seguro_minimo" validate:"gte=0"`
+	Surcharges       []Surcharge  `json:"taxas_adicionais,omitempty" mapstructure:"taxas_adicionais" validate:"unique=Name,dive"`
+}
+
+// Surcharge is a fixed fee charged on every package on top of the freight, such as a
+// pickup fee or road tolls
+type Surcharge struct {
+	Name   string   `json:"nome" mapstructure:"nome" validate:"required"`
+	Amount vo.Money `json:"valor" mapstructure:"valor" validate:"gt=0"`
+}
+
+// OriginRate is the coverage of the region for packages shipped from the Origin region.
+// Unserved origins are not covered by the carrier; the others replace the delivery
+// time, price table or minimum charge of the region with the values they set. Packages
+// without an origin, and origins without a rate, use the region values.
+type OriginRate struct {
+	Origin        string       `json:"origem" mapstructure:"origem" validate:"oneof=norte nordeste centro-oeste sudeste sul"`
+	Unserved      bool         `json:"nao_atendida,omitempty" mapstructure:"nao_atendida"`
+	EstimatedDays int          `json:"prazo_estimado_dias,omitempty" mapstructure:"prazo_estimado_dias" validate:"gte=0"`
+	PricePerKg    vo.Money     `json:"preco_por_kg,omitempty" mapstructure:"preco_por_kg" validate:"gte=0"`
+	WeightBands   []WeightBand `json:"faixas_peso,omitempty" mapstructure:"faixas_peso" validate:"dive"`
+	MinimumCharge vo.Money     `json:"valor_minimo,omitempty" mapstructure:"valor_minimo" validate:"gte=0"`
+}
+
+// CEPRange covers the CEPs from From to To, inclusive, inside a region. Ranges are
+// checked in order and the first one containing the destination CEP applies. Unserved
+// ranges are not covered by the carrier; the others replace the delivery time, price
+// table or minimum charge of the region with the values they set. Packages without a
+// CEP, and CEPs outside every range, use the region values.
+type CEPRange struct {
+	Name          string       `json:"nome" mapstructure:"nome" validate:"required"`
+	From          vo.CEP       `json:"cep_inicio" mapstructure:"cep_inicio" validate:"required"`
+	To            vo.CEP       `json:"cep_fim" mapstructure:"cep_fim" validate:"required"`
+	Unserved      bool         `json:"nao_atendida,omitempty" mapstructure:"nao_atendida"`
+	EstimatedDays int          `json:"prazo_estimado_dias,omitempty" mapstructure:"prazo_estimado_dias" validate:"gte=0"`
+	PricePerKg    vo.Money     `json:"preco_por_kg,omitempty" mapstructure:"preco_por_kg" validate:"gte=0"`
+	WeightBands   []WeightBand `json:"faixas_peso,omitempty" mapstructure:"faixas_peso" validate:"dive"`
+	MinimumCharge vo.Money     `json:"valor_minimo,omitempty" mapstructure:"valor_minimo" validate:"gte=0"`
+}
+
+// WeightBand prices the billable weight from the end of the previous band up to UpToKg:
+// FixedPrice plus PricePerKg for every kilo above the start of the band. Bands are sorted
+// by UpToKg and the last one leaves UpToKg at 0 to cover any heavier package.
+type WeightBand struct {
+	UpToKg     float64  `json:"ate_kg,omitempty" mapstructure:"ate_kg" validate:"gte=0"`
+	FixedPrice vo.Money `json:"preco_fixo,omitempty" mapstructure:"preco_fixo" validate:"gte=0"`
+	PricePerKg vo.Money `json:"preco_por_kg,omitempty" mapstructure:"preco_por_kg" validate:"gte=0"`
+}
+
+// Price calculates the price of the billable weight in the region, rounded to the
+// centavo. It reports false when the table has no price for the weight: no band covers
+// it, or the region has neither bands nor a price per kg.
+func (r CarrierRegion) Price(billableKg float64) (vo.Money, bool) {
+	minimum := r.MinimumCharge
+
+	if len(r.WeightBands) == 0 {
+		if r.PricePerKg.Cents() <= 0 {
+			return vo.Money{}, false
+		}
+		if minimum.IsZero() {
+			minimum = r.PricePerKg
+		}
+		return r.PricePerKg.MultiplyBy(billableKg).Max(minimum), true
+	}
+
+	var start float64
+	for _, band := range r.WeightBands {
+		if band.UpToKg == 0 || billableKg <= band.UpToKg {
+			price := band.FixedPrice.Add(band.PricePerKg.MultiplyBy(excessKg(billableKg, start)))
+			return price.Max(minimum), true
+		}
+		start = band.UpToKg
+	}
+	return vo.Money{}, false
+}
+
+// Insurance calculates the ad valorem insurance of the declared value, rounded to the
+// centavo. Packages without a declared value are not insured.
+func (r CarrierRegion) Insurance(declaredValue vo.Money) vo.Money {
+	if declaredValue.IsZero() {
+		return vo.Money{}
+	}
+	return declaredValue.Percent(r.AdValoremPercent).Max(r.MinimumInsurance)
+}
+
+// PriceBreakdown itemizes the freight of the billable weight, the insurance of the
+// declared value and the surcharges of the region, reporting false when the region has
+// no price for the weight
+func (r CarrierRegion) PriceBreakdown(billableKg float64, declaredValue vo.Money) (vo.PriceBreakdown, bool) {
+	freight, priced := r.Price(billableKg)
+	if !priced {
+		return vo.PriceBreakdown{}, false
+	}
+
+	breakdown := vo.PriceBreakdown{
+		Freight:   freight,
+		Insurance: r.Insurance(declaredValue),
+	}
+	for _, surcharge := range r.Surcharges {
+		breakdown.Surcharges = append(breakdown.Surcharges, vo.Surcharge{Name: surcharge.Name, Amount: surcharge.Amount})
+	}
+	return breakdown, true
+}
+
+// ForOrigin returns the coverage that applies to packages shipped from the origin
+// region, reporting false when the origin is unserved
+func (r CarrierRegion) ForOrigin(origin string) (CarrierRegion, bool) {
+	for _, rate := range r.Origins {
+		if origin == "" || rate.Origin != origin {
+			continue
+		}
+		if rate.Unserved {
+			return CarrierRegion{}, false
+		}
+		return r.override(rate.EstimatedDays, rate.PricePerKg, rate.WeightBands, rate.MinimumCharge), true
+	}
+	return r, true
+}
+
+// ForCEP returns the coverage that applies to the destination CEP, reporting false when
+// the CEP falls in an unserved range
+func (r CarrierRegion) ForCEP(cep vo.CEP) (CarrierRegion, bool) {
+	for _, cepRange := range r.CEPRanges {
+		if !cep.Between(cepRange.From, cepRange.To) {
+			continue
+		}
+		if cepRange.Unserved {
+			return CarrierRegion{}, false
+		}
+		return r.override(cepRange.EstimatedDays, cepRange.PricePerKg, cepRange.WeightBands, cepRange.MinimumCharge), true
+	}
+	return r, true
+}
+
+// override replaces the delivery time, price table and minimum charge of the region
+// with the ones that are set. Setting a price per kg or weight bands replaces the
+// whole price table.
+func (r CarrierRegion) override(estimatedDays int, pricePerKg vo.Money, weightBands []WeightBand, minimumCharge vo.Money) CarrierRegion {
+	if estimatedDays > 0 {
+		r.EstimatedDays = estimatedDays
+	}
+	if !pricePerKg.IsZero() || len(weightBands) > 0 {
+		r.PricePerKg, r.WeightBands = pricePerKg, weightBands
+	}
+	if !minimumCharge.IsZero() {
+		r.MinimumCharge = minimumCharge
+	}
+	return r
+}
+
+// excessKg returns the weight above the start of a band rounded to the gram, so float
+// noise in the subtraction (10.3 - 5 = 5.300000000000001) cannot tip the centavo rounding
+func excessKg(billableKg, start float64) float64 {
+	return math.Round(max(billableKg-start, 0)*1000) / 1000
+}
+
+// validateCarrierRegion checks what the field tags cannot: a region needs a price per kg
+// or weight bands, and the bands must be sorted with only the last one open ended
+func validateCarrierRegion(sl validator.StructLevel) {
+	region := sl.Current().Interface().(CarrierRegion)
+
+	if len(region.WeightBands) == 0 {
+		if region.PricePerKg.Cents() <= 0 {
+			sl.ReportError(region.PricePerKg, "PricePerKg", "PricePerKg", "required_without", "WeightBands")
+		}
+		return
+	}
+
+	validateWeightBands(sl, region.WeightBands)
+}
+
+// validateOriginRate checks that the weight bands of the origin follow the rules of the
+// region bands
+func validateOriginRate(sl validator.StructLevel) {
+	validateWeightBands(sl, sl.Current().Interface().(OriginRate).WeightBands)
+}
+
+// validateCEPRange checks that the range is valid and not inverted and that its own
+// weight bands follow the rules of the region bands
+func validateCEPRange(sl validator.StructLevel) {
+	cepRange := sl.Current().Interface().(CEPRange)
+
+	if !isNormalizedCEP(cepRange.From) {
+		sl.ReportError(cepRange.From, "From", "From", "cep", "")
+	}
+	if !isNormalizedCEP(cepRange.To) {
+		sl.ReportError(cepRange.To, "To", "To", "cep", "")
+	}
+	if cepRange.From > cepRange.To {
+		sl.ReportError(cepRange.To, "To", "To", "gtefield", "From")
+	}
+
+	validateWeightBands(sl, cepRange.WeightBands)
+}
+
+// isNormalizedCEP checks that the CEP has the eight digits, without hyphen
+func isNormalizedCEP(cep vo.CEP) bool {
+	parsed, err := vo.ParseCEP(string(cep))
+	return err == nil && parsed == cep
 }
 
-// Carrier represents a carrier in the system
+func validateWeightBands(sl validator.StructLevel, bands []WeightBand) {
+	last := len(bands) - 1
+	var previous float64
+	for i, band := range bands {
+		field := fmt.Sprintf("WeightBands[%d]", i)
+		switch {
+		case band.FixedPrice.Cents() <= 0 && band.PricePerKg.Cents() <= 0:
+			sl.ReportError(band, field, field, "priced", "")
+		case i < last && band.UpToKg <= previous:
+			sl.ReportError(band.UpToKg, field+".UpToKg", field+".UpToKg", "gtfield", "previous band")
+		case i == last && band.UpToKg != 0:
+			sl.ReportError(band.UpToKg, field+".UpToKg", field+".UpToKg", "open_ended", "")
+		}
+		previous = band.UpToKg
+	}
+}
+
+// Carrier represents a carrier in the system. Inactive carriers are kept in the
+// catalog but are neither quoted nor hired. Carriers with a QuoteURL are quoted by
+// their own API; the others by the price table of their regions. Packages heavier than
+// MaxWeightKg are not accepted; 0 means no limit.
 type Carrier struct {
-	ID      string          `json:"id"`
-	Name    string          `json:"nome"`
-	Regions []CarrierRegion `json:"regioes"`
+	ID          string          `json:"id" mapstructure:"id" validate:"required"`
+	Name        string          `json:"nome" mapstructure:"nome" validate:"required"`
+	Regions     []CarrierRegion `json:"regioes" mapstructure:"regioes" validate:"min=1,unique=Region,dive"`
+	MaxWeightKg float64         `json:"peso_maximo_kg,omitempty" mapstructure:"peso_maximo_kg" validate:"gte=0"`
+	Inactive    bool            `json:"inativa" mapstructure:"inativa"`
+	QuoteURL    string          `json:"cotacao_url,omitempty" mapstructure:"cotacao_url" validate:"omitempty,http_url"`
 }
 
 // NewCarrier creates a new instance of Carrier
@@ -27,6 +268,31 @@ func NewCarrier(id, name string, regions []CarrierRegion) *Carrier {
 	}
 }
 
+// Clone returns a copy that can be changed without affecting carriers already
+// handed out by the repository
+func (c *Carrier) Clone() *Carrier {
+	clone := *c
+	clone.Regions = slices.Clone(c.Regions)
+	for i := range clone.Regions {
+		clone.Regions[i].WeightBands = slices.Clone(c.Regions[i].WeightBands)
+		clone.Regions[i].Origins = slices.Clone(c.Regions[i].Origins)
+		for j := range clone.Regions[i].Origins {
+			clone.Regions[i].Origins[j].WeightBands = slices.Clone(c.Regions[i].Origins[j].WeightBands)
+		}
+		clone.Regions[i].Surcharges = slices.Clone(c.Regions[i].Surcharges)
+		clone.Regions[i].CEPRanges = slices.Clone(c.Regions[i].CEPRanges)
+		for j := range clone.Regions[i].CEPRanges {
+			clone.Regions[i].CEPRanges[j].WeightBands = slices.Clone(c.Regions[i].CEPRanges[j].WeightBands)
+		}
+	}
+	return &clone
+}
+
+// IsActive checks if the carrier can be quoted and hired
+func (c *Carrier) IsActive() bool {
+	return !c.Inactive
+}
+
 // GetRegionInfo returns the information of a specific region
 func (c *Carrier) GetRegionInfo(region string) (*CarrierRegion, bool) {
 	for _, r := range c.Regions {
@@ -37,21 +303,28 @@ func (c *Carrier) GetRegionInfo(region string) (*CarrierRegion, bool) {
 	return nil, false
 }
 
-// CalculateShipping calculates the cost and delivery time for a region
-func (c *Carrier) CalculateShipping(region string, weightKg float64) (float64, int, bool) {
-	regionInfo, exists := c.GetRegionInfo(region)
+// CalculateShipping calculates the cost and delivery time from the origin region to the
+// destination region and CEP of the request, charging the freight of the greater of the
+// actual and the cubic weight plus the insurance of the declared value and the surcharges.
+// An empty origin or CEP uses the region values; a zero declared value is not insured. It
+// reports false when the carrier does not cover the destination or has no price for the weight.
+func (c *Carrier) CalculateShipping(req vo.ShippingRequest) (vo.PriceBreakdown, int, bool) {
+	regionInfo, exists := c.coverage(req.OriginRegion, req.DestinationRegion, req.DestinationCEP)
 	if !exists {
-		return 0, 0, false
+		return vo.PriceBreakdown{}, 0, false
 	}
 
-	price := regionInfo.PricePerKg * weightKg
-
-	// Se o preço for menor que o preço por kg da região, usar o preço por kg da região como valor mínimo
-	if price < regionInfo.PricePerKg {
-		return regionInfo.PricePerKg, regionInfo.EstimatedDays, true
+	billableKg := vo.BillableWeightKg(req.WeightKg, req.Dimensions, regionInfo.CubingFactor)
+	breakdown, priced := regionInfo.PriceBreakdown(billableKg, req.DeclaredValue)
+	if !priced {
+		return vo.PriceBreakdown{}, 0, false
 	}
+	return breakdown, regionInfo.EstimatedDays, true
+}
 
-	return price, regionInfo.EstimatedDays, true
+// AcceptsWeight checks if the carrier takes packages of the given actual weight
+func (c *Carrier) AcceptsWeight(weightKg float64) bool {
+	return c.MaxWeightKg == 0 || weightKg <= c.MaxWeightKg
 }
 
 // IsAvailableForRegion checks if the carrier serves a region
@@ -60,6 +333,28 @@ func (c *Carrier) IsAvailableForRegion(region string) bool {
 	return exists
 }
 
+// IsAvailableFor checks if the carrier serves the destination CEP in the region from the
+// origin region. An empty origin or CEP is not checked.
+func (c *Carrier) IsAvailableFor(origin, region string, cep vo.CEP) bool {
+	_, exists := c.coverage(origin, region, cep)
+	return exists
+}
+
+// coverage returns the region coverage that applies to the origin and then to the
+// destination CEP, so CEP ranges override the origin rates
+func (c *Carrier) coverage(origin, region string, cep vo.CEP) (CarrierRegion, bool) {
+	regionInfo, exists := c.GetRegionInfo(region)
+	if !exists {
+		return CarrierRegion{}, false
+	}
+
+	fromOrigin, served := regionInfo.ForOrigin(origin)
+	if !served {
+		return CarrierRegion{}, false
+	}
+	return fromOrigin.ForCEP(cep)
+}
+
 // GetName returns the carrier name
 func (c *Carrier) GetName() string {
 	return c.Name
@@ -76,26 +371,57 @@ type CarrierRepository interface {
 	GetByID(id string) (*Carrier, error)
 }
 
-// CarrierRepositoryImpl implements the carrier repository interface with in-memory storage
-// Should simulate a api call to get the carriers
+// WritableCarrierRepository extends CarrierRepository with the catalog administration
+type WritableCarrierRepository interface {
+	CarrierRepository
+	Create(carrier *Carrier) error
+	Update(id string, change func(carrier *Carrier) error) (*Carrier, error)
+	Delete(id string) error
+}
+
+// CarrierRepositoryImpl implements the carrier repositories with in-memory storage,
+// optionally persisted to the catalog file. Carriers are never changed in place: every
+// write builds a new catalog, so carriers already returned to callers stay untouched.
 type CarrierRepositoryImpl struct {
+	mu       sync.RWMutex
+	path     string
 	carriers []*Carrier
 }
 
-// NewCarrierRepository creates a new instance of CarrierRepository
-func NewCarrierRepository() CarrierRepository {
+// NewCarrierRepository creates a new instance of CarrierRepository with the given catalog.
+// Writes are kept in memory only.
+func NewCarrierRepository(carriers []*Carrier) *CarrierRepositoryImpl {
 	return &CarrierRepositoryImpl{
-		carriers: getAvailableCarriers(),
+		carriers: carriers,
 	}
 }
 
-// GetAll returns all available carriers
+// NewFileCarrierRepository loads the catalog file and writes every change back to it
+func NewFileCarrierRepository(path string) (*CarrierRepositoryImpl, error) {
+	carriers, err := LoadCarrierCatalog(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CarrierRepositoryImpl{
+		path:     path,
+		carriers: carriers,
+	}, nil
+}
+
+// GetAll returns all carriers, including inactive ones
 func (r *CarrierRepositoryImpl) GetAll() []*Carrier {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	return r.carriers
 }
 
 // GetByID returns a carrier by its ID
 func (r *CarrierRepositoryImpl) GetByID(id string) (*Carrier, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	for _, carrier := range r.carriers {
 		if carrier.ID == id {
 			return carrier, nil
@@ -104,59 +430,83 @@ func (r *CarrierRepositoryImpl) GetByID(id string) (*Carrier, error) {
 	return nil, apperr.NewNotFoundError("Carrier not found")
 }
 
-// getAvailableCarriers returns the available carriers in the API call
-func getAvailableCarriers() []*Carrier {
-	return []*Carrier{
-		// Nebulix Logística
-		NewCarrier("nebulix", "Nebulix Logística", []CarrierRegion{
-			{
-				Region:        "sul",
-				EstimatedDays: 4,
-				PricePerKg:    5.90,
-			},
-			{
-				Region:        "sudeste",
-				EstimatedDays: 4,
-				PricePerKg:    5.90,
-			},
-		}),
-
-		// RotaFácil Transportes
-		NewCarrier("rotafacil", "RotaFácil Transportes", []CarrierRegion{
-			{
-				Region:        "sul",
-				EstimatedDays: 7,
-				PricePerKg:    4.35,
-			},
-			{
-				Region:        "sudeste",
-				EstimatedDays: 7,
-				PricePerKg:    4.35,
-			},
-			{
-				Region:        "centro-oeste",
-				EstimatedDays: 9,
-				PricePerKg:    6.22,
-			},
-			{
-				Region:        "nordeste",
-				EstimatedDays: 13,
-				PricePerKg:    8.00,
-			},
-		}),
-
-		// Moventra Express
-		NewCarrier("moventra", "Moventra Express", []CarrierRegion{
-			{
-				Region:        "centro-oeste",
-				EstimatedDays: 7,
-				PricePerKg:    7.30,
-			},
-			{
-				Region:        "nordeste",
-				EstimatedDays: 10,
-				PricePerKg:    9.50,
-			},
-		}),
+// Create adds a new carrier to the catalog
+func (r *CarrierRepositoryImpl) Create(carrier *Carrier) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.indexOf(carrier.ID) >= 0 {
+		return apperr.NewConflictError("Carrier already exists: " + carrier.ID)
+	}
+
+	carriers := append(slices.Clone(r.carriers), carrier.Clone())
+	return r.commit(carriers)
+}
+
+// Update applies change to a copy of the carrier and stores the result. The whole
+// read-change-write runs under the repository lock, so concurrent updates are not lost.
+func (r *CarrierRepositoryImpl) Update(id string, change func(carrier *Carrier) error) (*Carrier, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.indexOf(id)
+	if i < 0 {
+		return nil, apperr.NewNotFoundError("Carrier not found")
+	}
+
+	carrier := r.carriers[i].Clone()
+	if err := change(carrier); err != nil {
+		return nil, err
+	}
+	carrier.ID = id
+
+	carriers := slices.Clone(r.carriers)
+	carriers[i] = carrier
+	if err := r.commit(carriers); err != nil {
+		return nil, err
+	}
+
+	return carrier, nil
+}
+
+// Delete removes a carrier from the catalog
+func (r *CarrierRepositoryImpl) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.indexOf(id)
+	if i < 0 {
+		return apperr.NewNotFoundError("Carrier not found")
 	}
+
+	carriers := slices.Delete(slices.Clone(r.carriers), i, i+1)
+	return r.commit(carriers)
+}
+
+// Replace swaps the whole catalog, e.g. when the catalog file is reloaded
+func (r *CarrierRepositoryImpl) Replace(carriers []*Carrier) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.carriers = carriers
+}
+
+func (r *CarrierRepositoryImpl) indexOf(id string) int {
+	return slices.IndexFunc(r.carriers, func(c *Carrier) bool { return c.ID == id })
+}
+
+// commit validates the new catalog, persists it and only then makes it visible
+func (r *CarrierRepositoryImpl) commit(carriers []*Carrier) error {
+	if err := validateCarrierCatalog(carriers); err != nil {
+		return apperr.NewBadRequestError(err.Error())
+	}
+
+	if r.path != "" {
+		if err := writeCarrierCatalog(r.path, carriers); err != nil {
+			return err
+		}
+	}
+
+	r.carriers = carriers
+	return nil
 }
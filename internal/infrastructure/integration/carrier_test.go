@@ -1,9 +1,15 @@
 package integration
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewCarrier(t *testing.T) {
@@ -12,7 +18,7 @@ func TestNewCarrier(t *testing.T) {
 			{
 				Region:        "sudeste",
 				EstimatedDays: 5,
-				PricePerKg:    10.0,
+				PricePerKg:    vo.NewMoney(1000),
 			},
 		}
 
@@ -23,7 +29,7 @@ func TestNewCarrier(t *testing.T) {
 		assert.Len(t, carrier.Regions, 1)
 		assert.Equal(t, "sudeste", carrier.Regions[0].Region)
 		assert.Equal(t, 5, carrier.Regions[0].EstimatedDays)
-		assert.Equal(t, 10.0, carrier.Regions[0].PricePerKg)
+		assert.Equal(t, vo.NewMoney(1000), carrier.Regions[0].PricePerKg)
 	})
 }
 
@@ -35,12 +41,12 @@ func TestCarrier_GetRegionInfo(t *testing.T) {
 			{
 				Region:        "sudeste",
 				EstimatedDays: 5,
-				PricePerKg:    10.0,
+				PricePerKg:    vo.NewMoney(1000),
 			},
 			{
 				Region:        "sul",
 				EstimatedDays: 7,
-				PricePerKg:    8.0,
+				PricePerKg:    vo.NewMoney(800),
 			},
 		},
 	}
@@ -52,7 +58,7 @@ func TestCarrier_GetRegionInfo(t *testing.T) {
 		assert.NotNil(t, region)
 		assert.Equal(t, "sudeste", region.Region)
 		assert.Equal(t, 5, region.EstimatedDays)
-		assert.Equal(t, 10.0, region.PricePerKg)
+		assert.Equal(t, vo.NewMoney(1000), region.PricePerKg)
 	})
 
 	t.Run("should return nil when region does not exist", func(t *testing.T) {
@@ -71,34 +77,276 @@ func TestCarrier_CalculateShipping(t *testing.T) {
 			{
 				Region:        "sudeste",
 				EstimatedDays: 5,
-				PricePerKg:    10.0,
+				PricePerKg:    vo.NewMoney(1000),
 			},
 		},
 	}
 
 	t.Run("should calculate shipping for valid region", func(t *testing.T) {
-		price, days, ok := carrier.CalculateShipping("sudeste", 2.0)
+		price, days, ok := carrier.CalculateShipping(vo.ShippingRequest{WeightKg: 2.0, DestinationRegion: "sudeste"})
 
 		assert.True(t, ok)
-		assert.Equal(t, 20.0, price) // 2.0 * 10.0
+		assert.Equal(t, vo.NewMoney(2000), price.Total()) // 2.0 * 10.0
 		assert.Equal(t, 5, days)
 	})
 
 	t.Run("should return minimum price for light packages", func(t *testing.T) {
-		price, days, ok := carrier.CalculateShipping("sudeste", 0.5)
+		price, days, ok := carrier.CalculateShipping(vo.ShippingRequest{WeightKg: 0.5, DestinationRegion: "sudeste"})
 
 		assert.True(t, ok)
-		assert.Equal(t, 10.0, price) // Minimum price (price per kg)
+		assert.Equal(t, vo.NewMoney(1000), price.Total()) // Minimum price (price per kg)
 		assert.Equal(t, 5, days)
 	})
 
 	t.Run("should return false for invalid region", func(t *testing.T) {
-		price, days, ok := carrier.CalculateShipping("norte", 2.0)
+		price, days, ok := carrier.CalculateShipping(vo.ShippingRequest{WeightKg: 2.0, DestinationRegion: "norte"})
 
 		assert.False(t, ok)
-		assert.Equal(t, 0.0, price)
+		assert.Equal(t, vo.NewMoney(0), price.Total())
 		assert.Equal(t, 0, days)
 	})
+
+	t.Run("should return false instead of a free quote when no weight band applies", func(t *testing.T) {
+		// Bands without the open-ended last band are rejected by the catalog validation,
+		// but not by NewCarrierRepository
+		banded := &Carrier{
+			ID:      "banded-carrier",
+			Name:    "Banded Carrier",
+			Regions: []CarrierRegion{{Region: "sudeste", EstimatedDays: 5, WeightBands: []WeightBand{{UpToKg: 10, FixedPrice: vo.NewMoney(1990)}}}},
+		}
+
+		price, days, ok := banded.CalculateShipping(vo.ShippingRequest{WeightKg: 12, DestinationRegion: "sudeste"})
+
+		assert.False(t, ok)
+		assert.True(t, price.Total().IsZero())
+		assert.Equal(t, 0, days)
+
+		_, err := TableQuoter{}.Quote(context.Background(), banded, vo.ShippingRequest{DestinationRegion: "sudeste", WeightKg: 12})
+		assert.ErrorContains(t, err, "has no price")
+	})
+
+	t.Run("should charge the cubic weight of bulky packages", func(t *testing.T) {
+		cubing := &Carrier{
+			ID:      "cubing-carrier",
+			Name:    "Cubing Carrier",
+			Regions: []CarrierRegion{{Region: "sudeste", EstimatedDays: 5, PricePerKg: vo.NewMoney(1000), CubingFactor: 300}},
+		}
+
+		// 100 x 80 x 90 cm = 0.72 m³ x 300 kg/m³ = 216 kg
+		price, _, ok := cubing.CalculateShipping(vo.ShippingRequest{WeightKg: 15, Dimensions: vo.NewDimensions(100, 80, 90), DestinationRegion: "sudeste"})
+		assert.True(t, ok)
+		assert.Equal(t, vo.NewMoney(216000), price.Total())
+
+		// 20 x 20 x 10 cm = 1.2 kg of cubic weight, below the actual weight
+		price, _, ok = cubing.CalculateShipping(vo.ShippingRequest{WeightKg: 2.0, Dimensions: vo.NewDimensions(20, 20, 10), DestinationRegion: "sudeste"})
+		assert.True(t, ok)
+		assert.Equal(t, vo.NewMoney(2000), price.Total())
+
+		// Without a cubing factor only the actual weight is charged
+		price, _, ok = carrier.CalculateShipping(vo.ShippingRequest{WeightKg: 15, Dimensions: vo.NewDimensions(100, 80, 90), DestinationRegion: "sudeste"})
+		assert.True(t, ok)
+		assert.Equal(t, vo.NewMoney(15000), price.Total())
+	})
+}
+
+func TestCarrier_CalculateShippingByCEP(t *testing.T) {
+	carrier := &Carrier{
+		ID:   "test-carrier",
+		Name: "Test Carrier",
+		Regions: []CarrierRegion{{
+			Region:        "norte",
+			EstimatedDays: 8,
+			PricePerKg:    vo.NewMoney(990),
+			MinimumCharge: vo.NewMoney(1500),
+			CEPRanges: []CEPRange{
+				{Name: "manaus", From: "69000000", To: "69099999", EstimatedDays: 4, PricePerKg: vo.NewMoney(590)},
+				{Name: "interior-am", From: "69400000", To: "69899999", Unserved: true},
+				{Name: "remota", From: "69000000", To: "69999999", EstimatedDays: 15, WeightBands: []WeightBand{{FixedPrice: vo.NewMoney(4500)}}},
+			},
+		}},
+	}
+
+	tests := []struct {
+		name          string
+		cep           vo.CEP
+		expectedPrice vo.Money
+		expectedDays  int
+		served        bool
+	}{
+		{name: "capital range replaces days and price", cep: "69005010", expectedPrice: vo.NewMoney(1770), expectedDays: 4, served: true},
+		{name: "first matching range wins", cep: "69099999", expectedPrice: vo.NewMoney(1770), expectedDays: 4, served: true},
+		{name: "unserved range", cep: "69460000", served: false},
+		{name: "weight bands replace the price per kg", cep: "69900000", expectedPrice: vo.NewMoney(4500), expectedDays: 15, served: true},
+		{name: "outside every range uses the region", cep: "66010000", expectedPrice: vo.NewMoney(2970), expectedDays: 8, served: true},
+		{name: "without CEP uses the region", cep: "", expectedPrice: vo.NewMoney(2970), expectedDays: 8, served: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, days, ok := carrier.CalculateShipping(vo.ShippingRequest{WeightKg: 3, DestinationRegion: "norte", DestinationCEP: tt.cep})
+
+			assert.Equal(t, tt.served, ok)
+			assert.Equal(t, tt.served, carrier.IsAvailableFor("", "norte", tt.cep))
+			assert.Equal(t, tt.expectedPrice, price.Total())
+			assert.Equal(t, tt.expectedDays, days)
+		})
+	}
+
+	t.Run("should keep the region available to other CEPs", func(t *testing.T) {
+		assert.True(t, carrier.IsAvailableForRegion("norte"))
+		assert.False(t, carrier.IsAvailableFor("", "sul", ""))
+	})
+}
+
+func TestCarrier_CalculateShippingByOrigin(t *testing.T) {
+	carrier := &Carrier{
+		ID:   "test-carrier",
+		Name: "Test Carrier",
+		Regions: []CarrierRegion{{
+			Region:        "sudeste",
+			EstimatedDays: 4,
+			PricePerKg:    vo.NewMoney(590),
+			Origins: []OriginRate{
+				{Origin: "nordeste", EstimatedDays: 7, PricePerKg: vo.NewMoney(790)},
+				{Origin: "norte", Unserved: true},
+			},
+			CEPRanges: []CEPRange{
+				{Name: "capital-sp", From: "01000000", To: "05999999", EstimatedDays: 2},
+			},
+		}},
+	}
+
+	tests := []struct {
+		name          string
+		origin        string
+		cep           vo.CEP
+		expectedPrice vo.Money
+		expectedDays  int
+		served        bool
+	}{
+		{name: "origin rate replaces days and price", origin: "nordeste", expectedPrice: vo.NewMoney(1580), expectedDays: 7, served: true},
+		{name: "CEP range applies after the origin", origin: "nordeste", cep: "01310100", expectedPrice: vo.NewMoney(1580), expectedDays: 2, served: true},
+		{name: "unserved origin", origin: "norte", served: false},
+		{name: "origin without rate uses the region", origin: "sul", expectedPrice: vo.NewMoney(1180), expectedDays: 4, served: true},
+		{name: "without origin uses the region", origin: "", expectedPrice: vo.NewMoney(1180), expectedDays: 4, served: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, days, ok := carrier.CalculateShipping(vo.ShippingRequest{WeightKg: 2, DestinationRegion: "sudeste", DestinationCEP: tt.cep, OriginRegion: tt.origin})
+
+			assert.Equal(t, tt.served, ok)
+			assert.Equal(t, tt.served, carrier.IsAvailableFor(tt.origin, "sudeste", tt.cep))
+			assert.Equal(t, tt.expectedPrice, price.Total())
+			assert.Equal(t, tt.expectedDays, days)
+		})
+	}
+}
+
+func TestCarrier_CalculateShippingWithInsurance(t *testing.T) {
+	carrier := &Carrier{
+		ID:   "test-carrier",
+		Name: "Test Carrier",
+		Regions: []CarrierRegion{{
+			Region:           "sul",
+			EstimatedDays:    4,
+			PricePerKg:       vo.NewMoney(590),
+			AdValoremPercent: 0.5,
+			MinimumInsurance: vo.NewMoney(200),
+			Surcharges:       []Surcharge{{Name: "coleta", Amount: vo.NewMoney(490)}},
+			CEPRanges: []CEPRange{
+				{Name: "curitiba", From: "80000000", To: "82999999", EstimatedDays: 2, PricePerKg: vo.NewMoney(490)},
+			},
+		}},
+	}
+
+	tests := []struct {
+		name              string
+		cep               vo.CEP
+		declaredValue     vo.Money
+		expectedFreight   vo.Money
+		expectedInsurance vo.Money
+		expectedTotal     vo.Money
+	}{
+		{name: "ad valorem of the declared value", declaredValue: vo.NewMoney(179990), expectedFreight: vo.NewMoney(1180), expectedInsurance: vo.NewMoney(900), expectedTotal: vo.NewMoney(2570)},
+		{name: "minimum insurance for low values", declaredValue: vo.NewMoney(17980), expectedFreight: vo.NewMoney(1180), expectedInsurance: vo.NewMoney(200), expectedTotal: vo.NewMoney(1870)},
+		{name: "no insurance without declared value", expectedFreight: vo.NewMoney(1180), expectedTotal: vo.NewMoney(1670)},
+		{name: "CEP ranges keep the insurance of the region", cep: "80010000", declaredValue: vo.NewMoney(179990), expectedFreight: vo.NewMoney(980), expectedInsurance: vo.NewMoney(900), expectedTotal: vo.NewMoney(2370)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			breakdown, _, ok := carrier.CalculateShipping(vo.ShippingRequest{WeightKg: 2, DestinationRegion: "sul", DestinationCEP: tt.cep, DeclaredValue: tt.declaredValue})
+
+			assert.True(t, ok)
+			assert.Equal(t, tt.expectedFreight, breakdown.Freight)
+			assert.Equal(t, tt.expectedInsurance, breakdown.Insurance)
+			assert.Equal(t, []vo.Surcharge{{Name: "coleta", Amount: vo.NewMoney(490)}}, breakdown.Surcharges)
+			assert.Equal(t, tt.expectedTotal, breakdown.Total())
+		})
+	}
+}
+
+func TestCarrierRegion_Price(t *testing.T) {
+	banded := CarrierRegion{
+		Region:        "sul",
+		EstimatedDays: 7,
+		MinimumCharge: vo.NewMoney(1290),
+		WeightBands: []WeightBand{
+			{UpToKg: 1, FixedPrice: vo.NewMoney(1290)},
+			{UpToKg: 5, FixedPrice: vo.NewMoney(1290), PricePerKg: vo.NewMoney(435)},
+			{UpToKg: 30, FixedPrice: vo.NewMoney(3030), PricePerKg: vo.NewMoney(390)},
+			{FixedPrice: vo.NewMoney(12780), PricePerKg: vo.NewMoney(550)},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		region   CarrierRegion
+		weightKg float64
+		expected vo.Money
+		unpriced bool
+	}{
+		{name: "fixed price of the first band", region: banded, weightKg: 0.3, expected: vo.NewMoney(1290)},
+		{name: "upper limit of the first band", region: banded, weightKg: 1, expected: vo.NewMoney(1290)},
+		{name: "per kg above the start of the band", region: banded, weightKg: 3, expected: vo.NewMoney(2160)},
+		{name: "upper limit of a band", region: banded, weightKg: 5, expected: vo.NewMoney(3030)},
+		{name: "middle band", region: banded, weightKg: 10, expected: vo.NewMoney(4980)},
+		{name: "surcharge over the last limit", region: banded, weightKg: 40, expected: vo.NewMoney(18280)},
+		{
+			name:     "minimum charge above the band price",
+			region:   CarrierRegion{MinimumCharge: vo.NewMoney(1500), WeightBands: []WeightBand{{UpToKg: 1, FixedPrice: vo.NewMoney(990)}, {FixedPrice: vo.NewMoney(990), PricePerKg: vo.NewMoney(400)}}},
+			weightKg: 0.5,
+			expected: vo.NewMoney(1500),
+		},
+		{name: "per kg table", region: CarrierRegion{PricePerKg: vo.NewMoney(590)}, weightKg: 2, expected: vo.NewMoney(1180)},
+		{name: "per kg table charges at least one kilo", region: CarrierRegion{PricePerKg: vo.NewMoney(590)}, weightKg: 0.5, expected: vo.NewMoney(590)},
+		{name: "per kg table with minimum charge", region: CarrierRegion{PricePerKg: vo.NewMoney(590), MinimumCharge: vo.NewMoney(990)}, weightKg: 1, expected: vo.NewMoney(990)},
+		{
+			name:     "weight above every band",
+			region:   CarrierRegion{MinimumCharge: vo.NewMoney(1290), WeightBands: []WeightBand{{UpToKg: 1, FixedPrice: vo.NewMoney(1290)}, {UpToKg: 30, FixedPrice: vo.NewMoney(1290), PricePerKg: vo.NewMoney(435)}}},
+			weightKg: 31,
+			unpriced: true,
+		},
+		{name: "no price table", region: CarrierRegion{MinimumCharge: vo.NewMoney(990)}, weightKg: 1, unpriced: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, priced := tt.region.Price(tt.weightKg)
+
+			assert.Equal(t, !tt.unpriced, priced)
+			assert.Equal(t, tt.expected, price)
+		})
+	}
+}
+
+func TestCarrier_AcceptsWeight(t *testing.T) {
+	carrier := &Carrier{ID: "moventra", MaxWeightKg: 30}
+
+	assert.True(t, carrier.AcceptsWeight(30))
+	assert.False(t, carrier.AcceptsWeight(30.5))
+	assert.True(t, (&Carrier{ID: "nebulix"}).AcceptsWeight(500))
 }
 
 func TestCarrier_IsAvailableForRegion(t *testing.T) {
@@ -109,7 +357,7 @@ func TestCarrier_IsAvailableForRegion(t *testing.T) {
 			{
 				Region:        "sudeste",
 				EstimatedDays: 5,
-				PricePerKg:    10.0,
+				PricePerKg:    vo.NewMoney(1000),
 			},
 		},
 	}
@@ -150,23 +398,17 @@ func TestCarrier_GetID(t *testing.T) {
 }
 
 func TestCarrierRepositoryImpl(t *testing.T) {
-	repo := NewCarrierRepository()
+	repo := NewCarrierRepository([]*Carrier{
+		NewCarrier("nebulix", "Nebulix Logística", []CarrierRegion{{Region: "sul", EstimatedDays: 4, PricePerKg: vo.NewMoney(590)}}),
+		NewCarrier("moventra", "Moventra Express", []CarrierRegion{{Region: "nordeste", EstimatedDays: 10, PricePerKg: vo.NewMoney(950)}}),
+	})
 
 	t.Run("should return all carriers", func(t *testing.T) {
 		carriers := repo.GetAll()
 
-		assert.NotEmpty(t, carriers)
-		assert.Len(t, carriers, 3) // We have 3 carriers in the mock data
-
-		// Verify we have the expected carriers
-		carrierNames := make(map[string]bool)
-		for _, carrier := range carriers {
-			carrierNames[carrier.Name] = true
-		}
-
-		assert.True(t, carrierNames["Nebulix Logística"])
-		assert.True(t, carrierNames["RotaFácil Transportes"])
-		assert.True(t, carrierNames["Moventra Express"])
+		assert.Len(t, carriers, 2)
+		assert.Equal(t, "Nebulix Logística", carriers[0].Name)
+		assert.Equal(t, "Moventra Express", carriers[1].Name)
 	})
 
 	t.Run("should return carrier by ID", func(t *testing.T) {
@@ -185,44 +427,196 @@ func TestCarrierRepositoryImpl(t *testing.T) {
 		assert.Nil(t, carrier)
 		assert.Contains(t, err.Error(), "Carrier not found")
 	})
+
+	t.Run("should replace the catalog", func(t *testing.T) {
+		previous := repo.GetAll()
+
+		repo.Replace([]*Carrier{
+			NewCarrier("rotafacil", "RotaFácil Transportes", []CarrierRegion{{Region: "sul", EstimatedDays: 7, PricePerKg: vo.NewMoney(435)}}),
+		})
+
+		assert.Len(t, repo.GetAll(), 1)
+		_, err := repo.GetByID("nebulix")
+		assert.Error(t, err)
+		assert.Len(t, previous, 2, "slices already handed out must not change")
+	})
 }
 
-func TestGetAvailableCarriers(t *testing.T) {
-	carriers := getAvailableCarriers()
+func TestCarrierRepositoryImpl_Writes(t *testing.T) {
+	newRepo := func() *CarrierRepositoryImpl {
+		return NewCarrierRepository([]*Carrier{
+			NewCarrier("nebulix", "Nebulix Logística", []CarrierRegion{{Region: "sul", EstimatedDays: 4, PricePerKg: vo.NewMoney(590)}}),
+		})
+	}
+
+	t.Run("should create a carrier", func(t *testing.T) {
+		repo := newRepo()
+
+		err := repo.Create(NewCarrier("moventra", "Moventra Express", []CarrierRegion{{Region: "nordeste", EstimatedDays: 10, PricePerKg: vo.NewMoney(950)}}))
 
-	t.Run("should return correct number of carriers", func(t *testing.T) {
-		assert.Len(t, carriers, 3)
+		assert.NoError(t, err)
+		assert.Len(t, repo.GetAll(), 2)
 	})
 
-	t.Run("should have Nebulix Logística with correct data", func(t *testing.T) {
-		nebulix := carriers[0]
-		assert.Equal(t, "nebulix", nebulix.ID)
-		assert.Equal(t, "Nebulix Logística", nebulix.Name)
-		assert.Len(t, nebulix.Regions, 2)
+	t.Run("should reject duplicated carrier", func(t *testing.T) {
+		repo := newRepo()
 
-		// Check regions
-		southRegion, exists := nebulix.GetRegionInfo("sul")
-		assert.True(t, exists)
-		assert.Equal(t, 4, southRegion.EstimatedDays)
-		assert.Equal(t, 5.90, southRegion.PricePerKg)
+		err := repo.Create(NewCarrier("nebulix", "Nebulix 2", []CarrierRegion{{Region: "sul", EstimatedDays: 4, PricePerKg: vo.NewMoney(590)}}))
 
-		southeastRegion, exists := nebulix.GetRegionInfo("sudeste")
-		assert.True(t, exists)
-		assert.Equal(t, 4, southeastRegion.EstimatedDays)
-		assert.Equal(t, 5.90, southeastRegion.PricePerKg)
+		assert.ErrorContains(t, err, "Carrier already exists: nebulix")
+		assert.Len(t, repo.GetAll(), 1)
+	})
+
+	t.Run("should reject invalid carrier", func(t *testing.T) {
+		repo := newRepo()
+
+		err := repo.Create(NewCarrier("moventra", "Moventra Express", []CarrierRegion{{Region: "nordeste", EstimatedDays: 0, PricePerKg: vo.NewMoney(950)}}))
+
+		assert.ErrorContains(t, err, "EstimatedDays")
+		assert.Len(t, repo.GetAll(), 1)
+	})
+
+	t.Run("should update a copy of the carrier", func(t *testing.T) {
+		repo := newRepo()
+		before, err := repo.GetByID("nebulix")
+		require.NoError(t, err)
+
+		updated, err := repo.Update("nebulix", func(carrier *Carrier) error {
+			carrier.Regions[0].PricePerKg = vo.NewMoney(640)
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, vo.NewMoney(640), updated.Regions[0].PricePerKg)
+		assert.Equal(t, vo.NewMoney(590), before.Regions[0].PricePerKg, "carriers already handed out must not change")
+
+		after, err := repo.GetByID("nebulix")
+		require.NoError(t, err)
+		assert.Equal(t, vo.NewMoney(640), after.Regions[0].PricePerKg)
+	})
+
+	t.Run("should keep the carrier when the update fails", func(t *testing.T) {
+		repo := newRepo()
+
+		_, err := repo.Update("nebulix", func(carrier *Carrier) error {
+			carrier.Regions = append(carrier.Regions, carrier.Regions[0])
+			return nil
+		})
+
+		assert.ErrorContains(t, err, "unique")
+		carrier, _ := repo.GetByID("nebulix")
+		assert.Len(t, carrier.Regions, 1)
+	})
+
+	t.Run("should fail to update or delete unknown carrier", func(t *testing.T) {
+		repo := newRepo()
+
+		_, err := repo.Update("unknown", func(carrier *Carrier) error { return nil })
+		assert.ErrorContains(t, err, "Carrier not found")
+
+		err = repo.Delete("unknown")
+		assert.ErrorContains(t, err, "Carrier not found")
+	})
+
+	t.Run("should delete a carrier", func(t *testing.T) {
+		repo := newRepo()
+		require.NoError(t, repo.Create(NewCarrier("moventra", "Moventra Express", []CarrierRegion{{Region: "nordeste", EstimatedDays: 10, PricePerKg: vo.NewMoney(950)}})))
+
+		err := repo.Delete("nebulix")
+
+		assert.NoError(t, err)
+		_, err = repo.GetByID("nebulix")
+		assert.Error(t, err)
+		assert.Len(t, repo.GetAll(), 1)
 	})
 
-	t.Run("should have RotaFácil Transportes with correct data", func(t *testing.T) {
-		rotafacil := carriers[1]
-		assert.Equal(t, "rotafacil", rotafacil.ID)
-		assert.Equal(t, "RotaFácil Transportes", rotafacil.Name)
-		assert.Len(t, rotafacil.Regions, 4)
+	t.Run("should not leave the catalog empty", func(t *testing.T) {
+		repo := newRepo()
+
+		err := repo.Delete("nebulix")
+
+		assert.ErrorContains(t, err, "min")
+		assert.Len(t, repo.GetAll(), 1)
 	})
+}
 
-	t.Run("should have Moventra Express with correct data", func(t *testing.T) {
-		moventra := carriers[2]
-		assert.Equal(t, "moventra", moventra.ID)
-		assert.Equal(t, "Moventra Express", moventra.Name)
-		assert.Len(t, moventra.Regions, 2)
+func TestFileCarrierRepository(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "carriers.yaml")
+	writeCatalog(t, path, testCatalogYAML)
+
+	repo, err := NewFileCarrierRepository(path)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Create(NewCarrier("moventra", "Moventra Express", []CarrierRegion{{
+		Region:        "nordeste",
+		EstimatedDays: 10,
+		MinimumCharge: vo.NewMoney(2290),
+		CubingFactor:  300,
+		WeightBands:   []WeightBand{{UpToKg: 1, FixedPrice: vo.NewMoney(2290)}, {FixedPrice: vo.NewMoney(2290), PricePerKg: vo.NewMoney(950)}},
+		Origins: []OriginRate{
+			{Origin: "nordeste", EstimatedDays: 3, WeightBands: []WeightBand{{UpToKg: 1, FixedPrice: vo.NewMoney(1490)}, {FixedPrice: vo.NewMoney(1490), PricePerKg: vo.NewMoney(520)}}},
+			{Origin: "norte", Unserved: true},
+		},
+		CEPRanges: []CEPRange{
+			{Name: "recife", From: "50000000", To: "52999999", EstimatedDays: 6, MinimumCharge: vo.NewMoney(1990)},
+			{Name: "noronha", From: "53990000", To: "53990999", Unserved: true},
+			{Name: "sertao", From: "56000000", To: "56999999", WeightBands: []WeightBand{{FixedPrice: vo.NewMoney(3500)}}},
+		},
+		AdValoremPercent: 0.4,
+		MinimumInsurance: vo.NewMoney(250),
+		Surcharges:       []Surcharge{{Name: "coleta", Amount: vo.NewMoney(490)}},
+	}})))
+	_, err = repo.Update("nebulix", func(carrier *Carrier) error {
+		carrier.Inactive = true
+		carrier.MaxWeightKg = 50
+		return nil
 	})
+	require.NoError(t, err)
+
+	reloaded, err := LoadCarrierCatalog(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, repo.GetAll(), reloaded)
+	assert.True(t, reloaded[0].Inactive)
+	assert.NoFileExists(t, filepath.Join(filepath.Dir(path), ".tmp-carriers.yaml"))
+}
+
+func TestFileCarrierRepository_KeepsComments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "carriers.yaml")
+	catalog := `# Catálogo de transportadoras
+transportadoras:
+  - id: nebulix
+    nome: Nebulix Logística # razão social
+    regioes:
+      # contrato de 2024
+      - regiao: sul
+        prazo_estimado_dias: 4
+        preco_por_kg: 5.90
+        seguro_minimo: 2.00
+        origens:
+          - {origem: nordeste, prazo_estimado_dias: 9, preco_por_kg: 8.90}
+        faixas_cep:
+          - {nome: capital, cep_inicio: "80000-000", cep_fim: "82999-999"}
+      - regiao: sudeste
+        prazo_estimado_dias: 3
+        preco_por_kg: 6.40
+`
+	writeCatalog(t, path, catalog)
+
+	repo, err := NewFileCarrierRepository(path)
+	require.NoError(t, err)
+
+	_, err = repo.Update("nebulix", func(carrier *Carrier) error {
+		carrier.Regions[1].PricePerKg = vo.NewMoney(690)
+		return nil
+	})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Replace(catalog, "preco_por_kg: 6.40", "preco_por_kg: 6.9", 1), string(content))
+
+	reloaded, err := LoadCarrierCatalog(path)
+	require.NoError(t, err)
+	assert.Equal(t, repo.GetAll(), reloaded)
 }
@@ -0,0 +1,122 @@
+// Package carriertest provides a fake carrier quote API for tests, built on httptest.
+package carriertest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+)
+
+// QuoteRequest is the body the fake carrier expects
+type QuoteRequest struct {
+	CarrierID         string  `json:"transportadora_id"`
+	WeightKg          float64 `json:"peso_kg"`
+	LengthCm          float64 `json:"comprimento_cm,omitempty"`
+	WidthCm           float64 `json:"largura_cm,omitempty"`
+	HeightCm          float64 `json:"altura_cm,omitempty"`
+	DestinationState  string  `json:"estado_destino"`
+	DestinationRegion string  `json:"regiao_destino"`
+	DestinationCEP    string  `json:"cep_destino,omitempty"`
+	OriginState       string  `json:"estado_origem,omitempty"`
+	OriginRegion      string  `json:"regiao_origem,omitempty"`
+	OriginCEP         string  `json:"cep_origem,omitempty"`
+	DeclaredValue     float64 `json:"valor_declarado,omitempty"`
+}
+
+// QuoteResponse is the quote the fake carrier answers with
+type QuoteResponse struct {
+	Price     float64 `json:"preco"`
+	Insurance float64 `json:"seguro,omitempty"`
+	Days      int     `json:"prazo_dias"`
+}
+
+// Server is a fake carrier that quotes PricePerKg * weight, plus the insurance of the
+// declared value when one is set. Its behavior can be changed while the test runs to
+// simulate slow or failing carriers.
+type Server struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	pricePerKg float64
+	insurance  float64
+	days       int
+	delay      time.Duration
+	failures   int
+	failStatus int
+	requests   []QuoteRequest
+}
+
+// NewServer starts a fake carrier. Call Close when done.
+func NewServer(pricePerKg float64, days int) *Server {
+	s := &Server{
+		pricePerKg: pricePerKg,
+		days:       days,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// SetInsurance charges percent of the declared value as insurance, itemized in the answer
+func (s *Server) SetInsurance(percent float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.insurance = percent
+}
+
+// SetDelay makes every answer wait d, or until the client gives up
+func (s *Server) SetDelay(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.delay = d
+}
+
+// FailNext answers the next n requests with the given status code
+func (s *Server) FailNext(n, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = n
+	s.failStatus = status
+}
+
+// Requests returns the quote requests received so far
+func (s *Server) Requests() []QuoteRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]QuoteRequest(nil), s.requests...)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	var req QuoteRequest
+	if r.Method != http.MethodPost || json.NewDecoder(r.Body).Decode(&req) != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	delay := s.delay
+	status := http.StatusOK
+	if s.failures > 0 {
+		s.failures--
+		status = s.failStatus
+	}
+	quote := QuoteResponse{Insurance: req.DeclaredValue * s.insurance / 100, Days: s.days}
+	quote.Price = s.pricePerKg*req.WeightKg + quote.Insurance
+	s.mu.Unlock()
+
+	select {
+	case <-time.After(delay):
+	case <-r.Context().Done():
+		return
+	}
+
+	if status != http.StatusOK {
+		w.WriteHeader(status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(quote)
+}
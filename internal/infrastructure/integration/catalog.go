@@ -0,0 +1,409 @@
+package integration
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
+	"github.com/go-playground/validator/v10"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// carrierCatalog is the schema of the carrier catalog file
+type carrierCatalog struct {
+	Carriers []*Carrier `mapstructure:"transportadoras" validate:"min=1,unique=ID,dive,required"`
+}
+
+// LoadCarrierCatalog reads and validates the carrier catalog file. The format (YAML or
+// JSON) is taken from the file extension and unknown keys are rejected, so a typo in
+// a field name fails the load instead of silently zeroing a price.
+func LoadCarrierCatalog(path string) ([]*Carrier, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("reading carrier catalog %s: %w", path, err)
+	}
+
+	var catalog carrierCatalog
+	if err := v.UnmarshalExact(&catalog, viper.DecodeHook(decodeCatalogValue)); err != nil {
+		return nil, fmt.Errorf("decoding carrier catalog %s: %w", path, err)
+	}
+
+	if err := validateCarrierCatalog(catalog.Carriers); err != nil {
+		return nil, fmt.Errorf("invalid carrier catalog %s: %w", path, err)
+	}
+
+	return catalog.Carriers, nil
+}
+
+// decodeCatalogValue parses the catalog values that are not plain YAML or JSON types
+func decodeCatalogValue(from, to reflect.Type, data any) (any, error) {
+	switch to {
+	case reflect.TypeOf(vo.Money{}):
+		return decodeMoney(data)
+	case reflect.TypeOf(vo.CEP("")):
+		return decodeCEP(data)
+	}
+	return data, nil
+}
+
+// decodeMoney reads prices written as numbers (12.90) or strings ("12.90") into vo.Money
+// without going through float arithmetic
+func decodeMoney(data any) (any, error) {
+	switch value := data.(type) {
+	case float64:
+		return vo.MoneyFromFloat(value)
+	case int:
+		return vo.NewMoney(int64(value) * 100), nil
+	case string:
+		return vo.ParseMoney(value)
+	}
+	return nil, fmt.Errorf("invalid money amount: %v", data)
+}
+
+// decodeCEP reads CEPs with or without hyphen. They must be quoted in YAML, since an
+// unquoted CEP would lose its leading zeros as a number.
+func decodeCEP(data any) (any, error) {
+	value, ok := data.(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid CEP: %v (expected a quoted \"00000-000\")", data)
+	}
+	return vo.ParseCEP(value)
+}
+
+func validateCarrierCatalog(carriers []*Carrier) error {
+	validate := validator.New()
+	validate.RegisterCustomTypeFunc(vo.MoneyCents, vo.Money{})
+	validate.RegisterStructValidation(validateCarrierRegion, CarrierRegion{})
+	validate.RegisterStructValidation(validateOriginRate, OriginRate{})
+	validate.RegisterStructValidation(validateCEPRange, CEPRange{})
+	return validate.Struct(carrierCatalog{Carriers: carriers})
+}
+
+// writeCarrierCatalog saves the catalog in the format of the file extension. The file is
+// written next to the original and renamed over it, so readers never see a partial file.
+func writeCarrierCatalog(path string, carriers []*Carrier) error {
+	catalog := map[string]any{"transportadoras": carrierEntries(carriers)}
+
+	tmp := filepath.Join(filepath.Dir(path), ".tmp-"+filepath.Base(path))
+	if err := writeCatalogFile(tmp, path, catalog); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("writing carrier catalog %s: %w", path, err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("writing carrier catalog %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// writeCatalogFile writes the catalog to tmp. YAML catalogs keep the comments and layout
+// of the current file, which document the pricing schema for whoever edits it by hand.
+func writeCatalogFile(tmp, path string, catalog map[string]any) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		data, err := encodeYAMLCatalog(path, catalog)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(tmp, data, 0o644)
+	}
+
+	v := viper.New()
+	for key, value := range catalog {
+		v.Set(key, value)
+	}
+	return v.WriteConfigAs(tmp)
+}
+
+// encodeYAMLCatalog encodes the catalog taking comments, key order, styles and number
+// spellings from the nodes of the current file that still hold the same values
+func encodeYAMLCatalog(path string, catalog map[string]any) ([]byte, error) {
+	var root yaml.Node
+	if err := root.Encode(catalog); err != nil {
+		return nil, err
+	}
+	doc := &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{&root}}
+
+	if current, err := os.ReadFile(path); err == nil {
+		var previous yaml.Node
+		if yaml.Unmarshal(current, &previous) == nil {
+			keepYAMLLayout(&previous, doc)
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// yamlIdentityKeys are the keys that identify the entries of the catalog lists, so the
+// layout follows an entry even when others are added or removed before it
+var yamlIdentityKeys = []string{"id", "regiao", "origem", "nome"}
+
+// keepYAMLLayout copies the layout of the previous node onto the node about to be written
+func keepYAMLLayout(previous, node *yaml.Node) {
+	if previous.Kind != node.Kind {
+		return
+	}
+
+	node.HeadComment = previous.HeadComment
+	node.LineComment = previous.LineComment
+	node.FootComment = previous.FootComment
+	if node.Kind != yaml.ScalarNode {
+		node.Style = previous.Style
+	}
+
+	switch node.Kind {
+	case yaml.ScalarNode:
+		// 5.90 and 5.9 are the same price: keep the spelling of the file
+		if sameYAMLNumber(previous, node) {
+			node.Tag, node.Value = previous.Tag, previous.Value
+		}
+		if previous.Tag == node.Tag {
+			node.Style = previous.Style
+		}
+	case yaml.DocumentNode:
+		if len(previous.Content) > 0 && len(node.Content) > 0 {
+			keepYAMLLayout(previous.Content[0], node.Content[0])
+		}
+	case yaml.MappingNode:
+		keepYAMLMappingLayout(previous, node)
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			if match := matchYAMLItem(previous.Content, item, i); match != nil {
+				keepYAMLLayout(match, item)
+			}
+		}
+	}
+}
+
+func sameYAMLNumber(previous, node *yaml.Node) bool {
+	numeric := func(n *yaml.Node) bool { return n.Tag == "!!int" || n.Tag == "!!float" }
+	if !numeric(previous) || !numeric(node) {
+		return false
+	}
+	before, errBefore := strconv.ParseFloat(previous.Value, 64)
+	after, errAfter := strconv.ParseFloat(node.Value, 64)
+	return errBefore == nil && errAfter == nil && before == after
+}
+
+// keepYAMLMappingLayout merges the values of the keys in both mappings and writes the keys
+// in the previous order, followed by the new ones
+func keepYAMLMappingLayout(previous, node *yaml.Node) {
+	type pair struct {
+		key, value *yaml.Node
+		order      int
+	}
+
+	pairs := make([]pair, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		p := pair{key: node.Content[i], value: node.Content[i+1], order: len(previous.Content) + i}
+		for j := 0; j+1 < len(previous.Content); j += 2 {
+			if previous.Content[j].Value == p.key.Value {
+				keepYAMLLayout(previous.Content[j], p.key)
+				keepYAMLLayout(previous.Content[j+1], p.value)
+				p.order = j
+				break
+			}
+		}
+		pairs = append(pairs, p)
+	}
+
+	slices.SortStableFunc(pairs, func(a, b pair) int { return a.order - b.order })
+
+	node.Content = node.Content[:0]
+	for _, p := range pairs {
+		node.Content = append(node.Content, p.key, p.value)
+	}
+}
+
+// matchYAMLItem finds the previous list entry with the same identity key, or the one at the
+// same position when the entries have no identity
+func matchYAMLItem(previous []*yaml.Node, item *yaml.Node, index int) *yaml.Node {
+	if key, value := yamlIdentity(item); key != "" {
+		for _, candidate := range previous {
+			if candidateKey, candidateValue := yamlIdentity(candidate); candidateKey == key && candidateValue == value {
+				return candidate
+			}
+		}
+		return nil
+	}
+
+	if index < len(previous) {
+		return previous[index]
+	}
+	return nil
+}
+
+func yamlIdentity(node *yaml.Node) (string, string) {
+	if node.Kind != yaml.MappingNode {
+		return "", ""
+	}
+	for _, key := range yamlIdentityKeys {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				return key, node.Content[i+1].Value
+			}
+		}
+	}
+	return "", ""
+}
+
+// carrierEntries maps the carriers to the keys of the catalog file
+func carrierEntries(carriers []*Carrier) []map[string]any {
+	entries := make([]map[string]any, len(carriers))
+	for i, carrier := range carriers {
+		regions := make([]map[string]any, len(carrier.Regions))
+		for j, region := range carrier.Regions {
+			regions[j] = map[string]any{
+				"regiao":              region.Region,
+				"prazo_estimado_dias": region.EstimatedDays,
+			}
+			if !region.PricePerKg.IsZero() {
+				regions[j]["preco_por_kg"] = region.PricePerKg.Float64()
+			}
+			if len(region.WeightBands) > 0 {
+				regions[j]["faixas_peso"] = weightBandEntries(region.WeightBands)
+			}
+			if !region.MinimumCharge.IsZero() {
+				regions[j]["valor_minimo"] = region.MinimumCharge.Float64()
+			}
+			if region.CubingFactor > 0 {
+				regions[j]["fator_cubagem"] = region.CubingFactor
+			}
+			if len(region.Origins) > 0 {
+				regions[j]["origens"] = originRateEntries(region.Origins)
+			}
+			if len(region.CEPRanges) > 0 {
+				regions[j]["faixas_cep"] = cepRangeEntries(region.CEPRanges)
+			}
+			if region.AdValoremPercent > 0 {
+				regions[j]["ad_valorem_percentual"] = region.AdValoremPercent
+			}
+			if !region.MinimumInsurance.IsZero() {
+				regions[j]["seguro_minimo"] = region.MinimumInsurance.Float64()
+			}
+			if len(region.Surcharges) > 0 {
+				regions[j]["taxas_adicionais"] = surchargeEntries(region.Surcharges)
+			}
+		}
+
+		entry := map[string]any{
+			"id":      carrier.ID,
+			"nome":    carrier.Name,
+			"regioes": regions,
+		}
+		if carrier.MaxWeightKg > 0 {
+			entry["peso_maximo_kg"] = carrier.MaxWeightKg
+		}
+		if carrier.Inactive {
+			entry["inativa"] = true
+		}
+		if carrier.QuoteURL != "" {
+			entry["cotacao_url"] = carrier.QuoteURL
+		}
+		entries[i] = entry
+	}
+	return entries
+}
+
+func weightBandEntries(bands []WeightBand) []map[string]any {
+	entries := make([]map[string]any, len(bands))
+	for i, band := range bands {
+		entry := map[string]any{}
+		if band.UpToKg > 0 {
+			entry["ate_kg"] = band.UpToKg
+		}
+		if !band.FixedPrice.IsZero() {
+			entry["preco_fixo"] = band.FixedPrice.Float64()
+		}
+		if !band.PricePerKg.IsZero() {
+			entry["preco_por_kg"] = band.PricePerKg.Float64()
+		}
+		entries[i] = entry
+	}
+	return entries
+}
+
+func surchargeEntries(surcharges []Surcharge) []map[string]any {
+	entries := make([]map[string]any, len(surcharges))
+	for i, surcharge := range surcharges {
+		entries[i] = map[string]any{
+			"nome":  surcharge.Name,
+			"valor": surcharge.Amount.Float64(),
+		}
+	}
+	return entries
+}
+
+func originRateEntries(rates []OriginRate) []map[string]any {
+	entries := make([]map[string]any, len(rates))
+	for i, rate := range rates {
+		entry := map[string]any{
+			"origem": rate.Origin,
+		}
+		if rate.Unserved {
+			entry["nao_atendida"] = true
+		}
+		if rate.EstimatedDays > 0 {
+			entry["prazo_estimado_dias"] = rate.EstimatedDays
+		}
+		if !rate.PricePerKg.IsZero() {
+			entry["preco_por_kg"] = rate.PricePerKg.Float64()
+		}
+		if len(rate.WeightBands) > 0 {
+			entry["faixas_peso"] = weightBandEntries(rate.WeightBands)
+		}
+		if !rate.MinimumCharge.IsZero() {
+			entry["valor_minimo"] = rate.MinimumCharge.Float64()
+		}
+		entries[i] = entry
+	}
+	return entries
+}
+
+func cepRangeEntries(ranges []CEPRange) []map[string]any {
+	entries := make([]map[string]any, len(ranges))
+	for i, cepRange := range ranges {
+		entry := map[string]any{
+			"nome":       cepRange.Name,
+			"cep_inicio": cepRange.From.String(),
+			"cep_fim":    cepRange.To.String(),
+		}
+		if cepRange.Unserved {
+			entry["nao_atendida"] = true
+		}
+		if cepRange.EstimatedDays > 0 {
+			entry["prazo_estimado_dias"] = cepRange.EstimatedDays
+		}
+		if !cepRange.PricePerKg.IsZero() {
+			entry["preco_por_kg"] = cepRange.PricePerKg.Float64()
+		}
+		if len(cepRange.WeightBands) > 0 {
+			entry["faixas_peso"] = weightBandEntries(cepRange.WeightBands)
+		}
+		if !cepRange.MinimumCharge.IsZero() {
+			entry["valor_minimo"] = cepRange.MinimumCharge.Float64()
+		}
+		entries[i] = entry
+	}
+	return entries
+}
@@ -0,0 +1,492 @@
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeCatalog(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+const testCatalogYAML = `
+transportadoras:
+  - id: nebulix
+    nome: Nebulix Logística
+    regioes:
+      - regiao: sul
+        prazo_estimado_dias: 4
+        preco_por_kg: 5.90
+`
+
+func TestLoadCarrierCatalog(t *testing.T) {
+	t.Run("should load the shipped catalog", func(t *testing.T) {
+		carriers, err := LoadCarrierCatalog("../../../config/carriers.yaml")
+		require.NoError(t, err)
+
+		ids := make([]string, len(carriers))
+		for i, carrier := range carriers {
+			ids[i] = carrier.ID
+		}
+		assert.Equal(t, []string{"nebulix", "rotafacil", "moventra"}, ids)
+
+		southRegion, exists := carriers[0].GetRegionInfo("sul")
+		assert.True(t, exists)
+		assert.Equal(t, 4, southRegion.EstimatedDays)
+		assert.Equal(t, vo.NewMoney(590), southRegion.PricePerKg)
+		assert.Equal(t, 0.5, southRegion.AdValoremPercent)
+		assert.Equal(t, vo.NewMoney(200), southRegion.MinimumInsurance)
+		assert.Len(t, carriers[1].Regions, 4)
+		assert.Len(t, carriers[2].Regions, 2)
+
+		// Nebulix is faster and cheaper in the city of São Paulo
+		_, days, _ := carriers[0].CalculateShipping(vo.ShippingRequest{WeightKg: 1, DestinationRegion: "sudeste", DestinationCEP: "01310100"})
+		assert.Equal(t, 2, days)
+		assert.False(t, carriers[2].IsAvailableFor("", "nordeste", "53990000"))
+
+		// Moventra is faster within the Northeast, and RotaFácil does not ship from there to the South
+		_, days, _ = carriers[2].CalculateShipping(vo.ShippingRequest{WeightKg: 1, DestinationRegion: "nordeste", OriginRegion: "nordeste"})
+		assert.Equal(t, 3, days)
+		assert.False(t, carriers[1].IsAvailableFor("nordeste", "sul", ""))
+
+		// RotaFácil and Moventra are priced by weight bands
+		assert.Equal(t, 100.0, carriers[1].MaxWeightKg)
+		assert.Equal(t, 30.0, carriers[2].MaxWeightKg)
+		for _, carrier := range carriers[1:] {
+			for _, region := range carrier.Regions {
+				assert.NotEmpty(t, region.WeightBands, "%s %s", carrier.ID, region.Region)
+				assert.Zero(t, region.WeightBands[len(region.WeightBands)-1].UpToKg, "%s %s", carrier.ID, region.Region)
+			}
+		}
+	})
+
+	t.Run("should load a YAML catalog", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "carriers.yaml")
+		writeCatalog(t, path, testCatalogYAML)
+
+		carriers, err := LoadCarrierCatalog(path)
+
+		require.NoError(t, err)
+		require.Len(t, carriers, 1)
+		assert.Equal(t, "Nebulix Logística", carriers[0].Name)
+		assert.Equal(t, []CarrierRegion{{Region: "sul", EstimatedDays: 4, PricePerKg: vo.NewMoney(590)}}, carriers[0].Regions)
+	})
+
+	t.Run("should load a JSON catalog", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "carriers.json")
+		writeCatalog(t, path, `{
+			"transportadoras": [
+				{"id": "moventra", "nome": "Moventra Express", "regioes": [
+					{"regiao": "nordeste", "prazo_estimado_dias": 10, "preco_por_kg": 9.5}
+				]}
+			]
+		}`)
+
+		carriers, err := LoadCarrierCatalog(path)
+
+		require.NoError(t, err)
+		require.Len(t, carriers, 1)
+		assert.Equal(t, "moventra", carriers[0].ID)
+		assert.Equal(t, vo.NewMoney(950), carriers[0].Regions[0].PricePerKg)
+	})
+
+	t.Run("should read prices as exact centavos", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "carriers.yaml")
+		writeCatalog(t, path, `
+transportadoras:
+  - id: rotafacil
+    nome: RotaFácil
+    regioes:
+      - regiao: sul
+        prazo_estimado_dias: 7
+        valor_minimo: "12.90"
+        faixas_peso:
+          - {ate_kg: 1, preco_fixo: 13}
+          - {preco_fixo: 12.90, preco_por_kg: 4.35}`)
+
+		carriers, err := LoadCarrierCatalog(path)
+
+		require.NoError(t, err)
+		region := carriers[0].Regions[0]
+		assert.Equal(t, vo.NewMoney(1290), region.MinimumCharge)
+		assert.Equal(t, []WeightBand{
+			{UpToKg: 1, FixedPrice: vo.NewMoney(1300)},
+			{FixedPrice: vo.NewMoney(1290), PricePerKg: vo.NewMoney(435)},
+		}, region.WeightBands)
+	})
+
+	t.Run("should read CEP ranges", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "carriers.yaml")
+		writeCatalog(t, path, `
+transportadoras:
+  - id: nebulix
+    nome: Nebulix
+    regioes:
+      - regiao: norte
+        prazo_estimado_dias: 8
+        preco_por_kg: 9.90
+        faixas_cep:
+          - {nome: manaus, cep_inicio: "69000-000", cep_fim: "69099999", prazo_estimado_dias: 5}
+          - {nome: interior-am, cep_inicio: "69400-000", cep_fim: "69899-999", nao_atendida: true}`)
+
+		carriers, err := LoadCarrierCatalog(path)
+
+		require.NoError(t, err)
+		assert.Equal(t, []CEPRange{
+			{Name: "manaus", From: "69000000", To: "69099999", EstimatedDays: 5},
+			{Name: "interior-am", From: "69400000", To: "69899999", Unserved: true},
+		}, carriers[0].Regions[0].CEPRanges)
+	})
+
+	t.Run("should reject invalid catalogs", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			content  string
+			expected string
+		}{
+			{
+				name:     "empty catalog",
+				content:  "transportadoras: []",
+				expected: "Carriers' Error:Field validation for 'Carriers' failed on the 'min' tag",
+			},
+			{
+				name: "missing name",
+				content: `
+transportadoras:
+  - id: nebulix
+    regioes:
+      - {regiao: sul, prazo_estimado_dias: 4, preco_por_kg: 5.90}`,
+				expected: "Carriers[0].Name",
+			},
+			{
+				name: "carrier without regions",
+				content: `
+transportadoras:
+  - id: nebulix
+    nome: Nebulix`,
+				expected: "Carriers[0].Regions' Error:Field validation for 'Regions' failed on the 'min' tag",
+			},
+			{
+				name: "duplicated carrier",
+				content: `
+transportadoras:
+  - id: nebulix
+    nome: Nebulix
+    regioes:
+      - {regiao: sul, prazo_estimado_dias: 4, preco_por_kg: 5.90}
+  - id: nebulix
+    nome: Nebulix 2
+    regioes:
+      - {regiao: sul, prazo_estimado_dias: 4, preco_por_kg: 5.90}`,
+				expected: "Carriers' Error:Field validation for 'Carriers' failed on the 'unique' tag",
+			},
+			{
+				name: "duplicated region",
+				content: `
+transportadoras:
+  - id: nebulix
+    nome: Nebulix
+    regioes:
+      - {regiao: sul, prazo_estimado_dias: 4, preco_por_kg: 5.90}
+      - {regiao: sul, prazo_estimado_dias: 5, preco_por_kg: 6.90}`,
+				expected: "Carriers[0].Regions' Error:Field validation for 'Regions' failed on the 'unique' tag",
+			},
+			{
+				name: "unknown region",
+				content: `
+transportadoras:
+  - id: nebulix
+    nome: Nebulix
+    regioes:
+      - {regiao: oeste, prazo_estimado_dias: 4, preco_por_kg: 5.90}`,
+				expected: "Carriers[0].Regions[0].Region",
+			},
+			{
+				name: "zero price",
+				content: `
+transportadoras:
+  - id: nebulix
+    nome: Nebulix
+    regioes:
+      - {regiao: sul, prazo_estimado_dias: 4, preco_por_kg: 0}`,
+				expected: "Carriers[0].Regions[0].PricePerKg",
+			},
+			{
+				name: "price that is not a number",
+				content: `
+transportadoras:
+  - id: nebulix
+    nome: Nebulix
+    regioes:
+      - {regiao: sul, prazo_estimado_dias: 4, preco_por_kg: "5,90"}`,
+				expected: `invalid money amount: "5,90"`,
+			},
+			{
+				name: "region without price",
+				content: `
+transportadoras:
+  - id: rotafacil
+    nome: RotaFácil
+    regioes:
+      - {regiao: sul, prazo_estimado_dias: 7}`,
+				expected: "Carriers[0].Regions[0].PricePerKg' Error:Field validation for 'PricePerKg' failed on the 'required_without' tag",
+			},
+			{
+				name: "unsorted weight bands",
+				content: `
+transportadoras:
+  - id: rotafacil
+    nome: RotaFácil
+    regioes:
+      - regiao: sul
+        prazo_estimado_dias: 7
+        faixas_peso:
+          - {ate_kg: 5, preco_fixo: 12.90}
+          - {ate_kg: 1, preco_fixo: 10.00}
+          - {preco_fixo: 30.30, preco_por_kg: 3.90}`,
+				expected: "Carriers[0].Regions[0].WeightBands[1].UpToKg",
+			},
+			{
+				name: "last weight band not open ended",
+				content: `
+transportadoras:
+  - id: rotafacil
+    nome: RotaFácil
+    regioes:
+      - regiao: sul
+        prazo_estimado_dias: 7
+        faixas_peso:
+          - {ate_kg: 1, preco_fixo: 12.90}
+          - {ate_kg: 30, preco_fixo: 12.90, preco_por_kg: 4.35}`,
+				expected: "'open_ended' tag",
+			},
+			{
+				name: "weight band without price",
+				content: `
+transportadoras:
+  - id: rotafacil
+    nome: RotaFácil
+    regioes:
+      - regiao: sul
+        prazo_estimado_dias: 7
+        faixas_peso:
+          - {ate_kg: 1}
+          - {preco_fixo: 12.90, preco_por_kg: 4.35}`,
+				expected: "Carriers[0].Regions[0].WeightBands[0]' Error:Field validation for 'WeightBands[0]' failed on the 'priced' tag",
+			},
+			{
+				name: "negative maximum weight",
+				content: `
+transportadoras:
+  - id: nebulix
+    nome: Nebulix
+    peso_maximo_kg: -1
+    regioes:
+      - {regiao: sul, prazo_estimado_dias: 4, preco_por_kg: 5.90}`,
+				expected: "Carriers[0].MaxWeightKg",
+			},
+			{
+				name: "negative cubing factor",
+				content: `
+transportadoras:
+  - id: nebulix
+    nome: Nebulix
+    regioes:
+      - {regiao: sul, prazo_estimado_dias: 4, preco_por_kg: 5.90, fator_cubagem: -300}`,
+				expected: "Carriers[0].Regions[0].CubingFactor",
+			},
+			{
+				name: "misspelled field",
+				content: `
+transportadoras:
+  - id: nebulix
+    nome: Nebulix
+    regioes:
+      - {regiao: sul, prazo_estimado_dias: 4, preco_kg: 5.90}`,
+				expected: "preco_kg",
+			},
+			{
+				name: "repeated origin",
+				content: `
+transportadoras:
+  - id: nebulix
+    nome: Nebulix
+    regioes:
+      - regiao: sul
+        prazo_estimado_dias: 4
+        preco_por_kg: 5.90
+        origens:
+          - {origem: nordeste, prazo_estimado_dias: 9}
+          - {origem: nordeste, nao_atendida: true}`,
+				expected: "Field validation for 'Origins' failed on the 'unique' tag",
+			},
+			{
+				name: "unknown origin",
+				content: `
+transportadoras:
+  - id: nebulix
+    nome: Nebulix
+    regioes:
+      - regiao: sul
+        prazo_estimado_dias: 4
+        preco_por_kg: 5.90
+        origens:
+          - {origem: exterior, prazo_estimado_dias: 9}`,
+				expected: "Field validation for 'Origin' failed on the 'oneof' tag",
+			},
+			{
+				name: "unquoted CEP",
+				content: `
+transportadoras:
+  - id: nebulix
+    nome: Nebulix
+    regioes:
+      - regiao: norte
+        prazo_estimado_dias: 8
+        preco_por_kg: 9.90
+        faixas_cep:
+          - {nome: manaus, cep_inicio: 69000000, cep_fim: "69099-999"}`,
+				expected: `invalid CEP: 69000000 (expected a quoted "00000-000")`,
+			},
+			{
+				name: "malformed CEP",
+				content: `
+transportadoras:
+  - id: nebulix
+    nome: Nebulix
+    regioes:
+      - regiao: norte
+        prazo_estimado_dias: 8
+        preco_por_kg: 9.90
+        faixas_cep:
+          - {nome: manaus, cep_inicio: "69000", cep_fim: "69099-999"}`,
+				expected: `invalid CEP: "69000"`,
+			},
+			{
+				name: "inverted CEP range",
+				content: `
+transportadoras:
+  - id: nebulix
+    nome: Nebulix
+    regioes:
+      - regiao: norte
+        prazo_estimado_dias: 8
+        preco_por_kg: 9.90
+        faixas_cep:
+          - {nome: manaus, cep_inicio: "69099-999", cep_fim: "69000-000"}`,
+				expected: "Carriers[0].Regions[0].CEPRanges[0].To' Error:Field validation for 'To' failed on the 'gtefield' tag",
+			},
+			{
+				name: "ad valorem above 100%",
+				content: `
+transportadoras:
+  - id: nebulix
+    nome: Nebulix
+    regioes:
+      - {regiao: sul, prazo_estimado_dias: 4, preco_por_kg: 5.90, ad_valorem_percentual: 150}`,
+				expected: "Carriers[0].Regions[0].AdValoremPercent",
+			},
+			{
+				name: "surcharge without value",
+				content: `
+transportadoras:
+  - id: nebulix
+    nome: Nebulix
+    regioes:
+      - regiao: sul
+        prazo_estimado_dias: 4
+        preco_por_kg: 5.90
+        taxas_adicionais:
+          - {nome: coleta}`,
+				expected: "Carriers[0].Regions[0].Surcharges[0].Amount",
+			},
+			{
+				name:     "malformed YAML",
+				content:  "transportadoras: [",
+				expected: "reading carrier catalog",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				path := filepath.Join(t.TempDir(), "carriers.yaml")
+				writeCatalog(t, path, tt.content)
+
+				carriers, err := LoadCarrierCatalog(path)
+
+				assert.Nil(t, carriers)
+				assert.ErrorContains(t, err, tt.expected)
+			})
+		}
+	})
+
+	t.Run("should fail when the file does not exist", func(t *testing.T) {
+		_, err := LoadCarrierCatalog(filepath.Join(t.TempDir(), "missing.yaml"))
+
+		assert.ErrorContains(t, err, "reading carrier catalog")
+	})
+}
+
+func TestWatchCarrierCatalog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "carriers.yaml")
+	writeCatalog(t, path, testCatalogYAML)
+
+	carriers, err := LoadCarrierCatalog(path)
+	require.NoError(t, err)
+	repo := NewCarrierRepository(carriers)
+
+	watcher, err := WatchCarrierCatalog(path, repo)
+	require.NoError(t, err)
+	t.Cleanup(func() { watcher.Close() })
+
+	pricePerKg := func() vo.Money {
+		carrier, err := repo.GetByID("nebulix")
+		if err != nil {
+			return vo.Money{}
+		}
+		return carrier.Regions[0].PricePerKg
+	}
+
+	t.Run("should reload when the file changes", func(t *testing.T) {
+		writeCatalog(t, path, `
+transportadoras:
+  - id: nebulix
+    nome: Nebulix Logística
+    regioes:
+      - {regiao: sul, prazo_estimado_dias: 4, preco_por_kg: 6.40}`)
+
+		assert.Eventually(t, func() bool { return pricePerKg() == vo.NewMoney(640) }, 2*time.Second, 20*time.Millisecond)
+	})
+
+	t.Run("should reload when the file is replaced", func(t *testing.T) {
+		tmp := filepath.Join(filepath.Dir(path), "carriers.yaml.tmp")
+		writeCatalog(t, tmp, `
+transportadoras:
+  - id: nebulix
+    nome: Nebulix Logística
+    regioes:
+      - {regiao: sul, prazo_estimado_dias: 4, preco_por_kg: 7.10}`)
+		require.NoError(t, os.Rename(tmp, path))
+
+		assert.Eventually(t, func() bool { return pricePerKg() == vo.NewMoney(710) }, 2*time.Second, 20*time.Millisecond)
+	})
+
+	t.Run("should keep the previous catalog when the new one is invalid", func(t *testing.T) {
+		writeCatalog(t, path, `
+transportadoras:
+  - id: nebulix
+    nome: Nebulix Logística
+    regioes:
+      - {regiao: sul, prazo_estimado_dias: 4, preco_por_kg: -1}`)
+
+		time.Sleep(3 * catalogReloadDelay)
+		assert.Equal(t, vo.NewMoney(710), pricePerKg())
+	})
+}
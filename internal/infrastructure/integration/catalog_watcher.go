@@ -0,0 +1,91 @@
+package integration
+
+import (
+	"log/slog"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// catalogReloadDelay groups the burst of events editors emit for a single save
+const catalogReloadDelay = 100 * time.Millisecond
+
+// CatalogWatcher reloads the carrier catalog into a repository whenever its file changes.
+// A file that fails validation is logged and ignored, keeping the last valid catalog.
+type CatalogWatcher struct {
+	path    string
+	repo    *CarrierRepositoryImpl
+	watcher *fsnotify.Watcher
+	done    chan struct{}
+}
+
+// WatchCarrierCatalog starts watching the catalog file. The parent directory is watched
+// instead of the file itself so that atomic replaces (rename over the file) are seen.
+func WatchCarrierCatalog(path string, repo *CarrierRepositoryImpl) (*CatalogWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	path = filepath.Clean(path)
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	w := &CatalogWatcher{
+		path:    path,
+		repo:    repo,
+		watcher: watcher,
+		done:    make(chan struct{}),
+	}
+	go w.run()
+
+	return w, nil
+}
+
+// Close stops watching the catalog file
+func (w *CatalogWatcher) Close() error {
+	err := w.watcher.Close()
+	<-w.done
+	return err
+}
+
+func (w *CatalogWatcher) run() {
+	defer close(w.done)
+
+	var reload <-chan time.Time
+	for {
+		select {
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) == w.path && !event.Has(fsnotify.Chmod) {
+				reload = time.After(catalogReloadDelay)
+			}
+
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			slog.Error("watching carrier catalog", "path", w.path, "error", err)
+
+		case <-reload:
+			reload = nil
+			w.reload()
+		}
+	}
+}
+
+func (w *CatalogWatcher) reload() {
+	carriers, err := LoadCarrierCatalog(w.path)
+	if err != nil {
+		slog.Error("carrier catalog not reloaded, keeping the previous one", "path", w.path, "error", err)
+		return
+	}
+
+	w.repo.Replace(carriers)
+	slog.Info("carrier catalog reloaded", "path", w.path, "carriers", len(carriers))
+}
@@ -0,0 +1,212 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
+	"github.com/foliveiracamara/delivery-manager-api/internal/infrastructure/config"
+)
+
+// ErrCarrierUnavailable is returned without calling the carrier while its circuit is open
+var ErrCarrierUnavailable = errors.New("carrier temporarily unavailable")
+
+// maxQuoteResponseSize bounds how much of a carrier response is read
+const maxQuoteResponseSize = 1 << 20
+
+// remoteQuoteRequest is the body sent to the carrier quote endpoint. The declared value
+// is left out when the package has none.
+type remoteQuoteRequest struct {
+	CarrierID         string    `json:"transportadora_id"`
+	WeightKg          float64   `json:"peso_kg"`
+	LengthCm          float64   `json:"comprimento_cm,omitempty"`
+	WidthCm           float64   `json:"largura_cm,omitempty"`
+	HeightCm          float64   `json:"altura_cm,omitempty"`
+	DestinationState  string    `json:"estado_destino"`
+	DestinationRegion string    `json:"regiao_destino"`
+	DestinationCEP    vo.CEP    `json:"cep_destino,omitempty"`
+	OriginState       string    `json:"estado_origem,omitempty"`
+	OriginRegion      string    `json:"regiao_origem,omitempty"`
+	OriginCEP         vo.CEP    `json:"cep_origem,omitempty"`
+	DeclaredValue     *vo.Money `json:"valor_declarado,omitempty"`
+}
+
+// remoteQuoteResponse is the quote returned by the carrier; the prices are rounded to the
+// centavo. Insurance is the part of Price charged as ad valorem insurance; carriers that
+// leave it out have the whole price shown as freight.
+type remoteQuoteResponse struct {
+	Price     vo.Money `json:"preco"`
+	Insurance vo.Money `json:"seguro"`
+	Days      int      `json:"prazo_dias"`
+}
+
+// breakdown splits the price into the freight and the insurance
+func (r remoteQuoteResponse) breakdown() vo.PriceBreakdown {
+	return vo.PriceBreakdown{
+		Freight:   vo.NewMoney(r.Price.Cents() - r.Insurance.Cents()),
+		Insurance: r.Insurance,
+	}
+}
+
+// HTTPQuoter quotes with the carrier's own API (POST to Carrier.QuoteURL). Each attempt
+// has its own timeout, failures caused by the carrier (network errors, 5xx, 429) are
+// retried with exponential backoff, and every carrier has its own circuit breaker so a
+// carrier that keeps failing is skipped immediately instead of slowing every quote down.
+type HTTPQuoter struct {
+	client *http.Client
+	cfg    config.CarrierHTTP
+
+	mu       sync.Mutex
+	breakers map[string]*circuitBreaker
+}
+
+func NewHTTPQuoter(cfg config.CarrierHTTP) *HTTPQuoter {
+	return &HTTPQuoter{
+		client:   &http.Client{},
+		cfg:      cfg,
+		breakers: map[string]*circuitBreaker{},
+	}
+}
+
+func (q *HTTPQuoter) Quote(ctx context.Context, carrier *Carrier, req vo.ShippingRequest) (vo.Shipping, error) {
+	breaker := q.breaker(carrier.ID)
+	if !breaker.allow() {
+		return vo.Shipping{}, fmt.Errorf("quoting carrier %s: %w", carrier.ID, ErrCarrierUnavailable)
+	}
+
+	var (
+		quote     remoteQuoteResponse
+		retryable bool
+		err       error
+	)
+	for attempt := 0; ; attempt++ {
+		quote, retryable, err = q.call(ctx, carrier, req)
+		if err == nil || !retryable || attempt >= q.cfg.MaxRetries {
+			break
+		}
+
+		if waitErr := sleep(ctx, q.backoff(attempt)); waitErr != nil {
+			err = waitErr
+			break
+		}
+	}
+
+	switch {
+	case err != nil && ctx.Err() != nil:
+		// The caller gave up, which says nothing about the carrier health
+		breaker.release()
+	default:
+		// A request rejected as invalid still means the carrier is up
+		breaker.record(err == nil || !retryable)
+	}
+	if err != nil {
+		return vo.Shipping{}, fmt.Errorf("quoting carrier %s: %w", carrier.ID, err)
+	}
+
+	return vo.NewItemizedShippingQuote(carrier.Name, carrier.ID, quote.breakdown(), quote.Days), nil
+}
+
+// call makes a single attempt, reporting whether a failure is worth retrying
+func (q *HTTPQuoter) call(ctx context.Context, carrier *Carrier, req vo.ShippingRequest) (remoteQuoteResponse, bool, error) {
+	if q.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, q.cfg.Timeout)
+		defer cancel()
+	}
+
+	remoteReq := remoteQuoteRequest{
+		CarrierID:         carrier.ID,
+		WeightKg:          req.WeightKg,
+		LengthCm:          req.Dimensions.LengthCm,
+		WidthCm:           req.Dimensions.WidthCm,
+		HeightCm:          req.Dimensions.HeightCm,
+		DestinationState:  req.DestinationState,
+		DestinationRegion: req.DestinationRegion,
+		DestinationCEP:    req.DestinationCEP,
+		OriginState:       req.OriginState,
+		OriginRegion:      req.OriginRegion,
+		OriginCEP:         req.OriginCEP,
+	}
+	if !req.DeclaredValue.IsZero() {
+		remoteReq.DeclaredValue = &req.DeclaredValue
+	}
+
+	body, err := json.Marshal(remoteReq)
+	if err != nil {
+		return remoteQuoteResponse{}, false, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, carrier.QuoteURL, bytes.NewReader(body))
+	if err != nil {
+		return remoteQuoteResponse{}, false, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+
+	resp, err := q.client.Do(httpReq)
+	if err != nil {
+		return remoteQuoteResponse{}, true, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, io.LimitReader(resp.Body, maxQuoteResponseSize))
+		retryable := resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+		return remoteQuoteResponse{}, retryable, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var quote remoteQuoteResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxQuoteResponseSize)).Decode(&quote); err != nil {
+		return remoteQuoteResponse{}, true, fmt.Errorf("decoding quote: %w", err)
+	}
+	if quote.Price.Cents() <= 0 || quote.Days <= 0 {
+		return remoteQuoteResponse{}, false, fmt.Errorf("invalid quote: price %s, %d days", quote.Price.Decimal(), quote.Days)
+	}
+	if quote.Insurance.Cents() < 0 || quote.Insurance.Compare(quote.Price) > 0 {
+		return remoteQuoteResponse{}, false, fmt.Errorf("invalid quote: insurance %s over price %s", quote.Insurance.Decimal(), quote.Price.Decimal())
+	}
+
+	return quote, false, nil
+}
+
+func (q *HTTPQuoter) breaker(carrierID string) *circuitBreaker {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	breaker, ok := q.breakers[carrierID]
+	if !ok {
+		breaker = newCircuitBreaker(q.cfg.BreakerThreshold, q.cfg.BreakerCooldown)
+		q.breakers[carrierID] = breaker
+	}
+	return breaker
+}
+
+// backoff doubles the wait on every attempt, with up to 50% of jitter so that
+// retries from concurrent quotes do not hit the carrier at the same time
+func (q *HTTPQuoter) backoff(attempt int) time.Duration {
+	wait := q.cfg.RetryBackoff << attempt
+	if wait <= 0 {
+		return 0
+	}
+	return wait + rand.N(wait/2+1)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,272 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
+	"github.com/foliveiracamara/delivery-manager-api/internal/infrastructure/config"
+	"github.com/foliveiracamara/delivery-manager-api/internal/infrastructure/integration/carriertest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHTTPQuoter() *HTTPQuoter {
+	return NewHTTPQuoter(config.CarrierHTTP{
+		Timeout:          200 * time.Millisecond,
+		MaxRetries:       2,
+		RetryBackoff:     time.Millisecond,
+		BreakerThreshold: 2,
+		BreakerCooldown:  time.Minute,
+	})
+}
+
+func remoteCarrier(server *carriertest.Server) *Carrier {
+	carrier := NewCarrier("remote", "Remote Carrier", []CarrierRegion{{Region: "sul", EstimatedDays: 1, PricePerKg: vo.NewMoney(100)}})
+	carrier.QuoteURL = server.URL + "/quote"
+	return carrier
+}
+
+func TestHTTPQuoter_Quote(t *testing.T) {
+	request := vo.NewShippingRequest(2.0, vo.Dimensions{}, "PR", "sul")
+
+	t.Run("should translate the carrier quote", func(t *testing.T) {
+		server := carriertest.NewServer(6.0, 3)
+		defer server.Close()
+
+		shipping, err := newTestHTTPQuoter().Quote(context.Background(), remoteCarrier(server), request)
+
+		require.NoError(t, err)
+		assert.Equal(t, vo.NewShippingQuote("Remote Carrier", "remote", vo.NewMoney(1200), 3), shipping)
+		assert.Equal(t, []carriertest.QuoteRequest{
+			{CarrierID: "remote", WeightKg: 2.0, DestinationState: "PR", DestinationRegion: "sul"},
+		}, server.Requests())
+	})
+
+	t.Run("should send the destination CEP", func(t *testing.T) {
+		server := carriertest.NewServer(6.0, 3)
+		defer server.Close()
+
+		cepRequest := request
+		cepRequest.DestinationCEP = "80010000"
+		_, err := newTestHTTPQuoter().Quote(context.Background(), remoteCarrier(server), cepRequest)
+
+		require.NoError(t, err)
+		assert.Equal(t, "80010-000", server.Requests()[0].DestinationCEP)
+	})
+
+	t.Run("should send the origin", func(t *testing.T) {
+		server := carriertest.NewServer(6.0, 3)
+		defer server.Close()
+
+		originRequest := request
+		originRequest.OriginState, originRequest.OriginRegion, originRequest.OriginCEP = "PE", "nordeste", "50050000"
+		_, err := newTestHTTPQuoter().Quote(context.Background(), remoteCarrier(server), originRequest)
+
+		require.NoError(t, err)
+		received := server.Requests()[0]
+		assert.Equal(t, "PE", received.OriginState)
+		assert.Equal(t, "nordeste", received.OriginRegion)
+		assert.Equal(t, "50050-000", received.OriginCEP)
+	})
+
+	t.Run("should send the declared value only when there is one", func(t *testing.T) {
+		server := carriertest.NewServer(6.0, 3)
+		defer server.Close()
+
+		valuedRequest := request
+		valuedRequest.DeclaredValue = vo.NewMoney(159990)
+		_, err := newTestHTTPQuoter().Quote(context.Background(), remoteCarrier(server), valuedRequest)
+		require.NoError(t, err)
+		_, err = newTestHTTPQuoter().Quote(context.Background(), remoteCarrier(server), request)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1599.90, server.Requests()[0].DeclaredValue)
+		assert.Zero(t, server.Requests()[1].DeclaredValue)
+	})
+
+	t.Run("should itemize the insurance of the carrier", func(t *testing.T) {
+		server := carriertest.NewServer(6.0, 3)
+		defer server.Close()
+		server.SetInsurance(0.5)
+
+		valuedRequest := request
+		valuedRequest.DeclaredValue = vo.NewMoney(100000)
+		shipping, err := newTestHTTPQuoter().Quote(context.Background(), remoteCarrier(server), valuedRequest)
+
+		require.NoError(t, err)
+		assert.Equal(t, vo.NewMoney(1700), shipping.EstimatedPrice)
+		assert.Equal(t, vo.PriceBreakdown{Freight: vo.NewMoney(1200), Insurance: vo.NewMoney(500)}, shipping.PriceBreakdown())
+	})
+
+	t.Run("should round the carrier price to the centavo", func(t *testing.T) {
+		server := carriertest.NewServer(5.90, 3)
+		defer server.Close()
+
+		// the fake carrier answers 5.90 * 3 = 17.700000000000003
+		shipping, err := newTestHTTPQuoter().Quote(context.Background(), remoteCarrier(server), vo.NewShippingRequest(3, vo.Dimensions{}, "PR", "sul"))
+
+		require.NoError(t, err)
+		assert.Equal(t, vo.NewMoney(1770), shipping.EstimatedPrice)
+	})
+
+	t.Run("should retry server errors", func(t *testing.T) {
+		server := carriertest.NewServer(6.0, 3)
+		defer server.Close()
+		server.FailNext(2, http.StatusServiceUnavailable)
+
+		shipping, err := newTestHTTPQuoter().Quote(context.Background(), remoteCarrier(server), request)
+
+		require.NoError(t, err)
+		assert.Equal(t, vo.NewMoney(1200), shipping.EstimatedPrice)
+		assert.Len(t, server.Requests(), 3)
+	})
+
+	t.Run("should give up after the last retry", func(t *testing.T) {
+		server := carriertest.NewServer(6.0, 3)
+		defer server.Close()
+		server.FailNext(3, http.StatusInternalServerError)
+
+		_, err := newTestHTTPQuoter().Quote(context.Background(), remoteCarrier(server), request)
+
+		assert.ErrorContains(t, err, "unexpected status 500")
+		assert.Len(t, server.Requests(), 3)
+	})
+
+	t.Run("should not retry rejected requests", func(t *testing.T) {
+		server := carriertest.NewServer(6.0, 3)
+		defer server.Close()
+		server.FailNext(1, http.StatusUnprocessableEntity)
+
+		_, err := newTestHTTPQuoter().Quote(context.Background(), remoteCarrier(server), request)
+
+		assert.ErrorContains(t, err, "unexpected status 422")
+		assert.Len(t, server.Requests(), 1)
+	})
+
+	t.Run("should reject invalid quotes", func(t *testing.T) {
+		server := carriertest.NewServer(0, 3)
+		defer server.Close()
+
+		_, err := newTestHTTPQuoter().Quote(context.Background(), remoteCarrier(server), request)
+
+		assert.ErrorContains(t, err, "invalid quote")
+		assert.Len(t, server.Requests(), 1)
+	})
+
+	t.Run("should time out slow carriers", func(t *testing.T) {
+		server := carriertest.NewServer(6.0, 3)
+		defer server.Close()
+		server.SetDelay(time.Second)
+
+		quoter := newTestHTTPQuoter()
+		quoter.cfg.MaxRetries = 0
+
+		start := time.Now()
+		_, err := quoter.Quote(context.Background(), remoteCarrier(server), request)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+	})
+
+	t.Run("should stop retrying when the caller gives up", func(t *testing.T) {
+		server := carriertest.NewServer(6.0, 3)
+		defer server.Close()
+		server.FailNext(10, http.StatusBadGateway)
+
+		quoter := newTestHTTPQuoter()
+		quoter.cfg.RetryBackoff = time.Second
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := quoter.Quote(ctx, remoteCarrier(server), request)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Len(t, server.Requests(), 1)
+		assert.True(t, quoter.breaker("remote").allow(), "giving up must not count against the carrier")
+	})
+
+	t.Run("should open the circuit after consecutive failures", func(t *testing.T) {
+		server := carriertest.NewServer(6.0, 3)
+		defer server.Close()
+		server.FailNext(6, http.StatusInternalServerError)
+
+		quoter := newTestHTTPQuoter()
+		for i := 0; i < 2; i++ {
+			_, err := quoter.Quote(context.Background(), remoteCarrier(server), request)
+			require.Error(t, err)
+		}
+		require.Len(t, server.Requests(), 6)
+
+		_, err := quoter.Quote(context.Background(), remoteCarrier(server), request)
+
+		assert.ErrorIs(t, err, ErrCarrierUnavailable)
+		assert.Len(t, server.Requests(), 6, "an open circuit must not call the carrier")
+	})
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	breaker := newCircuitBreaker(2, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	assert.True(t, breaker.allow())
+	breaker.record(false)
+	assert.True(t, breaker.allow())
+	breaker.record(false)
+
+	assert.False(t, breaker.allow(), "open after the threshold")
+
+	now = now.Add(time.Minute)
+	assert.True(t, breaker.allow(), "one trial after the cooldown")
+	assert.False(t, breaker.allow(), "only one trial at a time")
+
+	breaker.record(false)
+	assert.False(t, breaker.allow(), "failed trial keeps it open")
+
+	now = now.Add(time.Minute)
+	require.True(t, breaker.allow())
+	breaker.record(true)
+	assert.True(t, breaker.allow(), "successful trial closes it")
+	assert.True(t, breaker.allow())
+}
+
+func TestCarrierQuoter(t *testing.T) {
+	server := carriertest.NewServer(6.0, 3)
+	defer server.Close()
+
+	quoter := NewCarrierQuoter(newTestHTTPQuoter())
+	request := vo.NewShippingRequest(2.0, vo.NewDimensions(40, 30, 20), "PR", "sul")
+
+	t.Run("should use the carrier API when it has a quote URL", func(t *testing.T) {
+		shipping, err := quoter.Quote(context.Background(), remoteCarrier(server), request)
+
+		require.NoError(t, err)
+		assert.Equal(t, vo.NewMoney(1200), shipping.EstimatedPrice)
+		assert.Equal(t, 3, shipping.EstimatedDays)
+		// The carrier gets the dimensions to compute its own cubic weight
+		assert.Equal(t, []carriertest.QuoteRequest{
+			{CarrierID: "remote", WeightKg: 2.0, LengthCm: 40, WidthCm: 30, HeightCm: 20, DestinationState: "PR", DestinationRegion: "sul"},
+		}, server.Requests())
+	})
+
+	t.Run("should use the price table otherwise", func(t *testing.T) {
+		carrier := NewCarrier("nebulix", "Nebulix Logística", []CarrierRegion{{Region: "sul", EstimatedDays: 4, PricePerKg: vo.NewMoney(590)}})
+
+		shipping, err := quoter.Quote(context.Background(), carrier, request)
+
+		require.NoError(t, err)
+		assert.Equal(t, vo.NewShippingQuote("Nebulix Logística", "nebulix", vo.NewMoney(1180), 4), shipping)
+	})
+
+	t.Run("should fail for regions the carrier does not serve", func(t *testing.T) {
+		carrier := NewCarrier("nebulix", "Nebulix Logística", []CarrierRegion{{Region: "sudeste", EstimatedDays: 4, PricePerKg: vo.NewMoney(590)}})
+
+		_, err := quoter.Quote(context.Background(), carrier, request)
+
+		assert.ErrorContains(t, err, "does not serve region sul")
+	})
+}
@@ -0,0 +1,53 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
+)
+
+// ShippingQuoter calculates the price and delivery time of a shipping with a carrier
+type ShippingQuoter interface {
+	Quote(ctx context.Context, carrier *Carrier, req vo.ShippingRequest) (vo.Shipping, error)
+}
+
+// TableQuoter quotes with the price table of the carrier regions in the catalog
+type TableQuoter struct{}
+
+func (TableQuoter) Quote(ctx context.Context, carrier *Carrier, req vo.ShippingRequest) (vo.Shipping, error) {
+	breakdown, days, ok := carrier.CalculateShipping(req)
+	if !ok {
+		switch {
+		case !carrier.IsAvailableForRegion(req.DestinationRegion):
+			return vo.Shipping{}, fmt.Errorf("carrier %s does not serve region %s", carrier.ID, req.DestinationRegion)
+		case !carrier.IsAvailableFor(req.OriginRegion, req.DestinationRegion, ""):
+			return vo.Shipping{}, fmt.Errorf("carrier %s does not ship from %s to %s", carrier.ID, req.OriginRegion, req.DestinationRegion)
+		case !carrier.IsAvailableFor(req.OriginRegion, req.DestinationRegion, req.DestinationCEP):
+			return vo.Shipping{}, fmt.Errorf("carrier %s does not serve CEP %s", carrier.ID, req.DestinationCEP)
+		}
+		return vo.Shipping{}, fmt.Errorf("carrier %s has no price for a %.3f kg package in region %s", carrier.ID, req.WeightKg, req.DestinationRegion)
+	}
+
+	return vo.NewItemizedShippingQuote(carrier.Name, carrier.ID, breakdown, days), nil
+}
+
+// CarrierQuoter calls the carrier API when the carrier has a quote URL and falls back
+// to the catalog price table otherwise
+type CarrierQuoter struct {
+	table  TableQuoter
+	remote *HTTPQuoter
+}
+
+func NewCarrierQuoter(remote *HTTPQuoter) *CarrierQuoter {
+	return &CarrierQuoter{
+		remote: remote,
+	}
+}
+
+func (q *CarrierQuoter) Quote(ctx context.Context, carrier *Carrier, req vo.ShippingRequest) (vo.Shipping, error) {
+	if carrier.QuoteURL != "" {
+		return q.remote.Quote(ctx, carrier, req)
+	}
+	return q.table.Quote(ctx, carrier, req)
+}
@@ -0,0 +1,93 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/infrastructure/config"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	_ "modernc.org/sqlite"
+)
+
+const connectTimeout = 5 * time.Second
+
+// OpenPostgres connects to PostgreSQL through the pgx driver, configures the
+// connection pool and applies the pending migrations.
+func OpenPostgres(cfg config.Postgres) (*sql.DB, error) {
+	if cfg.DSN == "" {
+		return nil, errors.New("storage.postgres.dsn is required for the postgres driver")
+	}
+
+	db, err := sql.Open("pgx", cfg.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("opening postgres: %w", err)
+	}
+
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), connectTimeout)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("connecting to postgres: %w", err)
+	}
+
+	if err := Migrate(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// OpenSQLite opens (or creates) the embedded database file used by single-node
+// deployments and applies the pending migrations. Writes go through a WAL journal
+// with full fsync, so a committed package survives a crash or power loss.
+func OpenSQLite(cfg config.SQLite) (*sql.DB, error) {
+	if cfg.Path == "" {
+		return nil, errors.New("storage.sqlite.path is required for the sqlite driver")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cfg.Path), 0o755); err != nil {
+		return nil, fmt.Errorf("creating sqlite data directory: %w", err)
+	}
+
+	pragmas := url.Values{}
+	pragmas.Add("_pragma", "journal_mode(WAL)")
+	pragmas.Add("_pragma", "synchronous(FULL)")
+	pragmas.Add("_pragma", "foreign_keys(ON)")
+	pragmas.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", cfg.BusyTimeout.Milliseconds()))
+	pragmas.Set("_txlock", "immediate")
+
+	db, err := sql.Open("sqlite", "file:"+cfg.Path+"?"+pragmas.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("opening sqlite: %w", err)
+	}
+
+	// SQLite has a single writer; one connection avoids lock contention between transactions
+	db.SetMaxOpenConns(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), connectTimeout)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("connecting to sqlite: %w", err)
+	}
+
+	if err := Migrate(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
@@ -0,0 +1,67 @@
+package persistence
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain"
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
+	"github.com/foliveiracamara/delivery-manager-api/internal/infrastructure/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenPostgres(t *testing.T) {
+	t.Run("should require a DSN", func(t *testing.T) {
+		db, err := OpenPostgres(config.Postgres{})
+
+		assert.Nil(t, db)
+		assert.ErrorContains(t, err, "storage.postgres.dsn is required")
+	})
+}
+
+func TestOpenSQLite(t *testing.T) {
+	t.Run("should require a path", func(t *testing.T) {
+		db, err := OpenSQLite(config.SQLite{})
+
+		assert.Nil(t, db)
+		assert.ErrorContains(t, err, "storage.sqlite.path is required")
+	})
+
+	t.Run("should pass the repository contract on a data file", func(t *testing.T) {
+		testPackageRepository(t, func(t *testing.T) domain.PackageRepository {
+			cfg := config.SQLite{Path: filepath.Join(t.TempDir(), "data", "packages.db"), BusyTimeout: time.Second}
+
+			db, err := OpenSQLite(cfg)
+			require.NoError(t, err)
+			t.Cleanup(func() { db.Close() })
+
+			return NewSQLPackageRepository(db)
+		})
+	})
+
+	t.Run("should keep packages across restarts", func(t *testing.T) {
+		cfg := config.SQLite{Path: filepath.Join(t.TempDir(), "packages.db"), BusyTimeout: time.Second}
+
+		db, err := OpenSQLite(cfg)
+		require.NoError(t, err)
+
+		pkg, err := domain.NewPackage("Test Product", "SP", 2.5, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+		pkg.AssignShipping(vo.NewShippingQuote("Test Carrier", "test-carrier", vo.NewMoney(2550), 5), "operator")
+		require.NoError(t, NewSQLPackageRepository(db).Save(pkg))
+		require.NoError(t, db.Close())
+
+		db, err = OpenSQLite(cfg)
+		require.NoError(t, err)
+		defer db.Close()
+
+		retrieved, err := NewSQLPackageRepository(db).GetByID(pkg.ID)
+		require.NoError(t, err)
+		assert.Equal(t, pkg.Product, retrieved.Product)
+		assert.Equal(t, domain.StatusWaitingPickup, retrieved.Status)
+		assert.Equal(t, "test-carrier", retrieved.Shipping.CarrierID)
+		assert.Len(t, retrieved.History, 2)
+	})
+}
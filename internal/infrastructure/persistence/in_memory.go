@@ -1,11 +1,18 @@
 package persistence
 
 import (
+	"slices"
+	"sync"
+	"time"
+
 	"github.com/foliveiracamara/delivery-manager-api/internal/domain"
 	apperr "github.com/foliveiracamara/delivery-manager-api/internal/shared/apperror"
 )
 
+// InMemoryPackageRepository is safe for concurrent use. Packages are copied on
+// Save and GetByID so callers never share state with the store.
 type InMemoryPackageRepository struct {
+	mu       sync.RWMutex
 	packages map[string]*domain.Package
 }
 
@@ -16,13 +23,136 @@ func NewInMemoryPackageRepository() domain.PackageRepository {
 }
 
 func (r *InMemoryPackageRepository) Save(pkg *domain.Package) error {
-	r.packages[pkg.ID] = pkg
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, exists := r.packages[pkg.ID]
+	if (exists && stored.Version != pkg.Version) || (!exists && pkg.Version != 0) {
+		return domain.NewVersionConflictError(pkg.ID)
+	}
+
+	pkg.Version++
+	clone := pkg.Clone()
+	if exists && clone.OverdueAt == nil {
+		clone.OverdueAt = stored.OverdueAt
+	}
+	r.packages[pkg.ID] = clone
+	return nil
+}
+
+func (r *InMemoryPackageRepository) SaveOverdue(id string, overdueAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.packages[id]
+	if !ok {
+		return apperr.NewNotFoundError("Package not found")
+	}
+	if stored.OverdueAt == nil {
+		stored.OverdueAt = &overdueAt
+	}
 	return nil
 }
 
 func (r *InMemoryPackageRepository) GetByID(id string) (*domain.Package, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	if pkg, ok := r.packages[id]; ok {
-		return pkg, nil
+		return pkg.Clone(), nil
 	}
 	return nil, apperr.NewNotFoundError("Package not found")
 }
+
+func (r *InMemoryPackageRepository) List(query domain.PackageQuery) (*domain.PackagePage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := []*domain.Package{}
+	for _, pkg := range r.packages {
+		if query.Filter.Matches(pkg) && query.IsAfterCursor(pkg) {
+			matches = append(matches, pkg)
+		}
+	}
+
+	slices.SortFunc(matches, query.Compare)
+	if len(matches) > query.Limit+1 {
+		matches = matches[:query.Limit+1]
+	}
+
+	for i, pkg := range matches {
+		matches[i] = pkg.Clone()
+	}
+
+	return domain.NewPackagePage(query, matches), nil
+}
+
+// InMemoryQuoteRepository is safe for concurrent use
+type InMemoryQuoteRepository struct {
+	mu     sync.RWMutex
+	quotes map[string]domain.Quote
+}
+
+func NewInMemoryQuoteRepository() domain.QuoteRepository {
+	return &InMemoryQuoteRepository{
+		quotes: make(map[string]domain.Quote),
+	}
+}
+
+func (r *InMemoryQuoteRepository) Save(quote *domain.Quote) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.quotes[quote.ID] = *quote.Clone()
+	return nil
+}
+
+func (r *InMemoryQuoteRepository) GetByID(id string) (*domain.Quote, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if quote, ok := r.quotes[id]; ok {
+		return quote.Clone(), nil
+	}
+	return nil, apperr.NewNotFoundError("Quote not found")
+}
+
+func (r *InMemoryQuoteRepository) DeleteExpired(before time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted := 0
+	for id, quote := range r.quotes {
+		if quote.ExpiresAt.Before(before) {
+			delete(r.quotes, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// InMemoryWarehouseRepository holds the warehouses set in the configuration. It is
+// read-only, so it needs no locking.
+type InMemoryWarehouseRepository struct {
+	warehouses []*domain.Warehouse
+}
+
+// NewInMemoryWarehouseRepository keeps the warehouses in the given order
+func NewInMemoryWarehouseRepository(warehouses []*domain.Warehouse) domain.WarehouseRepository {
+	return &InMemoryWarehouseRepository{
+		warehouses: warehouses,
+	}
+}
+
+func (r *InMemoryWarehouseRepository) GetAll() []*domain.Warehouse {
+	return r.warehouses
+}
+
+func (r *InMemoryWarehouseRepository) GetByID(id string) (*domain.Warehouse, error) {
+	for _, warehouse := range r.warehouses {
+		if warehouse.ID == id {
+			return warehouse, nil
+		}
+	}
+	return nil, apperr.NewNotFoundError("Warehouse not found: " + id)
+}
@@ -1,55 +1,103 @@
 package persistence
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/foliveiracamara/delivery-manager-api/internal/domain"
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestInMemoryPackageRepository(t *testing.T) {
-	repo := NewInMemoryPackageRepository()
+	testPackageRepository(t, func(t *testing.T) domain.PackageRepository {
+		return NewInMemoryPackageRepository()
+	})
+}
 
-	t.Run("should save and retrieve package", func(t *testing.T) {
-		pkg, err := domain.NewPackage("Test Product", "SP", 2.5, domain.DestinationRegionSoutheast)
-		require.NoError(t, err)
+func TestInMemoryQuoteRepository(t *testing.T) {
+	testQuoteRepository(t, func(t *testing.T) (domain.PackageRepository, domain.QuoteRepository) {
+		return NewInMemoryPackageRepository(), NewInMemoryQuoteRepository()
+	})
+}
 
-		// Save package
-		err = repo.Save(pkg)
-		assert.NoError(t, err)
-
-		// Retrieve package
-		retrieved, err := repo.GetByID(pkg.ID)
-		assert.NoError(t, err)
-		assert.Equal(t, pkg.ID, retrieved.ID)
-		assert.Equal(t, pkg.Product, retrieved.Product)
-		assert.Equal(t, pkg.DestinationState, retrieved.DestinationState)
-		assert.Equal(t, pkg.WeightKg, retrieved.WeightKg)
+func TestInMemoryWarehouseRepository(t *testing.T) {
+	repo := NewInMemoryWarehouseRepository([]*domain.Warehouse{
+		{ID: "curitiba", Name: "CD Curitiba", State: "PR", CEP: "81460000"},
+		{ID: "recife", Name: "CD Recife", State: "PE", CEP: "50050000"},
 	})
 
-	t.Run("should return error when package not found", func(t *testing.T) {
-		_, err := repo.GetByID("nonexistent-id")
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "Package not found")
+	t.Run("should keep the configured order", func(t *testing.T) {
+		warehouses := repo.GetAll()
+
+		require.Len(t, warehouses, 2)
+		assert.Equal(t, "curitiba", warehouses[0].ID)
+		assert.Equal(t, "recife", warehouses[1].ID)
 	})
 
-	t.Run("should update existing package", func(t *testing.T) {
-		pkg, err := domain.NewPackage("Original Product", "SP", 2.5, domain.DestinationRegionSoutheast)
-		require.NoError(t, err)
+	t.Run("should find a warehouse by ID", func(t *testing.T) {
+		warehouse, err := repo.GetByID("recife")
 
-		// Save original package
-		err = repo.Save(pkg)
 		require.NoError(t, err)
+		assert.Equal(t, "CD Recife", warehouse.Name)
+	})
 
-		// Update package
-		pkg.Product = "Updated Product"
-		err = repo.Save(pkg)
-		assert.NoError(t, err)
+	t.Run("should return error for unknown warehouse", func(t *testing.T) {
+		_, err := repo.GetByID("manaus")
 
-		// Retrieve and verify update
-		retrieved, err := repo.GetByID(pkg.ID)
-		assert.NoError(t, err)
-		assert.Equal(t, "Updated Product", retrieved.Product)
+		assert.ErrorContains(t, err, "Warehouse not found: manaus")
 	})
 }
+
+// Run with -race to detect unsynchronized access to the store
+func TestInMemoryPackageRepository_Concurrency(t *testing.T) {
+	repo := NewInMemoryPackageRepository()
+
+	const workers = 50
+	ids := make([]string, workers)
+	for i := range ids {
+		pkg, err := domain.NewPackage(fmt.Sprintf("Product %d", i), "SP", 1.0, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(pkg))
+		ids[i] = pkg.ID
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(2)
+
+		go func(id string) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				pkg, err := repo.GetByID(id)
+				if !assert.NoError(t, err) {
+					return
+				}
+				pkg.Product = fmt.Sprintf("Updated %d", j)
+				assert.NoError(t, repo.Save(pkg))
+			}
+		}(ids[i])
+
+		go func(id string) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				pkg, err := repo.GetByID(id)
+				if !assert.NoError(t, err) {
+					return
+				}
+				// Mutating a retrieved copy must never race with the store
+				pkg.AssignShipping(vo.NewShippingQuote("Test Carrier", "test-carrier", vo.NewMoney(2550), 5), "reader")
+			}
+		}(ids[i])
+	}
+	wg.Wait()
+
+	for _, id := range ids {
+		pkg, err := repo.GetByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, "Updated 19", pkg.Product)
+		assert.Nil(t, pkg.Shipping)
+	}
+}
@@ -0,0 +1,57 @@
+package persistence
+
+import (
+	"database/sql"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	t.Run("should be idempotent", func(t *testing.T) {
+		require.NoError(t, Migrate(db))
+		require.NoError(t, Migrate(db))
+
+		migrations, err := fs.Glob(migrationsFS, "migrations/*.sql")
+		require.NoError(t, err)
+
+		var applied int
+		err = db.QueryRow(`SELECT COUNT(*) FROM schema_migrations`).Scan(&applied)
+		assert.NoError(t, err)
+		assert.Equal(t, len(migrations), applied)
+	})
+}
+
+func TestMigrate_QuotePriceInCents(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	// Schema before quote prices moved to centavos
+	_, err = db.Exec(`CREATE TABLE schema_migrations (version VARCHAR(255) PRIMARY KEY, applied_at TIMESTAMP NOT NULL)`)
+	require.NoError(t, err)
+	for _, version := range []string{"0001_create_packages", "0002_add_package_version", "0003_add_package_listing_columns", "0004_create_shipping_quotes", "0005_add_package_dimensions"} {
+		require.NoError(t, applyMigration(db, version, "migrations/"+version+".sql"))
+	}
+	_, err = db.Exec(`INSERT INTO packages (id, product, weight_kg, destination_region, destination_state, status, created_at, updated_at)
+		VALUES ('pkg-1', 'Camisa', 3, 'sul', 'PR', 'criado', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO shipping_quotes (id, package_id, carrier_id, carrier_name, price, estimated_days, created_at, expires_at)
+		VALUES ('quote-1', 'pkg-1', 'nebulix', 'Nebulix', 17.700000000000003, 4, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`)
+	require.NoError(t, err)
+
+	require.NoError(t, Migrate(db))
+
+	quote, err := NewSQLQuoteRepository(db).GetByID("quote-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1770), quote.Shipping.EstimatedPrice.Cents())
+	assert.Equal(t, "BRL", string(quote.Shipping.EstimatedPrice.Currency()))
+}
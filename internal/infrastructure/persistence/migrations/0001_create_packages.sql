@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS packages (
+    id                 VARCHAR(36) PRIMARY KEY,
+    product            VARCHAR(100) NOT NULL,
+    weight_kg          DOUBLE PRECISION NOT NULL,
+    destination_region VARCHAR(20) NOT NULL,
+    destination_state  CHAR(2) NOT NULL,
+    status             VARCHAR(20) NOT NULL,
+    shipping           TEXT,
+    created_at         TIMESTAMP NOT NULL,
+    updated_at         TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_packages_status ON packages (status);
+
+CREATE TABLE IF NOT EXISTS package_status_events (
+    package_id  VARCHAR(36) NOT NULL REFERENCES packages (id) ON DELETE CASCADE,
+    seq         INTEGER NOT NULL,
+    status      VARCHAR(20) NOT NULL,
+    occurred_at TIMESTAMP NOT NULL,
+    actor       VARCHAR(100) NOT NULL,
+    note        TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (package_id, seq)
+);
@@ -0,0 +1 @@
+ALTER TABLE packages ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,8 @@
+-- carrier_id mirrors shipping.CarrierID for filtering; rows saved before this
+-- migration get it on their next save
+ALTER TABLE packages ADD COLUMN carrier_id VARCHAR(50);
+
+CREATE INDEX IF NOT EXISTS idx_packages_created_at ON packages (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_packages_updated_at ON packages (updated_at, id);
+CREATE INDEX IF NOT EXISTS idx_packages_carrier_id ON packages (carrier_id);
+CREATE INDEX IF NOT EXISTS idx_packages_destination ON packages (destination_region, destination_state);
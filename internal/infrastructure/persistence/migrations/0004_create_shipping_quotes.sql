@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS shipping_quotes (
+    id             VARCHAR(36) PRIMARY KEY,
+    package_id     VARCHAR(36) NOT NULL REFERENCES packages (id) ON DELETE CASCADE,
+    carrier_id     VARCHAR(50) NOT NULL,
+    carrier_name   VARCHAR(100) NOT NULL,
+    price          DOUBLE PRECISION NOT NULL,
+    estimated_days INTEGER NOT NULL,
+    created_at     TIMESTAMP NOT NULL,
+    expires_at     TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_shipping_quotes_package_id ON shipping_quotes (package_id);
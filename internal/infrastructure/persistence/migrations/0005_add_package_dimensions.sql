@@ -0,0 +1,4 @@
+-- Package dimensions in centimeters; 0 means they were not informed
+ALTER TABLE packages ADD COLUMN length_cm DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE packages ADD COLUMN width_cm DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE packages ADD COLUMN height_cm DOUBLE PRECISION NOT NULL DEFAULT 0;
@@ -0,0 +1,5 @@
+-- Quote prices move from floating point to integer centavos plus the ISO 4217 currency
+ALTER TABLE shipping_quotes ADD COLUMN price_cents BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE shipping_quotes ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'BRL';
+UPDATE shipping_quotes SET price_cents = CAST(ROUND(price * 100) AS BIGINT);
+ALTER TABLE shipping_quotes DROP COLUMN price;
@@ -0,0 +1,5 @@
+-- overdue_at is set by the SLA job the first time a package passes its promised
+-- delivery date and kept after delivery, for carrier penalty claims
+ALTER TABLE packages ADD COLUMN overdue_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_packages_overdue_at ON packages (overdue_at);
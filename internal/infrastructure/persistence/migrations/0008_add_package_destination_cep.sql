@@ -0,0 +1,2 @@
+-- Destination CEP as eight digits; empty for packages created with the state only
+ALTER TABLE packages ADD COLUMN destination_cep VARCHAR(8) NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+-- Copy of the warehouse the package ships from, as JSON; NULL for packages without origin
+ALTER TABLE packages ADD COLUMN origin TEXT;
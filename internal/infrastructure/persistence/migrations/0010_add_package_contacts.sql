@@ -0,0 +1,3 @@
+-- Recipient and sender name, document, phone and address, as JSON; NULL when not informed
+ALTER TABLE packages ADD COLUMN recipient TEXT;
+ALTER TABLE packages ADD COLUMN sender TEXT;
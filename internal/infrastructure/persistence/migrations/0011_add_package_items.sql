@@ -0,0 +1,3 @@
+-- Items with SKU, description, quantity, unit weight, unit declared value and NCM, as
+-- JSON; NULL for packages described only by the product
+ALTER TABLE packages ADD COLUMN items TEXT;
@@ -0,0 +1,3 @@
+-- Declared value of the content, base of the ad valorem insurance; 0 when not informed.
+-- Packages with items saved before this column fall back to the items value on load.
+ALTER TABLE packages ADD COLUMN declared_value_cents BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+-- Freight, insurance and surcharges that make up the quoted price, as JSON; NULL for
+-- quotes saved before the breakdown, whose whole price is freight
+ALTER TABLE shipping_quotes ADD COLUMN price_breakdown TEXT;
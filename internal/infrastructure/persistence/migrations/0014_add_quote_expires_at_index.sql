@@ -0,0 +1,2 @@
+-- expired quotes are purged by expires_at
+CREATE INDEX IF NOT EXISTS idx_shipping_quotes_expires_at ON shipping_quotes (expires_at);
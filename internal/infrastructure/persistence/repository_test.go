@@ -0,0 +1,608 @@
+package persistence
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain"
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
+	apperr "github.com/foliveiracamara/delivery-manager-api/internal/shared/apperror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPackageRepository runs the behaviour every domain.PackageRepository must honour.
+// newRepo must return an empty repository.
+func testPackageRepository(t *testing.T, newRepo func(t *testing.T) domain.PackageRepository) {
+	t.Run("should save and retrieve package", func(t *testing.T) {
+		repo := newRepo(t)
+
+		pkg, err := domain.NewPackage("Test Product", "SP", 2.5, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+
+		// Save package
+		err = repo.Save(pkg)
+		assert.NoError(t, err)
+
+		// Retrieve package
+		retrieved, err := repo.GetByID(pkg.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, pkg.ID, retrieved.ID)
+		assert.Equal(t, pkg.Product, retrieved.Product)
+		assert.Equal(t, pkg.DestinationState, retrieved.DestinationState)
+		assert.Equal(t, pkg.WeightKg, retrieved.WeightKg)
+		assert.True(t, retrieved.Dimensions.IsZero())
+	})
+
+	t.Run("should persist package dimensions", func(t *testing.T) {
+		repo := newRepo(t)
+
+		pkg, err := domain.NewPackage("Poltrona", "SP", 15, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+		require.NoError(t, pkg.SetDimensions(vo.NewDimensions(100, 80, 90.5)))
+		require.NoError(t, repo.Save(pkg))
+
+		retrieved, err := repo.GetByID(pkg.ID)
+		require.NoError(t, err)
+		assert.Equal(t, vo.NewDimensions(100, 80, 90.5), retrieved.Dimensions)
+	})
+
+	t.Run("should persist the destination CEP", func(t *testing.T) {
+		repo := newRepo(t)
+
+		pkg, err := domain.NewPackage("Camisa", "PR", 1, domain.DestinationRegionSouth)
+		require.NoError(t, err)
+		require.NoError(t, pkg.SetDestinationCEP("80010000"))
+		require.NoError(t, repo.Save(pkg))
+
+		retrieved, err := repo.GetByID(pkg.ID)
+		require.NoError(t, err)
+		assert.Equal(t, vo.CEP("80010000"), retrieved.DestinationCEP)
+	})
+
+	t.Run("should persist the origin", func(t *testing.T) {
+		repo := newRepo(t)
+		origin := domain.Warehouse{ID: "recife", Name: "CD Recife", State: "PE", CEP: "50050000"}
+
+		pkg, err := domain.NewPackage("Camisa", "PR", 1, domain.DestinationRegionSouth)
+		require.NoError(t, err)
+		pkg.SetOrigin(origin)
+		require.NoError(t, repo.Save(pkg))
+
+		retrieved, err := repo.GetByID(pkg.ID)
+		require.NoError(t, err)
+		assert.Equal(t, &origin, retrieved.Origin)
+	})
+
+	t.Run("should persist the items", func(t *testing.T) {
+		repo := newRepo(t)
+		items := []vo.Item{
+			{SKU: "CAM-G", Description: "Camisa azul", Quantity: 2, UnitWeightKg: 0.3, UnitValue: vo.NewMoney(8990), NCM: "62052000"},
+			{Description: "Calça jeans", Quantity: 1, UnitWeightKg: 0.7, UnitValue: vo.NewMoney(15950)},
+		}
+
+		pkg, err := domain.NewPackage("Roupas", "PR", 0, domain.DestinationRegionSouth)
+		require.NoError(t, err)
+		require.NoError(t, pkg.SetItems(items))
+		require.NoError(t, repo.Save(pkg))
+
+		retrieved, err := repo.GetByID(pkg.ID)
+		require.NoError(t, err)
+		assert.Equal(t, items, retrieved.Items)
+		assert.Equal(t, 1.3, retrieved.WeightKg)
+		assert.Equal(t, vo.NewMoney(33930), retrieved.DeclaredValue)
+	})
+
+	t.Run("should persist the declared value", func(t *testing.T) {
+		repo := newRepo(t)
+
+		pkg, err := domain.NewPackage("Notebook", "PR", 2.1, domain.DestinationRegionSouth)
+		require.NoError(t, err)
+		require.NoError(t, pkg.SetDeclaredValue(vo.NewMoney(459900)))
+		require.NoError(t, repo.Save(pkg))
+
+		retrieved, err := repo.GetByID(pkg.ID)
+		require.NoError(t, err)
+		assert.Equal(t, vo.NewMoney(459900), retrieved.DeclaredValue)
+	})
+
+	t.Run("should persist the recipient and the sender", func(t *testing.T) {
+		repo := newRepo(t)
+		recipient := vo.Contact{
+			Name:     "Maria da Silva",
+			Document: "52998224725",
+			Phone:    "41998765432",
+			Address:  vo.Address{Street: "Rua XV de Novembro", Number: "1000", Complement: "Apto 12", Neighborhood: "Centro", City: "Curitiba", CEP: "80010000"},
+		}
+		sender := vo.Contact{
+			Name:     "Loja Exemplo Ltda",
+			Document: "12ABC34501DE35",
+			Address:  vo.Address{Street: "Avenida Paulista", Number: "S/N", Neighborhood: "Bela Vista", City: "São Paulo", CEP: "01310200"},
+		}
+
+		pkg, err := domain.NewPackage("Camisa", "PR", 1, domain.DestinationRegionSouth)
+		require.NoError(t, err)
+		require.NoError(t, pkg.SetRecipient(recipient))
+		require.NoError(t, pkg.SetSender(sender))
+		require.NoError(t, repo.Save(pkg))
+
+		retrieved, err := repo.GetByID(pkg.ID)
+		require.NoError(t, err)
+		assert.Equal(t, &recipient, retrieved.Recipient)
+		assert.Equal(t, &sender, retrieved.Sender)
+	})
+
+	t.Run("should return error when package not found", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := repo.GetByID("nonexistent-id")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "Package not found")
+	})
+
+	t.Run("should update existing package", func(t *testing.T) {
+		repo := newRepo(t)
+
+		pkg, err := domain.NewPackage("Original Product", "SP", 2.5, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+
+		// Save original package
+		err = repo.Save(pkg)
+		require.NoError(t, err)
+
+		// Update package
+		pkg.Product = "Updated Product"
+		err = repo.Save(pkg)
+		assert.NoError(t, err)
+
+		// Retrieve and verify update
+		retrieved, err := repo.GetByID(pkg.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Updated Product", retrieved.Product)
+	})
+
+	t.Run("should persist status history", func(t *testing.T) {
+		repo := newRepo(t)
+
+		pkg, err := domain.NewPackage("Test Product", "SP", 2.5, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+		pkg.AssignShipping(vo.NewShippingQuote("Test Carrier", "test-carrier", vo.NewMoney(2550), 5), "operator")
+
+		err = repo.Save(pkg)
+		require.NoError(t, err)
+
+		retrieved, err := repo.GetByID(pkg.ID)
+		assert.NoError(t, err)
+		require.Len(t, retrieved.History, 2)
+		assert.Equal(t, domain.StatusCreated, retrieved.History[0].Status)
+		assert.Equal(t, domain.StatusWaitingPickup, retrieved.History[1].Status)
+		assert.Equal(t, "operator", retrieved.History[1].Actor)
+	})
+
+	t.Run("should persist the delivery date", func(t *testing.T) {
+		repo := newRepo(t)
+
+		pkg, err := domain.NewPackage("Test Product", "SP", 2.5, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+		shipping := vo.NewShippingQuote("Test Carrier", "test-carrier", vo.NewMoney(2550), 5)
+		shipping.EstimatedDeliveryDate = time.Date(2025, 1, 22, 0, 0, 0, 0, domain.BrazilTimezone)
+		pkg.AssignShipping(shipping, "operator")
+
+		err = repo.Save(pkg)
+		require.NoError(t, err)
+
+		retrieved, err := repo.GetByID(pkg.ID)
+		require.NoError(t, err)
+		assert.True(t, shipping.EstimatedDeliveryDate.Equal(retrieved.Shipping.EstimatedDeliveryDate))
+		assert.False(t, retrieved.IsOverdue(time.Date(2025, 1, 22, 18, 0, 0, 0, domain.BrazilTimezone)))
+		assert.True(t, retrieved.IsOverdue(time.Date(2025, 1, 23, 0, 0, 0, 0, domain.BrazilTimezone)))
+	})
+
+	t.Run("should persist and filter by the overdue mark", func(t *testing.T) {
+		repo := newRepo(t)
+
+		onTime, err := domain.NewPackage("On Time", "SP", 2.5, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(onTime))
+
+		late, err := domain.NewPackage("Late", "PR", 2.5, domain.DestinationRegionSouth)
+		require.NoError(t, err)
+		shipping := vo.NewShippingQuote("Test Carrier", "test-carrier", vo.NewMoney(2550), 5)
+		shipping.EstimatedDeliveryDate = time.Date(2025, 1, 22, 0, 0, 0, 0, domain.BrazilTimezone)
+		late.AssignShipping(shipping, "operator")
+		overdueAt := time.Date(2025, 1, 23, 0, 5, 0, 0, domain.BrazilTimezone)
+		require.True(t, late.MarkOverdue(overdueAt))
+		require.NoError(t, repo.Save(late))
+
+		retrieved, err := repo.GetByID(late.ID)
+		require.NoError(t, err)
+		require.NotNil(t, retrieved.OverdueAt)
+		assert.True(t, overdueAt.Equal(*retrieved.OverdueAt))
+
+		query, err := domain.NewPackageQuery(domain.PackageFilter{OverdueOnly: true}, domain.SortByCreatedAt, false, 0, "")
+		require.NoError(t, err)
+		page, err := repo.List(query)
+		require.NoError(t, err)
+		assert.Equal(t, []string{late.ID}, packageIDs(page.Packages))
+	})
+
+	t.Run("should save the overdue mark without changing the version", func(t *testing.T) {
+		repo := newRepo(t)
+
+		pkg, err := domain.NewPackage("Late", "PR", 2.5, domain.DestinationRegionSouth)
+		require.NoError(t, err)
+		pkg.AssignShipping(vo.NewShippingQuote("Test Carrier", "test-carrier", vo.NewMoney(2550), 5), "operator")
+		require.NoError(t, repo.Save(pkg))
+
+		overdueAt := time.Date(2025, 1, 23, 0, 5, 0, 0, time.UTC)
+		require.NoError(t, repo.SaveOverdue(pkg.ID, overdueAt))
+		require.NoError(t, repo.SaveOverdue(pkg.ID, overdueAt.Add(time.Hour)))
+
+		retrieved, err := repo.GetByID(pkg.ID)
+		require.NoError(t, err)
+		assert.Equal(t, pkg.Version, retrieved.Version)
+		require.NotNil(t, retrieved.OverdueAt)
+		assert.True(t, overdueAt.Equal(*retrieved.OverdueAt), "the first mark is kept")
+
+		// A client that read the package before the mark saves without erasing it
+		require.NoError(t, pkg.UpdateStatus(domain.StatusCollected, "operator", ""))
+		require.NoError(t, repo.Save(pkg))
+
+		retrieved, err = repo.GetByID(pkg.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusCollected, retrieved.Status)
+		require.NotNil(t, retrieved.OverdueAt)
+		assert.True(t, overdueAt.Equal(*retrieved.OverdueAt))
+
+		var appErr *apperr.AppErr
+		require.ErrorAs(t, repo.SaveOverdue("nonexistent-id", overdueAt), &appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.Code)
+	})
+
+	t.Run("should not share state with callers", func(t *testing.T) {
+		repo := newRepo(t)
+
+		pkg, err := domain.NewPackage("Original Product", "SP", 2.5, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(pkg))
+
+		// Changes made after Save must not leak into the store
+		pkg.Product = "Unsaved Product"
+		pkg.AssignShipping(vo.NewShippingQuote("Test Carrier", "test-carrier", vo.NewMoney(2550), 5), "operator")
+
+		retrieved, err := repo.GetByID(pkg.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Original Product", retrieved.Product)
+		assert.Nil(t, retrieved.Shipping)
+		assert.Len(t, retrieved.History, 1)
+
+		// Changes made on a retrieved copy must not leak either
+		retrieved.Product = "Changed Copy"
+		retrieved.History[0].Actor = "someone-else"
+
+		again, err := repo.GetByID(pkg.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Original Product", again.Product)
+		assert.Equal(t, domain.SystemActor, again.History[0].Actor)
+	})
+
+	t.Run("should increment the version on every save", func(t *testing.T) {
+		repo := newRepo(t)
+
+		pkg, err := domain.NewPackage("Test Product", "SP", 2.5, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+		assert.Equal(t, 0, pkg.Version)
+
+		require.NoError(t, repo.Save(pkg))
+		assert.Equal(t, 1, pkg.Version)
+
+		require.NoError(t, repo.Save(pkg))
+		assert.Equal(t, 2, pkg.Version)
+
+		retrieved, err := repo.GetByID(pkg.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, retrieved.Version)
+	})
+
+	t.Run("should reject stale saves", func(t *testing.T) {
+		repo := newRepo(t)
+
+		pkg, err := domain.NewPackage("Test Product", "SP", 2.5, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(pkg))
+
+		first, err := repo.GetByID(pkg.ID)
+		require.NoError(t, err)
+		second, err := repo.GetByID(pkg.ID)
+		require.NoError(t, err)
+
+		first.AssignShipping(vo.NewShippingQuote("First Carrier", "first", vo.NewMoney(2550), 5), "dashboard")
+		require.NoError(t, repo.Save(first))
+
+		second.AssignShipping(vo.NewShippingQuote("Second Carrier", "second", vo.NewMoney(2000), 7), "scanner")
+		err = repo.Save(second)
+
+		var appErr *apperr.AppErr
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusConflict, appErr.Code)
+		assert.Equal(t, 1, second.Version)
+
+		retrieved, err := repo.GetByID(pkg.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "first", retrieved.Shipping.CarrierID)
+		assert.Len(t, retrieved.History, 2)
+	})
+
+	t.Run("should reject creating the same package twice", func(t *testing.T) {
+		repo := newRepo(t)
+
+		pkg, err := domain.NewPackage("Test Product", "SP", 2.5, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+		duplicate := pkg.Clone()
+
+		require.NoError(t, repo.Save(pkg))
+		err = repo.Save(duplicate)
+
+		assert.ErrorContains(t, err, "was modified by another request")
+	})
+
+	t.Run("should list packages with filters, sorting and pagination", func(t *testing.T) {
+		repo := newRepo(t)
+		ids := seedPackages(t, repo)
+
+		list := func(filter domain.PackageFilter, sortBy domain.PackageSortField, descending bool, limit int, cursor string) *domain.PackagePage {
+			query, err := domain.NewPackageQuery(filter, sortBy, descending, limit, cursor)
+			require.NoError(t, err)
+			page, err := repo.List(query)
+			require.NoError(t, err)
+			return page
+		}
+
+		tests := []struct {
+			name       string
+			filter     domain.PackageFilter
+			sortBy     domain.PackageSortField
+			descending bool
+			expected   []string
+		}{
+			{
+				name:       "newest first without filters",
+				sortBy:     domain.SortByCreatedAt,
+				descending: true,
+				expected:   []string{ids[4], ids[3], ids[2], ids[1], ids[0]},
+			},
+			{
+				name:     "by status",
+				filter:   domain.PackageFilter{Statuses: []domain.PackageStatus{domain.StatusWaitingPickup}},
+				expected: []string{ids[1], ids[2]},
+			},
+			{
+				name:     "by region",
+				filter:   domain.PackageFilter{Region: domain.DestinationRegionSouth},
+				expected: []string{ids[1], ids[4]},
+			},
+			{
+				name:     "by state",
+				filter:   domain.PackageFilter{State: "SP"},
+				expected: []string{ids[0], ids[3]},
+			},
+			{
+				name:     "by carrier",
+				filter:   domain.PackageFilter{CarrierID: "nebulix"},
+				expected: []string{ids[1]},
+			},
+			{
+				name: "by creation range",
+				filter: domain.PackageFilter{
+					CreatedFrom: baseTime.Add(1 * time.Hour),
+					CreatedTo:   baseTime.Add(3 * time.Hour),
+				},
+				expected: []string{ids[1], ids[2], ids[3]},
+			},
+			{
+				name:     "by update range",
+				filter:   domain.PackageFilter{UpdatedFrom: baseTime.Add(48 * time.Hour)},
+				expected: []string{ids[1], ids[2]},
+			},
+			{
+				name:     "by weight range",
+				filter:   domain.PackageFilter{MinWeightKg: 1.0, MaxWeightKg: 12.0},
+				expected: []string{ids[0], ids[1], ids[2]},
+			},
+			{
+				name:     "sorted by weight",
+				sortBy:   domain.SortByWeight,
+				expected: []string{ids[4], ids[0], ids[1], ids[2], ids[3]},
+			},
+			{
+				name:       "sorted by last update",
+				sortBy:     domain.SortByUpdatedAt,
+				descending: true,
+				expected:   []string{ids[2], ids[1], ids[4], ids[3], ids[0]},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				page := list(tt.filter, tt.sortBy, tt.descending, 0, "")
+
+				assert.Equal(t, tt.expected, packageIDs(page.Packages))
+				assert.Empty(t, page.NextCursor)
+			})
+		}
+
+		t.Run("should paginate with a cursor", func(t *testing.T) {
+			first := list(domain.PackageFilter{}, domain.SortByCreatedAt, true, 2, "")
+			assert.Equal(t, []string{ids[4], ids[3]}, packageIDs(first.Packages))
+			require.NotEmpty(t, first.NextCursor)
+
+			second := list(domain.PackageFilter{}, domain.SortByCreatedAt, true, 2, first.NextCursor)
+			assert.Equal(t, []string{ids[2], ids[1]}, packageIDs(second.Packages))
+			require.NotEmpty(t, second.NextCursor)
+
+			last := list(domain.PackageFilter{}, domain.SortByCreatedAt, true, 2, second.NextCursor)
+			assert.Equal(t, []string{ids[0]}, packageIDs(last.Packages))
+			assert.Empty(t, last.NextCursor)
+		})
+
+		t.Run("should return full packages", func(t *testing.T) {
+			page := list(domain.PackageFilter{CarrierID: "nebulix"}, domain.SortByCreatedAt, false, 0, "")
+
+			require.Len(t, page.Packages, 1)
+			assert.Equal(t, "nebulix", page.Packages[0].Shipping.CarrierID)
+			assert.Len(t, page.Packages[0].History, 2)
+			assert.Equal(t, 2, page.Packages[0].Version)
+		})
+	})
+
+	t.Run("should not skip or repeat packages with the same sort value", func(t *testing.T) {
+		repo := newRepo(t)
+
+		expected := []string{}
+		for i := 0; i < 5; i++ {
+			pkg, err := domain.NewPackage("Same Time", "SP", 1.0, domain.DestinationRegionSoutheast)
+			require.NoError(t, err)
+			pkg.CreatedAt, pkg.UpdatedAt = baseTime, baseTime
+			require.NoError(t, repo.Save(pkg))
+			expected = append(expected, pkg.ID)
+		}
+
+		seen := []string{}
+		cursor := ""
+		for {
+			query, err := domain.NewPackageQuery(domain.PackageFilter{}, domain.SortByCreatedAt, false, 2, cursor)
+			require.NoError(t, err)
+			page, err := repo.List(query)
+			require.NoError(t, err)
+
+			seen = append(seen, packageIDs(page.Packages)...)
+			if page.NextCursor == "" {
+				break
+			}
+			cursor = page.NextCursor
+		}
+
+		assert.ElementsMatch(t, expected, seen)
+		assert.Len(t, seen, 5)
+	})
+}
+
+var baseTime = time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+
+// testQuoteRepository runs the behaviour every domain.QuoteRepository must honour.
+// newRepos must return empty repositories sharing the same storage.
+func testQuoteRepository(t *testing.T, newRepos func(t *testing.T) (domain.PackageRepository, domain.QuoteRepository)) {
+	t.Run("should save and retrieve quote", func(t *testing.T) {
+		packages, quotes := newRepos(t)
+
+		pkg, err := domain.NewPackage("Test Product", "SP", 2.5, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+		require.NoError(t, packages.Save(pkg))
+
+		now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+		breakdown := vo.PriceBreakdown{
+			Freight:    vo.NewMoney(1475),
+			Insurance:  vo.NewMoney(900),
+			Surcharges: []vo.Surcharge{{Name: "coleta", Amount: vo.NewMoney(490)}},
+		}
+		quote := domain.NewQuote(pkg.ID, vo.NewItemizedShippingQuote("Test Carrier", "test-carrier", breakdown, 5), 30*time.Minute, now)
+		require.NoError(t, quotes.Save(quote))
+
+		retrieved, err := quotes.GetByID(quote.ID)
+		require.NoError(t, err)
+		assert.Equal(t, quote.ID, retrieved.ID)
+		assert.Equal(t, pkg.ID, retrieved.PackageID)
+		assert.Equal(t, quote.Shipping, retrieved.Shipping)
+		assert.True(t, now.Equal(retrieved.CreatedAt))
+		assert.True(t, now.Add(30*time.Minute).Equal(retrieved.ExpiresAt))
+
+		quote.Shipping.Breakdown.Surcharges[0].Amount = vo.NewMoney(990)
+		retrieved.Shipping.Breakdown.Surcharges[0].Amount = vo.NewMoney(990)
+		again, err := quotes.GetByID(quote.ID)
+		require.NoError(t, err)
+		assert.Equal(t, vo.NewMoney(490), again.Shipping.Breakdown.Surcharges[0].Amount, "saved quotes must not share surcharges with callers")
+	})
+
+	t.Run("should delete quotes expired before the cutoff", func(t *testing.T) {
+		packages, quotes := newRepos(t)
+
+		pkg, err := domain.NewPackage("Test Product", "SP", 2.5, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+		require.NoError(t, packages.Save(pkg))
+
+		now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+		shipping := vo.NewShippingQuote("Test Carrier", "test-carrier", vo.NewMoney(1475), 5)
+		expired := domain.NewQuote(pkg.ID, shipping, 30*time.Minute, now.Add(-2*time.Hour))
+		valid := domain.NewQuote(pkg.ID, shipping, 30*time.Minute, now)
+		require.NoError(t, quotes.Save(expired))
+		require.NoError(t, quotes.Save(valid))
+
+		deleted, err := quotes.DeleteExpired(now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+
+		_, err = quotes.GetByID(expired.ID)
+		var appErr *apperr.AppErr
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.Code)
+
+		_, err = quotes.GetByID(valid.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("should return error when quote not found", func(t *testing.T) {
+		_, quotes := newRepos(t)
+
+		_, err := quotes.GetByID("nonexistent-id")
+
+		var appErr *apperr.AppErr
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.Code)
+	})
+}
+
+// seedPackages stores five packages created one hour apart and returns their IDs in creation order
+func seedPackages(t *testing.T, repo domain.PackageRepository) []string {
+	fixtures := []struct {
+		state     string
+		region    domain.DestinationRegion
+		weightKg  float64
+		carrierID string
+		updatedAt time.Duration
+	}{
+		{"SP", domain.DestinationRegionSoutheast, 1.0, "", 0},
+		{"PR", domain.DestinationRegionSouth, 5.0, "nebulix", 72 * time.Hour},
+		{"BA", domain.DestinationRegionNortheast, 12.0, "moventra", 96 * time.Hour},
+		{"SP", domain.DestinationRegionSoutheast, 30.0, "", 3 * time.Hour},
+		{"RS", domain.DestinationRegionSouth, 0.5, "", 24 * time.Hour},
+	}
+
+	ids := make([]string, len(fixtures))
+	for i, f := range fixtures {
+		pkg, err := domain.NewPackage("Product", f.state, f.weightKg, f.region)
+		require.NoError(t, err)
+		pkg.CreatedAt = baseTime.Add(time.Duration(i) * time.Hour)
+		if f.carrierID != "" {
+			pkg.AssignShipping(vo.NewShippingQuote("Carrier", f.carrierID, vo.NewMoney(1000), 5), "seed")
+		}
+		pkg.UpdatedAt = baseTime.Add(f.updatedAt)
+
+		require.NoError(t, repo.Save(pkg))
+		ids[i] = pkg.ID
+		if f.carrierID != "" {
+			// A second save proves the carrier survives updates
+			require.NoError(t, repo.Save(pkg))
+		}
+	}
+
+	return ids
+}
+
+func packageIDs(packages []*domain.Package) []string {
+	ids := make([]string, len(packages))
+	for i, pkg := range packages {
+		ids[i] = pkg.ID
+	}
+	return ids
+}
@@ -0,0 +1,654 @@
+package persistence
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain"
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
+	apperr "github.com/foliveiracamara/delivery-manager-api/internal/shared/apperror"
+)
+
+// SQLPackageRepository implements domain.PackageRepository on top of database/sql.
+// Timestamps are always stored in UTC.
+type SQLPackageRepository struct {
+	db *sql.DB
+}
+
+func NewSQLPackageRepository(db *sql.DB) domain.PackageRepository {
+	return &SQLPackageRepository{
+		db: db,
+	}
+}
+
+func (r *SQLPackageRepository) Save(pkg *domain.Package) error {
+	shipping, err := marshalShipping(pkg.Shipping)
+	if err != nil {
+		return err
+	}
+	origin, err := marshalOrigin(pkg.Origin)
+	if err != nil {
+		return err
+	}
+	recipient, err := marshalContact("recipient", pkg.Recipient)
+	if err != nil {
+		return err
+	}
+	sender, err := marshalContact("sender", pkg.Sender)
+	if err != nil {
+		return err
+	}
+	items, err := marshalItems(pkg.Items)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	saved, err := savePackageRow(tx, pkg, shipping, origin, recipient, sender, items, carrierID(pkg))
+	if err != nil {
+		return fmt.Errorf("saving package: %w", err)
+	}
+	if !saved {
+		return domain.NewVersionConflictError(pkg.ID)
+	}
+
+	// The history is append-only, so only the events not yet stored are inserted
+	var stored int
+	err = tx.QueryRow(`SELECT COUNT(*) FROM package_status_events WHERE package_id = $1`, pkg.ID).Scan(&stored)
+	if err != nil {
+		return fmt.Errorf("counting status events: %w", err)
+	}
+
+	for seq := stored; seq < len(pkg.History); seq++ {
+		event := pkg.History[seq]
+		_, err = tx.Exec(`
+			INSERT INTO package_status_events (package_id, seq, status, occurred_at, actor, note)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			pkg.ID,
+			seq,
+			string(event.Status),
+			event.Timestamp.UTC(),
+			event.Actor,
+			event.Note,
+		)
+		if err != nil {
+			return fmt.Errorf("saving status event: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing package: %w", err)
+	}
+
+	pkg.Version++
+	return nil
+}
+
+// savePackageRow inserts a new package (version 0) or updates the stored one only if
+// its version still matches, reporting false when another writer got there first
+func savePackageRow(tx *sql.Tx, pkg *domain.Package, shipping, origin, recipient, sender, items, carrierID sql.NullString) (bool, error) {
+	var (
+		result sql.Result
+		err    error
+	)
+
+	if pkg.Version == 0 {
+		result, err = tx.Exec(`
+			INSERT INTO packages (
+				id, product, weight_kg, destination_region, destination_state,
+				status, shipping, created_at, updated_at, version, carrier_id,
+				length_cm, width_cm, height_cm, overdue_at, destination_cep, origin,
+				recipient, sender, items, declared_value_cents
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
+			ON CONFLICT (id) DO NOTHING`,
+			pkg.ID,
+			pkg.Product,
+			pkg.WeightKg,
+			string(pkg.DestinationRegion),
+			pkg.DestinationState,
+			string(pkg.Status),
+			shipping,
+			pkg.CreatedAt.UTC(),
+			pkg.UpdatedAt.UTC(),
+			pkg.Version+1,
+			carrierID,
+			pkg.Dimensions.LengthCm,
+			pkg.Dimensions.WidthCm,
+			pkg.Dimensions.HeightCm,
+			overdueAt(pkg),
+			string(pkg.DestinationCEP),
+			origin,
+			recipient,
+			sender,
+			items,
+			pkg.DeclaredValue.Cents(),
+		)
+	} else {
+		result, err = tx.Exec(`
+			UPDATE packages SET
+				product = $2,
+				weight_kg = $3,
+				destination_region = $4,
+				destination_state = $5,
+				status = $6,
+				shipping = $7,
+				updated_at = $8,
+				version = $9,
+				carrier_id = $11,
+				length_cm = $12,
+				width_cm = $13,
+				height_cm = $14,
+				overdue_at = COALESCE(overdue_at, $15),
+				destination_cep = $16,
+				origin = $17,
+				recipient = $18,
+				sender = $19,
+				items = $20,
+				declared_value_cents = $21
+			WHERE id = $1 AND version = $10`,
+			pkg.ID,
+			pkg.Product,
+			pkg.WeightKg,
+			string(pkg.DestinationRegion),
+			pkg.DestinationState,
+			string(pkg.Status),
+			shipping,
+			pkg.UpdatedAt.UTC(),
+			pkg.Version+1,
+			pkg.Version,
+			carrierID,
+			pkg.Dimensions.LengthCm,
+			pkg.Dimensions.WidthCm,
+			pkg.Dimensions.HeightCm,
+			overdueAt(pkg),
+			string(pkg.DestinationCEP),
+			origin,
+			recipient,
+			sender,
+			items,
+			pkg.DeclaredValue.Cents(),
+		)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+// SaveOverdue sets overdue_at only, keeping the version, and never replaces a mark that
+// is already stored
+func (r *SQLPackageRepository) SaveOverdue(id string, overdueAt time.Time) error {
+	result, err := r.db.Exec(`UPDATE packages SET overdue_at = COALESCE(overdue_at, $2) WHERE id = $1`, id, overdueAt.UTC())
+	if err != nil {
+		return fmt.Errorf("saving overdue mark: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("saving overdue mark: %w", err)
+	}
+	if affected == 0 {
+		return apperr.NewNotFoundError("Package not found")
+	}
+	return nil
+}
+
+const packageColumns = `id, product, weight_kg, destination_region, destination_state,
+	status, shipping, created_at, updated_at, version, length_cm, width_cm, height_cm, overdue_at,
+	destination_cep, origin, recipient, sender, items, declared_value_cents`
+
+func (r *SQLPackageRepository) GetByID(id string) (*domain.Package, error) {
+	pkg, err := scanPackage(r.db.QueryRow(`SELECT `+packageColumns+` FROM packages WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperr.NewNotFoundError("Package not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.loadHistory(map[string]*domain.Package{pkg.ID: pkg}); err != nil {
+		return nil, err
+	}
+
+	return pkg, nil
+}
+
+func (r *SQLPackageRepository) List(query domain.PackageQuery) (*domain.PackagePage, error) {
+	where, args := listConditions(query)
+
+	sortColumn := map[domain.PackageSortField]string{
+		domain.SortByCreatedAt: "created_at",
+		domain.SortByUpdatedAt: "updated_at",
+		domain.SortByWeight:    "weight_kg",
+	}[query.SortBy]
+	direction := "ASC"
+	if query.Descending {
+		direction = "DESC"
+	}
+
+	if query.After != nil {
+		operator := ">"
+		if query.Descending {
+			operator = "<"
+		}
+		var value any = query.After.Time.UTC()
+		if query.SortBy == domain.SortByWeight {
+			value = query.After.Weight
+		}
+		args = append(args, value, query.After.ID)
+		where = append(where, fmt.Sprintf("(%s, id) %s ($%d, $%d)", sortColumn, operator, len(args)-1, len(args)))
+	}
+
+	statement := `SELECT ` + packageColumns + ` FROM packages`
+	if len(where) > 0 {
+		statement += ` WHERE ` + strings.Join(where, " AND ")
+	}
+	args = append(args, query.Limit+1)
+	statement += fmt.Sprintf(` ORDER BY %s %s, id %s LIMIT $%d`, sortColumn, direction, direction, len(args))
+
+	rows, err := r.db.Query(statement, args...)
+	if err != nil {
+		return nil, fmt.Errorf("listing packages: %w", err)
+	}
+	defer rows.Close()
+
+	packages := []*domain.Package{}
+	byID := map[string]*domain.Package{}
+	for rows.Next() {
+		pkg, err := scanPackage(rows)
+		if err != nil {
+			return nil, err
+		}
+		packages = append(packages, pkg)
+		byID[pkg.ID] = pkg
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("listing packages: %w", err)
+	}
+
+	if err := r.loadHistory(byID); err != nil {
+		return nil, err
+	}
+
+	return domain.NewPackagePage(query, packages), nil
+}
+
+// listConditions translates the filter into WHERE clauses with numbered placeholders
+func listConditions(query domain.PackageQuery) ([]string, []any) {
+	where := []string{}
+	args := []any{}
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		where = append(where, fmt.Sprintf(condition, len(args)))
+	}
+
+	filter := query.Filter
+	if len(filter.Statuses) > 0 {
+		placeholders := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			args = append(args, string(status))
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		where = append(where, "status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	if filter.Region != "" {
+		add("destination_region = $%d", string(filter.Region))
+	}
+	if filter.State != "" {
+		add("destination_state = $%d", filter.State)
+	}
+	if filter.CarrierID != "" {
+		add("carrier_id = $%d", filter.CarrierID)
+	}
+	if !filter.CreatedFrom.IsZero() {
+		add("created_at >= $%d", filter.CreatedFrom.UTC())
+	}
+	if !filter.CreatedTo.IsZero() {
+		add("created_at <= $%d", filter.CreatedTo.UTC())
+	}
+	if !filter.UpdatedFrom.IsZero() {
+		add("updated_at >= $%d", filter.UpdatedFrom.UTC())
+	}
+	if !filter.UpdatedTo.IsZero() {
+		add("updated_at <= $%d", filter.UpdatedTo.UTC())
+	}
+	if filter.MinWeightKg > 0 {
+		add("weight_kg >= $%d", filter.MinWeightKg)
+	}
+	if filter.MaxWeightKg > 0 {
+		add("weight_kg <= $%d", filter.MaxWeightKg)
+	}
+	if filter.OverdueOnly {
+		where = append(where, "overdue_at IS NOT NULL")
+	}
+
+	return where, args
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanPackage(row rowScanner) (*domain.Package, error) {
+	pkg := &domain.Package{}
+	var shipping, origin, recipient, sender, items sql.NullString
+	var overdue sql.NullTime
+	var declaredValueCents int64
+
+	err := row.Scan(
+		&pkg.ID,
+		&pkg.Product,
+		&pkg.WeightKg,
+		&pkg.DestinationRegion,
+		&pkg.DestinationState,
+		&pkg.Status,
+		&shipping,
+		&pkg.CreatedAt,
+		&pkg.UpdatedAt,
+		&pkg.Version,
+		&pkg.Dimensions.LengthCm,
+		&pkg.Dimensions.WidthCm,
+		&pkg.Dimensions.HeightCm,
+		&overdue,
+		&pkg.DestinationCEP,
+		&origin,
+		&recipient,
+		&sender,
+		&items,
+		&declaredValueCents,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading package: %w", err)
+	}
+
+	if pkg.Shipping, err = unmarshalShipping(shipping); err != nil {
+		return nil, err
+	}
+	if pkg.Origin, err = unmarshalOrigin(origin); err != nil {
+		return nil, err
+	}
+	if pkg.Recipient, err = unmarshalContact("recipient", recipient); err != nil {
+		return nil, err
+	}
+	if pkg.Sender, err = unmarshalContact("sender", sender); err != nil {
+		return nil, err
+	}
+	if pkg.Items, err = unmarshalItems(items); err != nil {
+		return nil, err
+	}
+	pkg.DeclaredValue = vo.NewMoney(declaredValueCents)
+	if pkg.DeclaredValue.IsZero() {
+		pkg.DeclaredValue = vo.ItemsValue(pkg.Items)
+	}
+	if overdue.Valid {
+		pkg.OverdueAt = &overdue.Time
+	}
+
+	return pkg, nil
+}
+
+// loadHistory fills the status history of the given packages with a single query
+func (r *SQLPackageRepository) loadHistory(packages map[string]*domain.Package) error {
+	if len(packages) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, 0, len(packages))
+	args := make([]any, 0, len(packages))
+	for id, pkg := range packages {
+		pkg.History = []domain.StatusEvent{}
+		args = append(args, id)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+	}
+
+	rows, err := r.db.Query(`
+		SELECT package_id, status, occurred_at, actor, note
+		FROM package_status_events
+		WHERE package_id IN (`+strings.Join(placeholders, ", ")+`)
+		ORDER BY package_id, seq`, args...,
+	)
+	if err != nil {
+		return fmt.Errorf("loading status events: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var packageID string
+		var event domain.StatusEvent
+		if err := rows.Scan(&packageID, &event.Status, &event.Timestamp, &event.Actor, &event.Note); err != nil {
+			return fmt.Errorf("reading status event: %w", err)
+		}
+		pkg := packages[packageID]
+		pkg.History = append(pkg.History, event)
+	}
+
+	return rows.Err()
+}
+
+func carrierID(pkg *domain.Package) sql.NullString {
+	if pkg.Shipping == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: pkg.Shipping.CarrierID, Valid: true}
+}
+
+func overdueAt(pkg *domain.Package) sql.NullTime {
+	if pkg.OverdueAt == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: pkg.OverdueAt.UTC(), Valid: true}
+}
+
+func marshalShipping(shipping *vo.Shipping) (sql.NullString, error) {
+	if shipping == nil {
+		return sql.NullString{}, nil
+	}
+
+	data, err := json.Marshal(shipping)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("encoding shipping: %w", err)
+	}
+
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+func unmarshalShipping(data sql.NullString) (*vo.Shipping, error) {
+	if !data.Valid {
+		return nil, nil
+	}
+
+	shipping := &vo.Shipping{}
+	if err := json.Unmarshal([]byte(data.String), shipping); err != nil {
+		return nil, fmt.Errorf("decoding shipping: %w", err)
+	}
+
+	return shipping, nil
+}
+
+func marshalOrigin(origin *domain.Warehouse) (sql.NullString, error) {
+	if origin == nil {
+		return sql.NullString{}, nil
+	}
+
+	data, err := json.Marshal(origin)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("encoding origin: %w", err)
+	}
+
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+func unmarshalOrigin(data sql.NullString) (*domain.Warehouse, error) {
+	if !data.Valid {
+		return nil, nil
+	}
+
+	origin := &domain.Warehouse{}
+	if err := json.Unmarshal([]byte(data.String), origin); err != nil {
+		return nil, fmt.Errorf("decoding origin: %w", err)
+	}
+
+	return origin, nil
+}
+
+// marshalContact encodes the recipient or the sender, named by column in errors
+func marshalContact(column string, contact *vo.Contact) (sql.NullString, error) {
+	if contact == nil {
+		return sql.NullString{}, nil
+	}
+
+	data, err := json.Marshal(contact)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("encoding %s: %w", column, err)
+	}
+
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+func unmarshalContact(column string, data sql.NullString) (*vo.Contact, error) {
+	if !data.Valid {
+		return nil, nil
+	}
+
+	contact := &vo.Contact{}
+	if err := json.Unmarshal([]byte(data.String), contact); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", column, err)
+	}
+
+	return contact, nil
+}
+
+func marshalItems(items []vo.Item) (sql.NullString, error) {
+	if len(items) == 0 {
+		return sql.NullString{}, nil
+	}
+
+	data, err := json.Marshal(items)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("encoding items: %w", err)
+	}
+
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+func unmarshalItems(data sql.NullString) ([]vo.Item, error) {
+	if !data.Valid {
+		return nil, nil
+	}
+
+	var items []vo.Item
+	if err := json.Unmarshal([]byte(data.String), &items); err != nil {
+		return nil, fmt.Errorf("decoding items: %w", err)
+	}
+
+	return items, nil
+}
+
+// SQLQuoteRepository implements domain.QuoteRepository on top of database/sql. Quotes
+// are deleted together with their package.
+type SQLQuoteRepository struct {
+	db *sql.DB
+}
+
+func NewSQLQuoteRepository(db *sql.DB) domain.QuoteRepository {
+	return &SQLQuoteRepository{
+		db: db,
+	}
+}
+
+func (r *SQLQuoteRepository) Save(quote *domain.Quote) error {
+	breakdown, err := json.Marshal(quote.Shipping.Breakdown)
+	if err != nil {
+		return fmt.Errorf("encoding price breakdown: %w", err)
+	}
+
+	_, err = r.db.Exec(`
+		INSERT INTO shipping_quotes (
+			id, package_id, carrier_id, carrier_name, price_cents, currency, estimated_days, created_at, expires_at,
+			price_breakdown
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		quote.ID,
+		quote.PackageID,
+		quote.Shipping.CarrierID,
+		quote.Shipping.CarrierName,
+		quote.Shipping.EstimatedPrice.Cents(),
+		string(quote.Shipping.EstimatedPrice.Currency()),
+		quote.Shipping.EstimatedDays,
+		quote.CreatedAt.UTC(),
+		quote.ExpiresAt.UTC(),
+		string(breakdown),
+	)
+	if err != nil {
+		return fmt.Errorf("saving quote: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SQLQuoteRepository) GetByID(id string) (*domain.Quote, error) {
+	quote := &domain.Quote{}
+	var priceCents int64
+	var currency string
+	var breakdown sql.NullString
+	err := r.db.QueryRow(`
+		SELECT id, package_id, carrier_id, carrier_name, price_cents, currency, estimated_days, created_at, expires_at,
+			price_breakdown
+		FROM shipping_quotes WHERE id = $1`, id,
+	).Scan(
+		&quote.ID,
+		&quote.PackageID,
+		&quote.Shipping.CarrierID,
+		&quote.Shipping.CarrierName,
+		&priceCents,
+		&currency,
+		&quote.Shipping.EstimatedDays,
+		&quote.CreatedAt,
+		&quote.ExpiresAt,
+		&breakdown,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperr.NewNotFoundError("Quote not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading quote: %w", err)
+	}
+	quote.Shipping.EstimatedPrice = vo.NewMoneyIn(priceCents, vo.Currency(currency))
+	if breakdown.Valid {
+		if err := json.Unmarshal([]byte(breakdown.String), &quote.Shipping.Breakdown); err != nil {
+			return nil, fmt.Errorf("decoding price breakdown: %w", err)
+		}
+	}
+
+	return quote, nil
+}
+
+func (r *SQLQuoteRepository) DeleteExpired(before time.Time) (int, error) {
+	result, err := r.db.Exec(`DELETE FROM shipping_quotes WHERE expires_at < $1`, before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("deleting expired quotes: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("deleting expired quotes: %w", err)
+	}
+	return int(deleted), nil
+}
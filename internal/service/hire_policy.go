@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain"
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
+	apperr "github.com/foliveiracamara/delivery-manager-api/internal/shared/apperror"
+)
+
+const (
+	PolicyCheapest = "cheapest"
+	PolicyFastest  = "fastest"
+	PolicyMaxDays  = "max_days"
+	PolicyMaxPrice = "max_price"
+)
+
+// HirePolicy escolhe o frete entre as cotações para contratar sem intervenção do cliente:
+// o mais barato, o mais rápido, o mais barato dentro de MaxDays ou o mais rápido até
+// MaxPrice. Empates seguem as regras de QuoteRanking.
+type HirePolicy struct {
+	Kind     string
+	MaxDays  int
+	MaxPrice vo.Money
+}
+
+// NewHirePolicy valida a política e seus limites
+func NewHirePolicy(kind string, maxDays int, maxPrice vo.Money) (HirePolicy, error) {
+	policy := HirePolicy{Kind: kind}
+
+	switch kind {
+	case PolicyCheapest, PolicyFastest:
+	case PolicyMaxDays:
+		if maxDays <= 0 {
+			return HirePolicy{}, apperr.NewBadRequestError("Hire policy max_days requires a maximum number of days")
+		}
+		policy.MaxDays = maxDays
+	case PolicyMaxPrice:
+		if maxPrice.Cents() <= 0 {
+			return HirePolicy{}, apperr.NewBadRequestError("Hire policy max_price requires a maximum price")
+		}
+		policy.MaxPrice = maxPrice
+	default:
+		return HirePolicy{}, apperr.NewBadRequestError("Invalid hire policy: " + kind)
+	}
+
+	return policy, nil
+}
+
+// Select escolhe o frete pela política; false quando nenhuma cotação a atende
+func (p HirePolicy) Select(shippings []vo.Shipping) (vo.Shipping, bool) {
+	candidates := slices.DeleteFunc(slices.Clone(shippings), func(s vo.Shipping) bool {
+		switch p.Kind {
+		case PolicyMaxDays:
+			return s.EstimatedDays > p.MaxDays
+		case PolicyMaxPrice:
+			return s.EstimatedPrice.Compare(p.MaxPrice) > 0
+		}
+		return false
+	})
+	if len(candidates) == 0 {
+		return vo.Shipping{}, false
+	}
+
+	var ranking QuoteRanking = CheapestRanking{}
+	if p.Kind == PolicyFastest || p.Kind == PolicyMaxPrice {
+		ranking = FastestRanking{}
+	}
+	ranking.Rank(candidates)
+
+	return candidates[0], true
+}
+
+// String descreve a política nas mensagens de erro
+func (p HirePolicy) String() string {
+	switch p.Kind {
+	case PolicyMaxDays:
+		return fmt.Sprintf("%s of %d days", p.Kind, p.MaxDays)
+	case PolicyMaxPrice:
+		return fmt.Sprintf("%s of %s", p.Kind, p.MaxPrice.Decimal())
+	}
+	return p.Kind
+}
+
+// HireByPolicy cota o pacote e contrata o frete escolhido pela política. Sem cotação que
+// atenda a política, o pacote fica sem transportadora e o erro informa quantas
+// transportadoras falharam ao cotar.
+func (s PackageService) HireByPolicy(ctx context.Context, pkg *domain.Package, policy HirePolicy, actor string) error {
+	if pkg.Shipping != nil {
+		return apperr.NewConflictError("Package already has a carrier")
+	}
+
+	shippings, failures := s.QuoteAvailableShippings(ctx, pkg, FastestRanking{})
+
+	shipping, ok := policy.Select(shippings)
+	if !ok {
+		message := "No carrier satisfies the hire policy " + policy.String()
+		if len(failures) > 0 {
+			message += fmt.Sprintf(" (%d carriers could not quote)", len(failures))
+		}
+		return apperr.NewUnprocessableEntityError(message)
+	}
+
+	s.assignShipping(pkg, shipping, actor)
+	return nil
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain"
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
+	"github.com/foliveiracamara/delivery-manager-api/internal/infrastructure/config"
+	"github.com/foliveiracamara/delivery-manager-api/internal/infrastructure/integration"
+	"github.com/foliveiracamara/delivery-manager-api/internal/infrastructure/integration/carriertest"
+	apperr "github.com/foliveiracamara/delivery-manager-api/internal/shared/apperror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHirePolicy_Select(t *testing.T) {
+	shippings := []vo.Shipping{
+		vo.NewShippingQuote("Slow", "slow", vo.NewMoney(2000), 10),
+		vo.NewShippingQuote("Express", "express", vo.NewMoney(8000), 1),
+		vo.NewShippingQuote("Standard", "standard", vo.NewMoney(2400), 3),
+		vo.NewShippingQuote("Economy", "economy", vo.NewMoney(2000), 6),
+	}
+
+	tests := []struct {
+		name     string
+		policy   HirePolicy
+		expected string
+		found    bool
+	}{
+		{name: "cheapest breaks ties by carrier ID", policy: HirePolicy{Kind: PolicyCheapest}, expected: "economy", found: true},
+		{name: "fastest", policy: HirePolicy{Kind: PolicyFastest}, expected: "express", found: true},
+		{name: "cheapest within max days", policy: HirePolicy{Kind: PolicyMaxDays, MaxDays: 5}, expected: "standard", found: true},
+		{name: "max days is inclusive", policy: HirePolicy{Kind: PolicyMaxDays, MaxDays: 6}, expected: "economy", found: true},
+		{name: "fastest within max price", policy: HirePolicy{Kind: PolicyMaxPrice, MaxPrice: vo.NewMoney(3000)}, expected: "standard", found: true},
+		{name: "max price is inclusive", policy: HirePolicy{Kind: PolicyMaxPrice, MaxPrice: vo.NewMoney(2000)}, expected: "economy", found: true},
+		{name: "no quote within max days", policy: HirePolicy{Kind: PolicyMaxDays, MaxDays: 0}, found: false},
+		{name: "no quote within max price", policy: HirePolicy{Kind: PolicyMaxPrice, MaxPrice: vo.NewMoney(1999)}, found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shipping, ok := tt.policy.Select(shippings)
+
+			assert.Equal(t, tt.found, ok)
+			assert.Equal(t, tt.expected, shipping.CarrierID)
+		})
+	}
+
+	t.Run("should not reorder the quotes", func(t *testing.T) {
+		HirePolicy{Kind: PolicyCheapest}.Select(shippings)
+
+		assert.Equal(t, []string{"slow", "express", "standard", "economy"}, carrierIDs(shippings))
+	})
+}
+
+func TestNewHirePolicy(t *testing.T) {
+	t.Run("should keep only the limit of the policy", func(t *testing.T) {
+		policy, err := NewHirePolicy(PolicyMaxDays, 5, vo.NewMoney(3000))
+
+		require.NoError(t, err)
+		assert.Equal(t, HirePolicy{Kind: PolicyMaxDays, MaxDays: 5}, policy)
+		assert.Equal(t, "max_days of 5 days", policy.String())
+	})
+
+	tests := []struct {
+		name     string
+		kind     string
+		maxDays  int
+		maxPrice vo.Money
+		expected string
+	}{
+		{name: "unknown policy", kind: "nearest", expected: "Invalid hire policy: nearest"},
+		{name: "max days without days", kind: PolicyMaxDays, maxPrice: vo.NewMoney(3000), expected: "Hire policy max_days requires a maximum number of days"},
+		{name: "max price without price", kind: PolicyMaxPrice, maxDays: 5, expected: "Hire policy max_price requires a maximum price"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewHirePolicy(tt.kind, tt.maxDays, tt.maxPrice)
+
+			var appErr *apperr.AppErr
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, http.StatusBadRequest, appErr.Code)
+			assert.Equal(t, tt.expected, appErr.Message)
+		})
+	}
+}
+
+func TestPackageService_HireByPolicy(t *testing.T) {
+	slow := carriertest.NewServer(1.0, 1)
+	defer slow.Close()
+	slow.SetDelay(time.Second)
+
+	carriers := []*integration.Carrier{
+		{ID: "fast", Name: "Fast Carrier", Regions: []integration.CarrierRegion{{Region: "sudeste", EstimatedDays: 2, PricePerKg: vo.NewMoney(1000)}}},
+		{ID: "cheap", Name: "Cheap Carrier", Regions: []integration.CarrierRegion{{Region: "sudeste", EstimatedDays: 7, PricePerKg: vo.NewMoney(400)}}},
+		{ID: "slow", Name: "Slow Carrier", QuoteURL: slow.URL, Regions: []integration.CarrierRegion{{Region: "sudeste", EstimatedDays: 1, PricePerKg: vo.NewMoney(100)}}},
+	}
+	quoter := integration.NewCarrierQuoter(integration.NewHTTPQuoter(config.CarrierHTTP{Timeout: 100 * time.Millisecond}))
+	service := NewPackageService(&MockCarrierRepository{carriers: carriers}, quoter, 0, 0, nil)
+
+	t.Run("should hire the carrier chosen by the policy", func(t *testing.T) {
+		pkg, err := domain.NewPackage("Test Product", "SP", 2.0, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+
+		err = service.HireByPolicy(context.Background(), pkg, HirePolicy{Kind: PolicyMaxDays, MaxDays: 3}, "checkout")
+
+		require.NoError(t, err)
+		assert.Equal(t, "fast", pkg.Shipping.CarrierID)
+		assert.Equal(t, vo.NewMoney(2000), pkg.Shipping.EstimatedPrice)
+		assert.Equal(t, domain.StatusWaitingPickup, pkg.Status)
+		assert.Equal(t, "checkout", pkg.History[len(pkg.History)-1].Actor)
+	})
+
+	t.Run("should fail when no carrier satisfies the policy", func(t *testing.T) {
+		pkg, err := domain.NewPackage("Test Product", "SP", 2.0, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+
+		err = service.HireByPolicy(context.Background(), pkg, HirePolicy{Kind: PolicyMaxPrice, MaxPrice: vo.NewMoney(500)}, "checkout")
+
+		var appErr *apperr.AppErr
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusUnprocessableEntity, appErr.Code)
+		assert.Equal(t, "No carrier satisfies the hire policy max_price of 5.00 (1 carriers could not quote)", appErr.Message)
+		assert.Nil(t, pkg.Shipping)
+		assert.Equal(t, domain.StatusCreated, pkg.Status)
+	})
+
+	t.Run("should reject a package that already has a carrier", func(t *testing.T) {
+		pkg, err := domain.NewPackage("Test Product", "SP", 2.0, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+		pkg.AssignShipping(vo.NewShippingQuote("Fast Carrier", "fast", vo.NewMoney(2000), 2), "checkout")
+
+		err = service.HireByPolicy(context.Background(), pkg, HirePolicy{Kind: PolicyCheapest}, "checkout")
+
+		var appErr *apperr.AppErr
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusConflict, appErr.Code)
+	})
+}
@@ -1,6 +1,12 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
 	"github.com/foliveiracamara/delivery-manager-api/internal/domain"
 	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
 	"github.com/foliveiracamara/delivery-manager-api/internal/infrastructure/integration"
@@ -9,17 +15,34 @@ import (
 
 // PackageService represents the package service
 type PackageService struct {
-	carrierRepo integration.CarrierRepository
+	carrierRepo   integration.CarrierRepository
+	quoter        integration.ShippingQuoter
+	quoteTimeout  time.Duration
+	quoteValidity time.Duration
+	calendar      domain.HolidayCalendar
 }
 
-// NewPackageService creates a new instance of PackageService
-func NewPackageService(carrierRepo integration.CarrierRepository) *PackageService {
+// NewPackageService creates a new instance of PackageService. quoteTimeout bounds the
+// quote of each carrier; 0 leaves it to the caller context. quoteValidity is how long a
+// quote can be hired; 0 uses domain.DefaultQuoteValidity. calendar sets the holidays
+// skipped by the delivery date; nil skips only weekends.
+func NewPackageService(carrierRepo integration.CarrierRepository, quoter integration.ShippingQuoter, quoteTimeout, quoteValidity time.Duration, calendar domain.HolidayCalendar) *PackageService {
+	if quoteValidity <= 0 {
+		quoteValidity = domain.DefaultQuoteValidity
+	}
+
 	return &PackageService{
-		carrierRepo: carrierRepo,
+		carrierRepo:   carrierRepo,
+		quoter:        quoter,
+		quoteTimeout:  quoteTimeout,
+		quoteValidity: quoteValidity,
+		calendar:      calendar,
 	}
 }
 
 func (s PackageService) Create(pkg *domain.Package) (*domain.Package, error) {
+	dimensions, cep, origin := pkg.Dimensions, pkg.DestinationCEP, pkg.Origin
+	recipient, sender, items, declaredValue := pkg.Recipient, pkg.Sender, pkg.Items, pkg.DeclaredValue
 	pkg, err := domain.NewPackage(
 		pkg.Product,
 		pkg.DestinationState,
@@ -30,43 +53,140 @@ func (s PackageService) Create(pkg *domain.Package) (*domain.Package, error) {
 		return nil, err
 	}
 
+	if err := pkg.SetDimensions(dimensions); err != nil {
+		return nil, err
+	}
+
+	if !declaredValue.IsZero() {
+		if err := pkg.SetDeclaredValue(declaredValue); err != nil {
+			return nil, err
+		}
+	}
+
+	if items != nil {
+		if err := pkg.SetItems(items); err != nil {
+			return nil, err
+		}
+	}
+	if pkg.WeightKg <= 0 {
+		return nil, apperr.NewBadRequestError("Package weight must be greater than zero")
+	}
+
+	if !cep.IsZero() {
+		if err := pkg.SetDestinationCEP(cep); err != nil {
+			return nil, err
+		}
+	}
+
+	if origin != nil {
+		pkg.SetOrigin(*origin)
+	}
+
+	if recipient != nil {
+		if err := pkg.SetRecipient(*recipient); err != nil {
+			return nil, err
+		}
+	}
+
+	if sender != nil {
+		if err := pkg.SetSender(*sender); err != nil {
+			return nil, err
+		}
+	}
+
 	return pkg, nil
 }
 
-func (s PackageService) UpdateStatus(pkg *domain.Package, status domain.PackageStatus) error {
-	return pkg.UpdateStatus(status)
+func (s PackageService) UpdateStatus(pkg *domain.Package, status domain.PackageStatus, actor, note string) error {
+	return pkg.UpdateStatus(status, actor, note)
 }
 
-func (s PackageService) QuoteAvailableShippings(pkg *domain.Package) ([]vo.Shipping, error) {
+// QuoteAvailableShippings cota o pacote em paralelo com as transportadoras ativas que
+// atendem a rota e o peso do pacote e devolve as cotações na ordem de ranking. As que
+// falham ou não respondem a tempo são devolvidas em failures, sem impedir as demais.
+func (s PackageService) QuoteAvailableShippings(ctx context.Context, pkg *domain.Package, ranking QuoteRanking) ([]vo.Shipping, []vo.QuoteFailure) {
 	availableCarriers := []*integration.Carrier{}
 	allCarriers := s.carrierRepo.GetAll()
-	destinationRegion := string(pkg.DestinationRegion)
+	originRegion, destinationRegion := string(pkg.OriginRegion()), string(pkg.DestinationRegion)
 
 	for _, carrier := range allCarriers {
-		if carrier.IsAvailableForRegion(destinationRegion) {
+		if carrier.IsActive() && carrier.IsAvailableFor(originRegion, destinationRegion, pkg.DestinationCEP) && carrier.AcceptsWeight(pkg.WeightKg) {
 			availableCarriers = append(availableCarriers, carrier)
 		}
 	}
 
+	type outcome struct {
+		shipping vo.Shipping
+		err      error
+	}
+	outcomes := make([]outcome, len(availableCarriers))
+	request := shippingRequest(pkg)
+
+	var wg sync.WaitGroup
+	for i, carrier := range availableCarriers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			outcomes[i].shipping, outcomes[i].err = s.quote(ctx, carrier, request)
+		}()
+	}
+	wg.Wait()
+
 	shippings := []vo.Shipping{}
-	for _, carrier := range availableCarriers {
-		price, days, ok := carrier.CalculateShipping(destinationRegion, pkg.WeightKg)
-		if !ok {
-			return nil, apperr.NewInternalServerError("Failed to calculate shipping")
+	failures := []vo.QuoteFailure{}
+	for i, outcome := range outcomes {
+		if outcome.err != nil {
+			failures = append(failures, vo.QuoteFailure{
+				CarrierID:   availableCarriers[i].ID,
+				CarrierName: availableCarriers[i].Name,
+				Reason:      quoteFailureReason(outcome.err),
+			})
+			continue
 		}
-		shippings = append(shippings, vo.NewShippingQuote(
-			carrier.Name,
-			carrier.ID,
-			price,
-			days,
-		))
+		shippings = append(shippings, outcome.shipping)
 	}
 
-	sortedShippings := pkg.SortShippingsByDeliveryTime(shippings)
-	return sortedShippings, nil
+	ranking.Rank(shippings)
+	return shippings, failures
+}
+
+// NewQuotes registra as cotações obtidas para que possam ser contratadas pelo ID
+func (s PackageService) NewQuotes(pkg *domain.Package, shippings []vo.Shipping) []*domain.Quote {
+	now := time.Now()
+	quotes := make([]*domain.Quote, len(shippings))
+	for i, shipping := range shippings {
+		quotes[i] = domain.NewQuote(pkg.ID, shipping, s.quoteValidity, now)
+	}
+	return quotes
 }
 
-func (s PackageService) HireCarrier(pkg *domain.Package, carrierID string) error {
+// HireQuote contrata o frete pelo preço e prazo da cotação, sem cotar novamente. A
+// transportadora precisa continuar ativa, mas alterações de preço no catálogo não afetam
+// cotações dentro da validade.
+func (s PackageService) HireQuote(pkg *domain.Package, quote *domain.Quote, actor string) error {
+	if pkg.Shipping != nil {
+		return apperr.NewConflictError("Package already has a carrier")
+	}
+
+	if err := quote.CheckHire(pkg, time.Now()); err != nil {
+		return err
+	}
+
+	carrier, err := s.carrierRepo.GetByID(quote.Shipping.CarrierID)
+	if err != nil {
+		return err
+	}
+
+	if !carrier.IsActive() {
+		return apperr.NewBadRequestError("Carrier is inactive")
+	}
+
+	s.assignShipping(pkg, quote.Shipping, actor)
+
+	return nil
+}
+
+func (s PackageService) HireCarrier(ctx context.Context, pkg *domain.Package, carrierID, actor string) error {
 	if pkg.Shipping != nil {
 		return apperr.NewConflictError("Package already has a carrier")
 	}
@@ -76,23 +196,75 @@ func (s PackageService) HireCarrier(pkg *domain.Package, carrierID string) error
 		return err
 	}
 
-	destinationRegion := string(pkg.DestinationRegion)
+	if !carrier.IsActive() {
+		return apperr.NewBadRequestError("Carrier is inactive")
+	}
+
+	originRegion, destinationRegion := string(pkg.OriginRegion()), string(pkg.DestinationRegion)
 	if !carrier.IsAvailableForRegion(destinationRegion) {
 		return apperr.NewBadRequestError("Carrier does not serve the destination region")
 	}
 
-	price, days, ok := carrier.CalculateShipping(destinationRegion, pkg.WeightKg)
-	if !ok {
-		return apperr.NewInternalServerError("Failed to calculate shipping")
+	if !carrier.IsAvailableFor(originRegion, destinationRegion, "") {
+		return apperr.NewBadRequestError("Carrier does not ship from the origin region to the destination region")
 	}
 
-	shipping := vo.NewShippingQuote(
-		carrier.Name,
-		carrier.ID,
-		price,
-		days,
-	)
-	pkg.AssignShipping(shipping)
+	if !carrier.IsAvailableFor(originRegion, destinationRegion, pkg.DestinationCEP) {
+		return apperr.NewBadRequestError("Carrier does not serve the destination CEP")
+	}
+
+	if !carrier.AcceptsWeight(pkg.WeightKg) {
+		return apperr.NewBadRequestError(fmt.Sprintf("Carrier does not accept packages over %g kg", carrier.MaxWeightKg))
+	}
+
+	shipping, err := s.quote(ctx, carrier, shippingRequest(pkg))
+	if err != nil {
+		return apperr.NewServiceUnavailableError("Carrier could not quote the shipping: " + quoteFailureReason(err))
+	}
+	s.assignShipping(pkg, shipping, actor)
 
 	return nil
 }
+
+// assignShipping contrata o frete prometendo a entrega em EstimatedDays dias úteis a
+// partir de agora, conforme os feriados do estado de destino
+func (s PackageService) assignShipping(pkg *domain.Package, shipping vo.Shipping, actor string) {
+	shipping.EstimatedDeliveryDate = domain.AddBusinessDays(s.calendar, time.Now(), shipping.EstimatedDays, pkg.DestinationState)
+	pkg.AssignShipping(shipping, actor)
+}
+
+// quote limita a cotação de uma transportadora ao prazo configurado
+func (s PackageService) quote(ctx context.Context, carrier *integration.Carrier, req vo.ShippingRequest) (vo.Shipping, error) {
+	if s.quoteTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.quoteTimeout)
+		defer cancel()
+	}
+
+	return s.quoter.Quote(ctx, carrier, req)
+}
+
+func quoteFailureReason(err error) string {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return "Carrier did not answer in time"
+	case errors.Is(err, context.Canceled):
+		return "Quote canceled"
+	case errors.Is(err, integration.ErrCarrierUnavailable):
+		return "Carrier temporarily unavailable"
+	default:
+		return err.Error()
+	}
+}
+
+func shippingRequest(pkg *domain.Package) vo.ShippingRequest {
+	req := vo.NewShippingRequest(pkg.WeightKg, pkg.Dimensions, pkg.DestinationState, string(pkg.DestinationRegion))
+	req.DestinationCEP = pkg.DestinationCEP
+	req.DeclaredValue = pkg.DeclaredValue
+	if pkg.Origin != nil {
+		req.OriginState = pkg.Origin.State
+		req.OriginRegion = string(pkg.Origin.Region())
+		req.OriginCEP = pkg.Origin.CEP
+	}
+	return req
+}
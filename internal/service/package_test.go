@@ -1,10 +1,17 @@
 package service
 
 import (
+	"context"
+	"fmt"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/foliveiracamara/delivery-manager-api/internal/domain"
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
+	"github.com/foliveiracamara/delivery-manager-api/internal/infrastructure/config"
 	"github.com/foliveiracamara/delivery-manager-api/internal/infrastructure/integration"
+	"github.com/foliveiracamara/delivery-manager-api/internal/infrastructure/integration/carriertest"
 	apperr "github.com/foliveiracamara/delivery-manager-api/internal/shared/apperror"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -50,6 +57,35 @@ func TestPackageService_Create(t *testing.T) {
 		assert.Equal(t, domain.DestinationRegionSoutheast, result.DestinationRegion)
 		assert.Equal(t, domain.StatusCreated, result.Status)
 	})
+
+	t.Run("should keep the package dimensions", func(t *testing.T) {
+		pkg := &domain.Package{
+			Product:           "Poltrona",
+			DestinationState:  "SP",
+			WeightKg:          15,
+			Dimensions:        vo.NewDimensions(100, 80, 90),
+			DestinationRegion: domain.DestinationRegionSoutheast,
+		}
+
+		result, err := service.Create(pkg)
+
+		require.NoError(t, err)
+		assert.Equal(t, vo.NewDimensions(100, 80, 90), result.Dimensions)
+	})
+
+	t.Run("should reject incomplete dimensions", func(t *testing.T) {
+		pkg := &domain.Package{
+			Product:           "Poltrona",
+			DestinationState:  "SP",
+			WeightKg:          15,
+			Dimensions:        vo.NewDimensions(100, 0, 90),
+			DestinationRegion: domain.DestinationRegionSoutheast,
+		}
+
+		_, err := service.Create(pkg)
+
+		assert.ErrorContains(t, err, "Package dimensions must all be greater than zero")
+	})
 }
 
 func TestPackageService_UpdateStatus(t *testing.T) {
@@ -58,10 +94,28 @@ func TestPackageService_UpdateStatus(t *testing.T) {
 	t.Run("should update status successfully", func(t *testing.T) {
 		pkg, err := domain.NewPackage("Test Product", "SP", 2.5, domain.DestinationRegionSoutheast)
 		require.NoError(t, err)
+		pkg.AssignShipping(vo.NewShippingQuote("Test Carrier", "test-carrier", vo.NewMoney(2550), 5), "operator")
 
-		err = service.UpdateStatus(pkg, domain.StatusWaitingPickup)
+		err = service.UpdateStatus(pkg, domain.StatusCollected, "scanner", "Coletado no CD")
 
 		assert.NoError(t, err)
+		assert.Equal(t, domain.StatusCollected, pkg.Status)
+		last := pkg.History[len(pkg.History)-1]
+		assert.Equal(t, domain.StatusCollected, last.Status)
+		assert.Equal(t, "scanner", last.Actor)
+		assert.Equal(t, "Coletado no CD", last.Note)
+	})
+
+	t.Run("should reject transitions outside the status flow", func(t *testing.T) {
+		pkg, err := domain.NewPackage("Test Product", "SP", 2.5, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+		pkg.AssignShipping(vo.NewShippingQuote("Test Carrier", "test-carrier", vo.NewMoney(2550), 5), "operator")
+
+		err = service.UpdateStatus(pkg, domain.StatusDelivered, "", "")
+
+		var transitionErr *domain.InvalidStatusTransitionError
+		assert.ErrorAs(t, err, &transitionErr)
+		assert.Equal(t, []domain.PackageStatus{domain.StatusCollected}, transitionErr.Allowed)
 		assert.Equal(t, domain.StatusWaitingPickup, pkg.Status)
 	})
 }
@@ -76,7 +130,7 @@ func TestPackageService_QuoteAvailableShippings(t *testing.T) {
 				{
 					Region:        "sudeste",
 					EstimatedDays: 3,
-					PricePerKg:    10.0,
+					PricePerKg:    vo.NewMoney(1000),
 				},
 			},
 		},
@@ -87,7 +141,19 @@ func TestPackageService_QuoteAvailableShippings(t *testing.T) {
 				{
 					Region:        "sudeste",
 					EstimatedDays: 7,
-					PricePerKg:    8.0,
+					PricePerKg:    vo.NewMoney(800),
+				},
+			},
+		},
+		{
+			ID:       "carrier4",
+			Name:     "Inactive Carrier",
+			Inactive: true,
+			Regions: []integration.CarrierRegion{
+				{
+					Region:        "sudeste",
+					EstimatedDays: 1,
+					PricePerKg:    vo.NewMoney(100),
 				},
 			},
 		},
@@ -98,46 +164,44 @@ func TestPackageService_QuoteAvailableShippings(t *testing.T) {
 				{
 					Region:        "sul",
 					EstimatedDays: 5,
-					PricePerKg:    9.0,
+					PricePerKg:    vo.NewMoney(900),
 				},
 			},
 		},
 	}
 
 	mockRepo := &MockCarrierRepository{carriers: mockCarriers}
-	service := &PackageService{carrierRepo: mockRepo}
+	service := NewPackageService(mockRepo, integration.TableQuoter{}, 0, 0, nil)
 
 	t.Run("should quote available shippings for southeast region", func(t *testing.T) {
 		pkg, err := domain.NewPackage("Test Product", "SP", 2.0, domain.DestinationRegionSoutheast)
 		require.NoError(t, err)
 
-		shippings, err := service.QuoteAvailableShippings(pkg)
+		shippings, _ := service.QuoteAvailableShippings(context.Background(), pkg, FastestRanking{})
 
-		assert.NoError(t, err)
-		assert.Len(t, shippings, 2) // Only carriers that serve southeast
+		assert.Len(t, shippings, 2) // Only active carriers that serve southeast
 
 		// Should be sorted by delivery time (fastest first)
 		assert.Equal(t, "Fast Carrier", shippings[0].CarrierName)
 		assert.Equal(t, 3, shippings[0].EstimatedDays)
-		assert.Equal(t, 20.0, shippings[0].EstimatedPrice) // 2.0 * 10.0
+		assert.Equal(t, vo.NewMoney(2000), shippings[0].EstimatedPrice) // 2.0 * 10.0
 
 		assert.Equal(t, "Slow Carrier", shippings[1].CarrierName)
 		assert.Equal(t, 7, shippings[1].EstimatedDays)
-		assert.Equal(t, 16.0, shippings[1].EstimatedPrice) // 2.0 * 8.0
+		assert.Equal(t, vo.NewMoney(1600), shippings[1].EstimatedPrice) // 2.0 * 8.0
 	})
 
 	t.Run("should handle minimum price correctly", func(t *testing.T) {
 		pkg, err := domain.NewPackage("Test Product", "SP", 0.5, domain.DestinationRegionSoutheast) // Very light package
 		require.NoError(t, err)
 
-		shippings, err := service.QuoteAvailableShippings(pkg)
+		shippings, _ := service.QuoteAvailableShippings(context.Background(), pkg, FastestRanking{})
 
-		assert.NoError(t, err)
 		assert.Len(t, shippings, 2)
 
 		// Price should be at least the price per kg (minimum price)
-		assert.Equal(t, 10.0, shippings[0].EstimatedPrice) // Minimum price, not 0.5 * 10.0
-		assert.Equal(t, 8.0, shippings[1].EstimatedPrice)  // Minimum price, not 0.5 * 8.0
+		assert.Equal(t, vo.NewMoney(1000), shippings[0].EstimatedPrice) // Minimum price, not 0.5 * 10.0
+		assert.Equal(t, vo.NewMoney(800), shippings[1].EstimatedPrice)  // Minimum price, not 0.5 * 8.0
 	})
 }
 
@@ -151,26 +215,26 @@ func TestPackageService_HireCarrier(t *testing.T) {
 				{
 					Region:        "sudeste",
 					EstimatedDays: 5,
-					PricePerKg:    10.0,
+					PricePerKg:    vo.NewMoney(1000),
 				},
 			},
 		},
 	}
 
 	mockRepo := &MockCarrierRepository{carriers: mockCarriers}
-	service := &PackageService{carrierRepo: mockRepo}
+	service := NewPackageService(mockRepo, integration.TableQuoter{}, 0, 0, nil)
 
 	t.Run("should hire carrier successfully", func(t *testing.T) {
 		pkg, err := domain.NewPackage("Test Product", "SP", 2.0, domain.DestinationRegionSoutheast)
 		require.NoError(t, err)
 
-		err = service.HireCarrier(pkg, "carrier1")
+		err = service.HireCarrier(context.Background(), pkg, "carrier1", "")
 
 		assert.NoError(t, err)
 		assert.NotNil(t, pkg.Shipping)
 		assert.Equal(t, "Test Carrier", pkg.Shipping.CarrierName)
 		assert.Equal(t, "carrier1", pkg.Shipping.CarrierID)
-		assert.Equal(t, 20.0, pkg.Shipping.EstimatedPrice) // 2.0 * 10.0
+		assert.Equal(t, vo.NewMoney(2000), pkg.Shipping.EstimatedPrice) // 2.0 * 10.0
 		assert.Equal(t, 5, pkg.Shipping.EstimatedDays)
 		assert.Equal(t, domain.StatusWaitingPickup, pkg.Status)
 	})
@@ -180,11 +244,11 @@ func TestPackageService_HireCarrier(t *testing.T) {
 		require.NoError(t, err)
 
 		// Assign first carrier
-		err = service.HireCarrier(pkg, "carrier1")
+		err = service.HireCarrier(context.Background(), pkg, "carrier1", "")
 		require.NoError(t, err)
 
 		// Try to assign second carrier
-		err = service.HireCarrier(pkg, "carrier1")
+		err = service.HireCarrier(context.Background(), pkg, "carrier1", "")
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "Package already has a carrier")
@@ -194,7 +258,7 @@ func TestPackageService_HireCarrier(t *testing.T) {
 		pkg, err := domain.NewPackage("Test Product", "SP", 2.0, domain.DestinationRegionSoutheast)
 		require.NoError(t, err)
 
-		err = service.HireCarrier(pkg, "nonexistent")
+		err = service.HireCarrier(context.Background(), pkg, "nonexistent", "")
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "Carrier not found")
@@ -209,20 +273,560 @@ func TestPackageService_HireCarrier(t *testing.T) {
 				{
 					Region:        "sul",
 					EstimatedDays: 5,
-					PricePerKg:    10.0,
+					PricePerKg:    vo.NewMoney(1000),
 				},
 			},
 		}
 
 		mockRepoWithSouth := &MockCarrierRepository{carriers: []*integration.Carrier{southCarrier}}
-		serviceWithSouth := &PackageService{carrierRepo: mockRepoWithSouth}
+		serviceWithSouth := NewPackageService(mockRepoWithSouth, integration.TableQuoter{}, 0, 0, nil)
 
 		pkg, err := domain.NewPackage("Test Product", "SP", 2.0, domain.DestinationRegionSoutheast) // Southeast region
 		require.NoError(t, err)
 
-		err = serviceWithSouth.HireCarrier(pkg, "south-carrier")
+		err = serviceWithSouth.HireCarrier(context.Background(), pkg, "south-carrier", "")
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "Carrier does not serve the destination region")
 	})
+	t.Run("should fail when carrier is inactive", func(t *testing.T) {
+		inactiveCarrier := &integration.Carrier{
+			ID:       "inactive-carrier",
+			Name:     "Inactive Carrier",
+			Inactive: true,
+			Regions: []integration.CarrierRegion{
+				{
+					Region:        "sudeste",
+					EstimatedDays: 5,
+					PricePerKg:    vo.NewMoney(1000),
+				},
+			},
+		}
+
+		serviceWithInactive := NewPackageService(&MockCarrierRepository{carriers: []*integration.Carrier{inactiveCarrier}}, integration.TableQuoter{}, 0, 0, nil)
+
+		pkg, err := domain.NewPackage("Test Product", "SP", 2.0, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+
+		err = serviceWithInactive.HireCarrier(context.Background(), pkg, "inactive-carrier", "")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "Carrier is inactive")
+		assert.Nil(t, pkg.Shipping)
+	})
+}
+
+// Móveis volumosos e leves são cobrados pelo peso cúbico na cotação e na contratação
+// stateHolidays marca como feriado todos os dias úteis de um estado
+type stateHolidays string
+
+func (s stateHolidays) IsHoliday(day time.Time, state string) bool {
+	return state == string(s) && day.Weekday() != time.Friday
+}
+
+func TestPackageService_DeliveryDate(t *testing.T) {
+	carriers := []*integration.Carrier{
+		{ID: "carrier1", Name: "Test Carrier", Regions: []integration.CarrierRegion{{Region: "sudeste", EstimatedDays: 3, PricePerKg: vo.NewMoney(1000)}}},
+	}
+	calendar := stateHolidays("SP")
+	service := NewPackageService(&MockCarrierRepository{carriers: carriers}, integration.TableQuoter{}, 0, 0, calendar)
+
+	tests := []struct {
+		state    string
+		weekdays []time.Weekday
+		minDays  int
+	}{
+		// em SP só as sextas são dias úteis: a terceira sexta depois da contratação
+		{state: "SP", weekdays: []time.Weekday{time.Friday}, minDays: 14},
+		{state: "RJ", weekdays: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}, minDays: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.state, func(t *testing.T) {
+			pkg, err := domain.NewPackage("Test Product", tt.state, 2.0, domain.DestinationRegionSoutheast)
+			require.NoError(t, err)
+
+			err = service.HireCarrier(context.Background(), pkg, "carrier1", "")
+
+			require.NoError(t, err)
+			delivery := pkg.Shipping.EstimatedDeliveryDate
+			assert.Equal(t, domain.AddBusinessDays(calendar, pkg.UpdatedAt, 3, tt.state), delivery)
+			assert.Contains(t, tt.weekdays, delivery.Weekday())
+			assert.True(t, delivery.After(pkg.UpdatedAt.AddDate(0, 0, tt.minDays)))
+			assert.False(t, pkg.IsOverdue(pkg.UpdatedAt))
+		})
+	}
+}
+
+func TestPackageService_CubicWeight(t *testing.T) {
+	carriers := []*integration.Carrier{
+		{ID: "road", Name: "Road Carrier", Regions: []integration.CarrierRegion{{Region: "sudeste", EstimatedDays: 5, PricePerKg: vo.NewMoney(200), CubingFactor: 300}}},
+		{ID: "actual", Name: "Actual Weight Carrier", Regions: []integration.CarrierRegion{{Region: "sudeste", EstimatedDays: 7, PricePerKg: vo.NewMoney(200)}}},
+	}
+	service := NewPackageService(&MockCarrierRepository{carriers: carriers}, integration.TableQuoter{}, 0, 0, nil)
+
+	newArmchair := func(t *testing.T) *domain.Package {
+		pkg, err := domain.NewPackage("Poltrona", "SP", 15, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+		require.NoError(t, pkg.SetDimensions(vo.NewDimensions(100, 80, 90)))
+		return pkg
+	}
+
+	t.Run("should quote the cubic weight", func(t *testing.T) {
+		shippings, _ := service.QuoteAvailableShippings(context.Background(), newArmchair(t), FastestRanking{})
+
+		require.Len(t, shippings, 2)
+		assert.Equal(t, vo.NewMoney(43200), shippings[0].EstimatedPrice) // 0,72 m³ x 300 kg/m³ x 2,00
+		assert.Equal(t, vo.NewMoney(3000), shippings[1].EstimatedPrice)  // 15 kg x 2,00
+	})
+
+	t.Run("should hire at the cubic weight", func(t *testing.T) {
+		pkg := newArmchair(t)
+
+		err := service.HireCarrier(context.Background(), pkg, "road", "")
+
+		require.NoError(t, err)
+		assert.Equal(t, vo.NewMoney(43200), pkg.Shipping.EstimatedPrice)
+	})
+}
+
+// Tabelas por faixa de peso e limite de peso por transportadora
+func TestPackageService_WeightBands(t *testing.T) {
+	carriers := []*integration.Carrier{
+		{
+			ID:          "small",
+			Name:        "Small Parcels",
+			MaxWeightKg: 30,
+			Regions: []integration.CarrierRegion{{
+				Region:        "sudeste",
+				EstimatedDays: 4,
+				WeightBands: []integration.WeightBand{
+					{UpToKg: 1, FixedPrice: vo.NewMoney(1290)},
+					{UpToKg: 5, FixedPrice: vo.NewMoney(1290), PricePerKg: vo.NewMoney(435)},
+					{FixedPrice: vo.NewMoney(3030), PricePerKg: vo.NewMoney(390)},
+				},
+			}},
+		},
+		{ID: "heavy", Name: "Heavy Cargo", Regions: []integration.CarrierRegion{{Region: "sudeste", EstimatedDays: 8, PricePerKg: vo.NewMoney(200), MinimumCharge: vo.NewMoney(2500)}}},
+	}
+	service := NewPackageService(&MockCarrierRepository{carriers: carriers}, integration.TableQuoter{}, 0, 0, nil)
+
+	t.Run("should quote by weight band and minimum charge", func(t *testing.T) {
+		pkg, err := domain.NewPackage("Test Product", "SP", 3, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+
+		shippings, _ := service.QuoteAvailableShippings(context.Background(), pkg, FastestRanking{})
+
+		require.Len(t, shippings, 2)
+		assert.Equal(t, vo.NewMoney(2160), shippings[0].EstimatedPrice) // 12,90 + 2 kg x 4,35
+		assert.Equal(t, vo.NewMoney(2500), shippings[1].EstimatedPrice) // valor mínimo acima de 3 kg x 2,00
+	})
+
+	t.Run("should leave out carriers below the package weight", func(t *testing.T) {
+		pkg, err := domain.NewPackage("Test Product", "SP", 45, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+
+		shippings, failures := service.QuoteAvailableShippings(context.Background(), pkg, FastestRanking{})
+
+		assert.Empty(t, failures)
+		require.Len(t, shippings, 1)
+		assert.Equal(t, "heavy", shippings[0].CarrierID)
+	})
+
+	t.Run("should not hire a carrier over its maximum weight", func(t *testing.T) {
+		pkg, err := domain.NewPackage("Test Product", "SP", 45, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+
+		err = service.HireCarrier(context.Background(), pkg, "small", "")
+
+		var appErr *apperr.AppErr
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+		assert.Contains(t, err.Error(), "Carrier does not accept packages over 30 kg")
+		assert.Nil(t, pkg.Shipping)
+	})
+}
+
+func TestPackageService_DestinationCEP(t *testing.T) {
+	carriers := []*integration.Carrier{
+		{
+			ID:   "capital",
+			Name: "Capital Express",
+			Regions: []integration.CarrierRegion{{
+				Region:        "sudeste",
+				EstimatedDays: 4,
+				PricePerKg:    vo.NewMoney(590),
+				CEPRanges: []integration.CEPRange{
+					{Name: "capital-sp", From: "01000000", To: "05999999", EstimatedDays: 2, PricePerKg: vo.NewMoney(490)},
+					{Name: "litoral-sp", From: "11000000", To: "11999999", Unserved: true},
+				},
+			}},
+		},
+		{ID: "national", Name: "National Cargo", Regions: []integration.CarrierRegion{{Region: "sudeste", EstimatedDays: 6, PricePerKg: vo.NewMoney(400)}}},
+	}
+	service := NewPackageService(&MockCarrierRepository{carriers: carriers}, integration.TableQuoter{}, 0, 0, nil)
+
+	newPackage := func(t *testing.T, cep vo.CEP) *domain.Package {
+		pkg, err := domain.NewPackage("Test Product", "SP", 2, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+		require.NoError(t, pkg.SetDestinationCEP(cep))
+		return pkg
+	}
+
+	t.Run("should quote the CEP range", func(t *testing.T) {
+		shippings, _ := service.QuoteAvailableShippings(context.Background(), newPackage(t, "01310100"), FastestRanking{})
+
+		require.Len(t, shippings, 2)
+		assert.Equal(t, "capital", shippings[0].CarrierID)
+		assert.Equal(t, vo.NewMoney(980), shippings[0].EstimatedPrice)
+		assert.Equal(t, 2, shippings[0].EstimatedDays)
+	})
+
+	t.Run("should leave out carriers that do not serve the CEP", func(t *testing.T) {
+		shippings, failures := service.QuoteAvailableShippings(context.Background(), newPackage(t, "11010000"), FastestRanking{})
+
+		assert.Empty(t, failures)
+		require.Len(t, shippings, 1)
+		assert.Equal(t, "national", shippings[0].CarrierID)
+	})
+
+	t.Run("should not hire a carrier that does not serve the CEP", func(t *testing.T) {
+		pkg := newPackage(t, "11010000")
+
+		err := service.HireCarrier(context.Background(), pkg, "capital", "")
+
+		var appErr *apperr.AppErr
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+		assert.Contains(t, err.Error(), "Carrier does not serve the destination CEP")
+		assert.Nil(t, pkg.Shipping)
+	})
+
+	t.Run("should keep a valid CEP on create", func(t *testing.T) {
+		result, err := service.Create(&domain.Package{
+			Product:           "Test Product",
+			DestinationState:  "SP",
+			DestinationCEP:    "01310100",
+			WeightKg:          2,
+			DestinationRegion: domain.DestinationRegionSoutheast,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, vo.CEP("01310100"), result.DestinationCEP)
+	})
+}
+
+func TestPackageService_Origin(t *testing.T) {
+	carriers := []*integration.Carrier{
+		{
+			ID:   "southern",
+			Name: "Southern Express",
+			Regions: []integration.CarrierRegion{{
+				Region:        "sul",
+				EstimatedDays: 3,
+				PricePerKg:    vo.NewMoney(590),
+				Origins:       []integration.OriginRate{{Origin: "nordeste", Unserved: true}},
+			}},
+		},
+		{
+			ID:   "national",
+			Name: "National Cargo",
+			Regions: []integration.CarrierRegion{{
+				Region:        "sul",
+				EstimatedDays: 4,
+				PricePerKg:    vo.NewMoney(500),
+				Origins:       []integration.OriginRate{{Origin: "nordeste", EstimatedDays: 9, PricePerKg: vo.NewMoney(890)}},
+			}},
+		},
+	}
+	service := NewPackageService(&MockCarrierRepository{carriers: carriers}, integration.TableQuoter{}, 0, 0, nil)
+	recife := domain.Warehouse{ID: "recife", Name: "CD Recife", State: "PE", CEP: "50050000"}
+
+	newPackage := func(t *testing.T, origin *domain.Warehouse) *domain.Package {
+		pkg, err := domain.NewPackage("Test Product", "PR", 2, domain.DestinationRegionSouth)
+		require.NoError(t, err)
+		if origin != nil {
+			pkg.SetOrigin(*origin)
+		}
+		return pkg
+	}
+
+	t.Run("should quote the origin rates", func(t *testing.T) {
+		shippings, failures := service.QuoteAvailableShippings(context.Background(), newPackage(t, &recife), FastestRanking{})
+
+		assert.Empty(t, failures)
+		require.Len(t, shippings, 1)
+		assert.Equal(t, "national", shippings[0].CarrierID)
+		assert.Equal(t, vo.NewMoney(1780), shippings[0].EstimatedPrice)
+		assert.Equal(t, 9, shippings[0].EstimatedDays)
+	})
+
+	t.Run("should quote the region prices without origin", func(t *testing.T) {
+		shippings, _ := service.QuoteAvailableShippings(context.Background(), newPackage(t, nil), FastestRanking{})
+
+		require.Len(t, shippings, 2)
+		assert.Equal(t, vo.NewMoney(1180), shippings[0].EstimatedPrice)
+		assert.Equal(t, vo.NewMoney(1000), shippings[1].EstimatedPrice)
+	})
+
+	t.Run("should not hire a carrier that does not ship from the origin", func(t *testing.T) {
+		pkg := newPackage(t, &recife)
+
+		err := service.HireCarrier(context.Background(), pkg, "southern", "")
+
+		var appErr *apperr.AppErr
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+		assert.Contains(t, err.Error(), "Carrier does not ship from the origin region to the destination region")
+		assert.Nil(t, pkg.Shipping)
+	})
+
+	t.Run("should keep the origin on create", func(t *testing.T) {
+		result, err := service.Create(newPackage(t, &recife))
+
+		require.NoError(t, err)
+		assert.Equal(t, &recife, result.Origin)
+	})
+}
+
+func TestPackageService_HireQuote(t *testing.T) {
+	carriers := []*integration.Carrier{
+		{ID: "active", Name: "Active Carrier", Regions: []integration.CarrierRegion{{Region: "sudeste", EstimatedDays: 5, PricePerKg: vo.NewMoney(1000)}}},
+		{ID: "inactive", Name: "Inactive Carrier", Inactive: true, Regions: []integration.CarrierRegion{{Region: "sudeste", EstimatedDays: 3, PricePerKg: vo.NewMoney(800)}}},
+	}
+	service := NewPackageService(&MockCarrierRepository{carriers: carriers}, integration.TableQuoter{}, 0, time.Hour, nil)
+
+	t.Run("should register quotes with the configured validity", func(t *testing.T) {
+		pkg, err := domain.NewPackage("Test Product", "SP", 2.0, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+
+		shippings, _ := service.QuoteAvailableShippings(context.Background(), pkg, FastestRanking{})
+		quotes := service.NewQuotes(pkg, shippings)
+
+		require.Len(t, quotes, 1)
+		assert.Equal(t, pkg.ID, quotes[0].PackageID)
+		assert.Equal(t, shippings[0], quotes[0].Shipping)
+		assert.Equal(t, time.Hour, quotes[0].ExpiresAt.Sub(quotes[0].CreatedAt))
+	})
+
+	t.Run("should hire the quoted shipping", func(t *testing.T) {
+		pkg, err := domain.NewPackage("Test Product", "SP", 2.0, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+		quote := domain.NewQuote(pkg.ID, vo.NewShippingQuote("Active Carrier", "active", vo.NewMoney(1500), 5), time.Hour, time.Now())
+
+		err = service.HireQuote(pkg, quote, "checkout")
+
+		require.NoError(t, err)
+		assert.Equal(t, vo.NewMoney(1500), pkg.Shipping.EstimatedPrice)
+		assert.Equal(t, domain.StatusWaitingPickup, pkg.Status)
+	})
+
+	t.Run("should fail when the carrier was deactivated after the quote", func(t *testing.T) {
+		pkg, err := domain.NewPackage("Test Product", "SP", 2.0, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+		quote := domain.NewQuote(pkg.ID, vo.NewShippingQuote("Inactive Carrier", "inactive", vo.NewMoney(1600), 3), time.Hour, time.Now())
+
+		err = service.HireQuote(pkg, quote, "checkout")
+
+		assert.ErrorContains(t, err, "Carrier is inactive")
+		assert.Nil(t, pkg.Shipping)
+	})
+}
+
+func TestPackageService_RemoteCarriers(t *testing.T) {
+	healthy := carriertest.NewServer(6.0, 3)
+	defer healthy.Close()
+	slow := carriertest.NewServer(1.0, 1)
+	defer slow.Close()
+	slow.SetDelay(time.Second)
+
+	carriers := []*integration.Carrier{
+		{ID: "healthy", Name: "Healthy Carrier", QuoteURL: healthy.URL, Regions: []integration.CarrierRegion{{Region: "sudeste", EstimatedDays: 1, PricePerKg: vo.NewMoney(100)}}},
+		{ID: "slow", Name: "Slow Carrier", QuoteURL: slow.URL, Regions: []integration.CarrierRegion{{Region: "sudeste", EstimatedDays: 1, PricePerKg: vo.NewMoney(100)}}},
+		{ID: "table", Name: "Table Carrier", Regions: []integration.CarrierRegion{{Region: "sudeste", EstimatedDays: 5, PricePerKg: vo.NewMoney(400)}}},
+	}
+	quoter := integration.NewCarrierQuoter(integration.NewHTTPQuoter(config.CarrierHTTP{Timeout: 100 * time.Millisecond}))
+	service := NewPackageService(&MockCarrierRepository{carriers: carriers}, quoter, 0, 0, nil)
+
+	t.Run("should report failing carriers apart from the quotes", func(t *testing.T) {
+		pkg, err := domain.NewPackage("Test Product", "SP", 2.0, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+
+		start := time.Now()
+		shippings, failures := service.QuoteAvailableShippings(context.Background(), pkg, FastestRanking{})
+
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+		require.Len(t, shippings, 2)
+		assert.Equal(t, vo.NewShippingQuote("Healthy Carrier", "healthy", vo.NewMoney(1200), 3), shippings[0])
+		assert.Equal(t, vo.NewShippingQuote("Table Carrier", "table", vo.NewMoney(800), 5), shippings[1])
+		assert.Equal(t, []vo.QuoteFailure{
+			{CarrierID: "slow", CarrierName: "Slow Carrier", Reason: "Carrier did not answer in time"},
+		}, failures)
+	})
+
+	t.Run("should report unavailable carrier on hire", func(t *testing.T) {
+		pkg, err := domain.NewPackage("Test Product", "SP", 2.0, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+
+		err = service.HireCarrier(context.Background(), pkg, "slow", "")
+
+		var appErr *apperr.AppErr
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusServiceUnavailable, appErr.Code)
+		assert.Nil(t, pkg.Shipping)
+		assert.Equal(t, domain.StatusCreated, pkg.Status)
+	})
+}
+
+func TestPackageService_ParallelQuotes(t *testing.T) {
+	servers := make([]*carriertest.Server, 3)
+	carriers := []*integration.Carrier{}
+	for i := range servers {
+		servers[i] = carriertest.NewServer(2.0, i+2)
+		defer servers[i].Close()
+		servers[i].SetDelay(150 * time.Millisecond)
+		carriers = append(carriers, &integration.Carrier{
+			ID:       fmt.Sprintf("remote%d", i),
+			Name:     fmt.Sprintf("Remote %d", i),
+			QuoteURL: servers[i].URL,
+			Regions:  []integration.CarrierRegion{{Region: "sudeste", EstimatedDays: 1, PricePerKg: vo.NewMoney(100)}},
+		})
+	}
+	hanging := carriertest.NewServer(1.0, 1)
+	defer hanging.Close()
+	hanging.SetDelay(time.Second)
+	carriers = append(carriers, &integration.Carrier{
+		ID:       "hanging",
+		Name:     "Hanging Carrier",
+		QuoteURL: hanging.URL,
+		Regions:  []integration.CarrierRegion{{Region: "sudeste", EstimatedDays: 1, PricePerKg: vo.NewMoney(100)}},
+	})
+
+	quoter := integration.NewCarrierQuoter(integration.NewHTTPQuoter(config.CarrierHTTP{Timeout: 2 * time.Second}))
+	service := NewPackageService(&MockCarrierRepository{carriers: carriers}, quoter, 300*time.Millisecond, 0, nil)
+
+	t.Run("should wait only for the slowest carrier within the deadline", func(t *testing.T) {
+		pkg, err := domain.NewPackage("Test Product", "SP", 1.0, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+
+		start := time.Now()
+		shippings, failures := service.QuoteAvailableShippings(context.Background(), pkg, FastestRanking{})
+		elapsed := time.Since(start)
+
+		// Quoting one carrier after the other would take at least 3 x 150ms plus the deadline
+		assert.Less(t, elapsed, 600*time.Millisecond)
+		require.Len(t, shippings, 3)
+		for i, shipping := range shippings {
+			assert.Equal(t, fmt.Sprintf("remote%d", i), shipping.CarrierID)
+		}
+		assert.Equal(t, []vo.QuoteFailure{
+			{CarrierID: "hanging", CarrierName: "Hanging Carrier", Reason: "Carrier did not answer in time"},
+		}, failures)
+	})
+
+	t.Run("should report every carrier when the caller gives up", func(t *testing.T) {
+		pkg, err := domain.NewPackage("Test Product", "SP", 1.0, domain.DestinationRegionSoutheast)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		shippings, failures := service.QuoteAvailableShippings(ctx, pkg, FastestRanking{})
+
+		assert.Empty(t, shippings)
+		require.Len(t, failures, 4)
+		for _, failure := range failures {
+			assert.Equal(t, "Quote canceled", failure.Reason)
+		}
+	})
+}
+
+func TestPackageService_Items(t *testing.T) {
+	server := carriertest.NewServer(5.0, 3)
+	defer server.Close()
+
+	carriers := []*integration.Carrier{
+		{ID: "remote", Name: "Remote Carrier", QuoteURL: server.URL, Regions: []integration.CarrierRegion{{Region: "sul", EstimatedDays: 3, PricePerKg: vo.NewMoney(500)}}},
+	}
+	quoter := integration.NewCarrierQuoter(integration.NewHTTPQuoter(config.CarrierHTTP{Timeout: time.Second}))
+	service := NewPackageService(&MockCarrierRepository{carriers: carriers}, quoter, 0, 0, nil)
+	items := []vo.Item{
+		{Description: "Camisa azul", Quantity: 2, UnitWeightKg: 0.3, UnitValue: vo.NewMoney(8990)},
+		{Description: "Calça jeans", Quantity: 1, UnitWeightKg: 0.7, UnitValue: vo.NewMoney(15950)},
+	}
+
+	t.Run("should weigh the package by its items and quote the declared value", func(t *testing.T) {
+		pkg, err := service.Create(&domain.Package{DestinationState: "PR", DestinationRegion: domain.DestinationRegionSouth, Items: items})
+		require.NoError(t, err)
+		assert.Equal(t, 1.3, pkg.WeightKg)
+		assert.Equal(t, "Camisa azul e mais 1 item", pkg.Product)
+
+		_, failures := service.QuoteAvailableShippings(context.Background(), pkg, FastestRanking{})
+
+		assert.Empty(t, failures)
+		received := server.Requests()[0]
+		assert.Equal(t, 1.3, received.WeightKg)
+		assert.Equal(t, 339.30, received.DeclaredValue)
+	})
+
+	t.Run("should require a weight without items", func(t *testing.T) {
+		_, err := service.Create(&domain.Package{Product: "Camisa", DestinationState: "PR", DestinationRegion: domain.DestinationRegionSouth})
+
+		var appErr *apperr.AppErr
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+		assert.Equal(t, "Package weight must be greater than zero", appErr.Message)
+	})
+}
+
+func TestPackageService_Insurance(t *testing.T) {
+	carriers := []*integration.Carrier{
+		{ID: "insured", Name: "Insured Carrier", Regions: []integration.CarrierRegion{{
+			Region:           "sul",
+			EstimatedDays:    4,
+			PricePerKg:       vo.NewMoney(590),
+			AdValoremPercent: 0.5,
+			MinimumInsurance: vo.NewMoney(200),
+			Surcharges:       []integration.Surcharge{{Name: "coleta", Amount: vo.NewMoney(490)}},
+		}}},
+	}
+	service := NewPackageService(&MockCarrierRepository{carriers: carriers}, integration.TableQuoter{}, 0, 0, nil)
+
+	t.Run("should charge the insurance of the declared value", func(t *testing.T) {
+		pkg, err := service.Create(&domain.Package{Product: "Notebook", WeightKg: 2, DestinationState: "PR", DestinationRegion: domain.DestinationRegionSouth, DeclaredValue: vo.NewMoney(179990)})
+		require.NoError(t, err)
+
+		shippings, failures := service.QuoteAvailableShippings(context.Background(), pkg, FastestRanking{})
+
+		assert.Empty(t, failures)
+		require.Len(t, shippings, 1)
+		assert.Equal(t, vo.PriceBreakdown{
+			Freight:    vo.NewMoney(1180),
+			Insurance:  vo.NewMoney(900),
+			Surcharges: []vo.Surcharge{{Name: "coleta", Amount: vo.NewMoney(490)}},
+		}, shippings[0].Breakdown)
+		assert.Equal(t, vo.NewMoney(2570), shippings[0].EstimatedPrice)
+	})
+
+	t.Run("should not insure packages without declared value", func(t *testing.T) {
+		pkg, err := service.Create(&domain.Package{Product: "Camisa", WeightKg: 2, DestinationState: "PR", DestinationRegion: domain.DestinationRegionSouth})
+		require.NoError(t, err)
+
+		shippings, _ := service.QuoteAvailableShippings(context.Background(), pkg, FastestRanking{})
+
+		require.Len(t, shippings, 1)
+		assert.True(t, shippings[0].Breakdown.Insurance.IsZero())
+		assert.Equal(t, vo.NewMoney(1670), shippings[0].EstimatedPrice)
+	})
+
+	t.Run("should reject a declared value below the items", func(t *testing.T) {
+		items := []vo.Item{{Description: "Camisa azul", Quantity: 2, UnitWeightKg: 0.3, UnitValue: vo.NewMoney(8990)}}
+
+		_, err := service.Create(&domain.Package{DestinationState: "PR", DestinationRegion: domain.DestinationRegionSouth, Items: items, DeclaredValue: vo.NewMoney(10000)})
+
+		var appErr *apperr.AppErr
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+		assert.Equal(t, "Declared value R$ 100,00 is less than the items value R$ 179,80", appErr.Message)
+	})
 }
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain"
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
+	apperr "github.com/foliveiracamara/delivery-manager-api/internal/shared/apperror"
+)
+
+const (
+	RankFastest   = "fastest"
+	RankCheapest  = "cheapest"
+	RankBestValue = "best_value"
+	RankReliable  = "reliable"
+)
+
+// DefaultBestValuePriceWeight é o peso do preço no custo-benefício; o prazo fica com o restante
+const DefaultBestValuePriceWeight = 0.5
+
+// QuoteRanking ordena as cotações conforme a prioridade do cliente. Empates são
+// desfeitos pelo menor preço e depois pelo ID da transportadora, para que a mesma
+// cotação sempre produza a mesma ordem.
+type QuoteRanking interface {
+	Rank(shippings []vo.Shipping)
+}
+
+// ParseQuoteRanking escolhe a ordenação pelo nome; vazio ordena pelo menor prazo
+func ParseQuoteRanking(name string) (QuoteRanking, error) {
+	switch name {
+	case "", RankFastest:
+		return FastestRanking{}, nil
+	case RankCheapest:
+		return CheapestRanking{}, nil
+	case RankBestValue:
+		return BestValueRanking{PriceWeight: DefaultBestValuePriceWeight}, nil
+	case RankReliable:
+		return ReliableRanking{}, nil
+	}
+
+	allowed := strings.Join([]string{RankFastest, RankCheapest, RankBestValue, RankReliable}, ", ")
+	return nil, apperr.NewBadRequestError("Invalid sort: " + name + ". Allowed: " + allowed)
+}
+
+// FastestRanking ordena pelo menor prazo de entrega
+type FastestRanking struct{}
+
+func (FastestRanking) Rank(shippings []vo.Shipping) {
+	slices.SortFunc(shippings, func(a, b vo.Shipping) int {
+		return cmp.Or(cmp.Compare(a.EstimatedDays, b.EstimatedDays), breakTie(a, b))
+	})
+}
+
+// CheapestRanking ordena pelo menor preço
+type CheapestRanking struct{}
+
+func (CheapestRanking) Rank(shippings []vo.Shipping) {
+	slices.SortFunc(shippings, breakTie)
+}
+
+// BestValueRanking ordena pelo custo-benefício: cada cotação é comparada com o menor
+// preço e o menor prazo entre as cotações, e a pontuação pondera as duas proporções.
+// Com peso 0,5, uma cotação de 2 dias 20% mais cara que outra de 4 dias
+// (1,2 × 0,5 + 1,0 × 0,5 = 1,1) fica à frente dela (1,0 × 0,5 + 2,0 × 0,5 = 1,5).
+type BestValueRanking struct {
+	// PriceWeight é o peso do preço, entre 0 e 1; o prazo tem peso 1 - PriceWeight
+	PriceWeight float64
+}
+
+func (r BestValueRanking) Rank(shippings []vo.Shipping) {
+	if len(shippings) == 0 {
+		return
+	}
+
+	cheapest, fastest := shippings[0].EstimatedPrice, shippings[0].EstimatedDays
+	for _, shipping := range shippings[1:] {
+		if shipping.EstimatedPrice.Compare(cheapest) < 0 {
+			cheapest = shipping.EstimatedPrice
+		}
+		fastest = min(fastest, shipping.EstimatedDays)
+	}
+
+	score := func(s vo.Shipping) float64 {
+		return r.PriceWeight*ratio(float64(s.EstimatedPrice.Cents()), float64(cheapest.Cents())) +
+			(1-r.PriceWeight)*ratio(float64(s.EstimatedDays), float64(fastest))
+	}
+
+	slices.SortFunc(shippings, func(a, b vo.Shipping) int {
+		return cmp.Or(cmp.Compare(score(a), score(b)), breakTie(a, b))
+	})
+}
+
+// ReliableRanking ordena pela nota de confiabilidade da transportadora, calculada pelo
+// histórico de entregas, e depois pelo menor prazo. Transportadoras sem nota recebem a
+// nota de quem não tem histórico.
+type ReliableRanking struct {
+	// Scores é a nota de cada transportadora pelo ID, entre 0 e 1
+	Scores map[string]float64
+}
+
+func (r ReliableRanking) Rank(shippings []vo.Shipping) {
+	score := func(s vo.Shipping) float64 {
+		if score, ok := r.Scores[s.CarrierID]; ok {
+			return score
+		}
+		return domain.CarrierMetrics{}.ReliabilityScore()
+	}
+
+	slices.SortFunc(shippings, func(a, b vo.Shipping) int {
+		return cmp.Or(cmp.Compare(score(b), score(a)), cmp.Compare(a.EstimatedDays, b.EstimatedDays), breakTie(a, b))
+	})
+}
+
+// ratio mede quanto o valor está acima do melhor; sem referência positiva, todos empatam
+func ratio(value, best float64) float64 {
+	if best <= 0 {
+		return 1
+	}
+	return value / best
+}
+
+// breakTie desempata pelo menor preço e depois pelo ID da transportadora
+func breakTie(a, b vo.Shipping) int {
+	return cmp.Or(a.EstimatedPrice.Compare(b.EstimatedPrice), strings.Compare(a.CarrierID, b.CarrierID))
+}
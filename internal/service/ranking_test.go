@@ -0,0 +1,129 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/foliveiracamara/delivery-manager-api/internal/domain/vo"
+	apperr "github.com/foliveiracamara/delivery-manager-api/internal/shared/apperror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func carrierIDs(shippings []vo.Shipping) []string {
+	ids := make([]string, len(shippings))
+	for i, shipping := range shippings {
+		ids[i] = shipping.CarrierID
+	}
+	return ids
+}
+
+func TestQuoteRanking(t *testing.T) {
+	quotes := func() []vo.Shipping {
+		return []vo.Shipping{
+			vo.NewShippingQuote("Slow", "slow", vo.NewMoney(2000), 10),
+			vo.NewShippingQuote("Express", "express", vo.NewMoney(8000), 1),
+			vo.NewShippingQuote("Standard", "standard", vo.NewMoney(2400), 3),
+			vo.NewShippingQuote("Economy", "economy", vo.NewMoney(2000), 6),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		sort     string
+		expected []string
+	}{
+		{name: "fastest by default", sort: "", expected: []string{"express", "standard", "economy", "slow"}},
+		{name: "fastest", sort: RankFastest, expected: []string{"express", "standard", "economy", "slow"}},
+		{name: "cheapest breaks ties by carrier ID", sort: RankCheapest, expected: []string{"economy", "slow", "standard", "express"}},
+		// preço/menor preço × 0,5 + prazo/menor prazo × 0,5:
+		// standard 1,2 × 0,5 + 3 × 0,5 = 2,1; express 4,0 × 0,5 + 1 × 0,5 = 2,5
+		// economy 1,0 × 0,5 + 6 × 0,5 = 3,5; slow 1,0 × 0,5 + 10 × 0,5 = 5,5
+		{name: "best value", sort: RankBestValue, expected: []string{"standard", "express", "economy", "slow"}},
+		{name: "reliable without history is fastest", sort: RankReliable, expected: []string{"express", "standard", "economy", "slow"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranking, err := ParseQuoteRanking(tt.sort)
+			require.NoError(t, err)
+
+			shippings := quotes()
+			ranking.Rank(shippings)
+
+			assert.Equal(t, tt.expected, carrierIDs(shippings))
+		})
+	}
+
+	t.Run("should reject an unknown sort", func(t *testing.T) {
+		_, err := ParseQuoteRanking("nearest")
+
+		var appErr *apperr.AppErr
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+		assert.Equal(t, "Invalid sort: nearest. Allowed: fastest, cheapest, best_value, reliable", appErr.Message)
+	})
+}
+
+func TestFastestRanking_BreaksTiesByPriceThenCarrierID(t *testing.T) {
+	shippings := []vo.Shipping{
+		vo.NewShippingQuote("Zeta", "zeta", vo.NewMoney(2000), 4),
+		vo.NewShippingQuote("Pricey", "pricey", vo.NewMoney(2500), 4),
+		vo.NewShippingQuote("Alfa", "alfa", vo.NewMoney(2000), 4),
+	}
+
+	FastestRanking{}.Rank(shippings)
+
+	assert.Equal(t, []string{"alfa", "zeta", "pricey"}, carrierIDs(shippings))
+}
+
+func TestReliableRanking(t *testing.T) {
+	shippings := []vo.Shipping{
+		vo.NewShippingQuote("Express", "express", vo.NewMoney(8000), 1),
+		vo.NewShippingQuote("Newcomer", "newcomer", vo.NewMoney(2000), 6),
+		vo.NewShippingQuote("Steady", "steady", vo.NewMoney(2400), 3),
+		vo.NewShippingQuote("Careful", "careful", vo.NewMoney(2600), 5),
+	}
+
+	ReliableRanking{Scores: map[string]float64{
+		"express": 0.25,
+		"steady":  0.9,
+		"careful": 0.9,
+	}}.Rank(shippings)
+
+	// sem histórico, newcomer fica com 0,5, entre as confiáveis e a express
+	assert.Equal(t, []string{"steady", "careful", "newcomer", "express"}, carrierIDs(shippings))
+}
+
+func TestBestValueRanking_PriceWeight(t *testing.T) {
+	quotes := func() []vo.Shipping {
+		return []vo.Shipping{
+			vo.NewShippingQuote("Cheap", "cheap", vo.NewMoney(2000), 8),
+			vo.NewShippingQuote("Fast", "fast", vo.NewMoney(3000), 2),
+		}
+	}
+
+	tests := []struct {
+		name        string
+		priceWeight float64
+		expected    []string
+	}{
+		{name: "only price", priceWeight: 1, expected: []string{"cheap", "fast"}},
+		{name: "only delivery time", priceWeight: 0, expected: []string{"fast", "cheap"}},
+		// cheap 1,0 × 0,9 + 4,0 × 0,1 = 1,3; fast 1,5 × 0,9 + 1,0 × 0,1 = 1,45
+		{name: "price heavy", priceWeight: 0.9, expected: []string{"cheap", "fast"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shippings := quotes()
+			BestValueRanking{PriceWeight: tt.priceWeight}.Rank(shippings)
+
+			assert.Equal(t, tt.expected, carrierIDs(shippings))
+		})
+	}
+
+	t.Run("should keep an empty list", func(t *testing.T) {
+		assert.NotPanics(t, func() { BestValueRanking{PriceWeight: 0.5}.Rank(nil) })
+	})
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"maps"
+	"sync"
+	"time"
+)
+
+// ReliabilityScores guarda as notas de confiabilidade das transportadoras calculadas pela
+// verificação periódica, para que a ordenação reliable não percorra o histórico dos
+// pacotes a cada cotação. Notas calculadas há mais de ttl são descartadas e, até o
+// próximo cálculo, as transportadoras são ordenadas como sem histórico; ttl 0 mantém as
+// notas até o próximo cálculo. É seguro para uso concorrente.
+type ReliabilityScores struct {
+	mu         sync.RWMutex
+	ttl        time.Duration
+	scores     map[string]float64
+	computedAt time.Time
+}
+
+func NewReliabilityScores(ttl time.Duration) *ReliabilityScores {
+	return &ReliabilityScores{
+		ttl: ttl,
+	}
+}
+
+// Update substitui as notas pelas calculadas em computedAt
+func (s *ReliabilityScores) Update(scores map[string]float64, computedAt time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.scores = maps.Clone(scores)
+	s.computedAt = computedAt
+}
+
+// Ranking retorna a ordenação reliable com as notas ainda válidas em now
+func (s *ReliabilityScores) Ranking(now time.Time) ReliableRanking {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.ttl > 0 && now.Sub(s.computedAt) > s.ttl {
+		return ReliableRanking{}
+	}
+	return ReliableRanking{Scores: s.scores}
+}
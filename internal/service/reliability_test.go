@@ -0,0 +1,37 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReliabilityScores_Ranking(t *testing.T) {
+	computedAt := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	scores := map[string]float64{"steady": 0.9}
+
+	tests := []struct {
+		name     string
+		ttl      time.Duration
+		now      time.Time
+		expected map[string]float64
+	}{
+		{name: "fresh scores", ttl: time.Hour, now: computedAt.Add(30 * time.Minute), expected: scores},
+		{name: "scores older than the ttl", ttl: time.Hour, now: computedAt.Add(2 * time.Hour), expected: nil},
+		{name: "no ttl", ttl: 0, now: computedAt.Add(48 * time.Hour), expected: scores},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reliability := NewReliabilityScores(tt.ttl)
+			reliability.Update(scores, computedAt)
+
+			assert.Equal(t, tt.expected, reliability.Ranking(tt.now).Scores)
+		})
+	}
+
+	t.Run("should rank without scores before the first update", func(t *testing.T) {
+		assert.Empty(t, NewReliabilityScores(time.Hour).Ranking(computedAt).Scores)
+	})
+}
@@ -41,6 +41,14 @@ func NewConflictError(message string) *AppErr {
 	}
 }
 
+func NewPreconditionFailedError(message string) *AppErr {
+	return &AppErr{
+		Message: message,
+		Err:     "precondition_failed",
+		Code:    http.StatusPreconditionFailed,
+	}
+}
+
 func NewBadRequestValidationError(message string, causes []Causes) *AppErr {
 	return &AppErr{
 		Message: message,
@@ -49,6 +57,14 @@ func NewBadRequestValidationError(message string, causes []Causes) *AppErr {
 	}
 }
 
+func NewGoneError(message string) *AppErr {
+	return &AppErr{
+		Message: message,
+		Err:     "gone",
+		Code:    http.StatusGone,
+	}
+}
+
 func NewInternalServerError(message string) *AppErr {
 	return &AppErr{
 		Message: message,
@@ -65,10 +81,25 @@ func NewNotFoundError(message string) *AppErr {
 	}
 }
 
+func NewServiceUnavailableError(message string) *AppErr {
+	return &AppErr{
+		Message: message,
+		Err:     "service_unavailable",
+		Code:    http.StatusServiceUnavailable,
+	}
+}
+
 func NewUnauthorizedError(message string) *AppErr {
 	return &AppErr{
 		Message: message,
 		Err:     "unauthorized",
 		Code:    http.StatusUnauthorized,
 	}
-}
\ No newline at end of file
+}
+func NewUnprocessableEntityError(message string) *AppErr {
+	return &AppErr{
+		Message: message,
+		Err:     "unprocessable_entity",
+		Code:    http.StatusUnprocessableEntity,
+	}
+}